@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"slices"
 
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/assertion"
@@ -38,6 +39,16 @@ const _doc = "Read the assertions and annotations from this package as Results f
 	" against each other to obtain a list of triggered assertions that a later analyzer will report" +
 	" as errors"
 
+// InternalErrorCategory is the category of the diagnostics reporting the internal errors of
+// NilAway (e.g., panics on unexpected AST shapes), such that the upper-level analyzers can tell
+// them apart from the potential nil panics (e.g., to fail the analysis instead).
+const InternalErrorCategory = "nilaway/internal-error"
+
+// IncompleteInferenceCategory is the category of the diagnostics noting that the inference of a
+// package stopped early since it exceeded the bound set by the `max-inference-iterations` flag,
+// hence the errors reported for the package may be incomplete.
+const IncompleteInferenceCategory = "nilaway/incomplete-inference"
+
 // Analyzer here is the accumulator that combines assertions and annotations to generate a list of
 // triggered assertions that will become errors in the next Analyzer
 var Analyzer = &analysis.Analyzer{
@@ -66,6 +77,10 @@ var Analyzer = &analysis.Analyzer{
 // possibly determine upstream values but cannot determine the already-determined local
 // values) and report errors if there are any.
 //
+// - Mode inference.DefiniteOnly: No annotations and no inference
+// We only report the assertions that fail regardless of the nilability of any annotation sites
+// (e.g., dereferences of literal nils), without running the inference engine at all.
+//
 // - Mode inference.FullInfer: Multi-Package Inference
 // Assertions are observed one by one to determine any further sites that must be determined from
 // this package's constraints. This is the extent of determination done, and all remaining
@@ -82,7 +97,7 @@ func run(pass *analysis.Pass) (result interface{}, _ error) {
 			// Deferred functions are executed after a result is generated, so here we modify the
 			// return value `result` in-place.
 			// Diagnostics with invalid positions (<= 0) will be silently suppressed, so here we use 1.
-			d := analysis.Diagnostic{Pos: 1, Message: fmt.Sprintf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))}
+			d := analysis.Diagnostic{Pos: 1, Category: InternalErrorCategory, Message: fmt.Sprintf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))}
 			if diagnostics, ok := result.([]analysis.Diagnostic); ok {
 				result = append(diagnostics, d)
 			} else {
@@ -104,10 +119,24 @@ func run(pass *analysis.Pass) (result interface{}, _ error) {
 		// errors. However, in the future we could implement error recovery and make use of the partial
 		// information to continue the analysis.
 		// Diagnostics with invalid positions (<= 0) will be silently suppressed, so here we use 1.
-		return []analysis.Diagnostic{{Pos: 1, Message: fmt.Sprintf("INTERNAL ERROR(s):\n%s", err)}}, nil
+		return []analysis.Diagnostic{{Pos: 1, Category: InternalErrorCategory, Message: fmt.Sprintf("INTERNAL ERROR(s):\n%s", err)}}, nil
 	}
 
 	diagnosticEngine := diagnostic.NewEngine(pass)
+	if conf.WithHints {
+		diagnosticEngine.EnableHints()
+	}
+	if conf.RootInScopeOnly {
+		diagnosticEngine.EnableRootInScopeOnly(conf)
+	}
+	if conf.KeepExplanationsInGroups {
+		diagnosticEngine.EnableExplanationsInGroups()
+	}
+	if conf.ReportOncePerSite {
+		diagnosticEngine.EnableReportOncePerSite()
+	}
+	diagnosticEngine.SetDiscardedErrors(conf.DiscardedErrors)
+	diagnosticEngine.SetMinConfidence(conf.MinConfidence)
 
 	// Create an inference engine and observe (load) information from upstream dependencies (i.e.,
 	// mappings between annotation sites and their inferred values).
@@ -130,9 +159,52 @@ func run(pass *analysis.Pass) (result interface{}, _ error) {
 		// Incorporate assertions from this package one-by-one into the inferredAnnotationMap, possibly
 		// determining local and upstream sites in the process. This is guaranteed not to determine any
 		// sites unless we really have a reason they have to be determined.
+		// ObservePackage filters the triggers in place, so we keep a copy of the original
+		// triggers for checking the annotations later.
+		var triggers []annotation.FullTrigger
+		if conf.WarnRedundantAnnotations || conf.SuggestRelaxAnnotations || conf.APILint || conf.NilabilityReport {
+			triggers = slices.Clone(assertionsResult.Res)
+		}
+		inferenceEngine.SetMaxIterations(conf.MaxInferenceIterations)
 		inferenceEngine.ObservePackage(assertionsResult.Res)
 		inferredMap = inferenceEngine.InferredMap()
+		if conf.WarnRedundantAnnotations {
+			for _, r := range inferenceEngine.RedundantAnnotations(annotationsResult.Res, triggers) {
+				diagnosticEngine.AddRedundantAnnotation(r)
+			}
+		}
+		if conf.SuggestRelaxAnnotations {
+			for _, r := range inferenceEngine.RelaxableAnnotations(annotationsResult.Res, triggers) {
+				diagnosticEngine.AddRelaxableAnnotation(r)
+			}
+		}
+		if conf.APILint {
+			// In API lint mode, the potential nil panics are not reported, and only the violations
+			// of the annotations on the exported API are.
+			diagnosticEngine.DiscardConflicts()
+			for _, v := range inferenceEngine.ContractViolations(annotationsResult.Res, triggers) {
+				diagnosticEngine.AddContractViolation(v)
+			}
+		}
+		if conf.ApplyInferredAnnotations {
+			for _, a := range inferenceEngine.InferredAnnotations(annotationsResult.Res) {
+				diagnosticEngine.AddInferredAnnotation(a)
+			}
+		}
+		if conf.NilabilityReport {
+			for _, r := range inferenceEngine.NilabilityReport(triggers) {
+				diagnosticEngine.AddNilabilityReport(r)
+			}
+		}
 		diagnostics = diagnosticEngine.Diagnostics(conf.GroupErrorMessages)
+		if inferenceEngine.IterationsExceeded() && len(pass.Files) > 0 {
+			diagnostics = append(diagnostics, analysis.Diagnostic{
+				Pos:      pass.Files[0].Package,
+				Category: IncompleteInferenceCategory,
+				Message: fmt.Sprintf("inference of package %q stopped after %d iterations (see -%s), the errors reported for it may be incomplete",
+					pass.Pkg.Path(), conf.MaxInferenceIterations, config.MaxInferenceIterationsFlag),
+			})
+		}
 
 	case inference.NoInfer:
 		// In non-inference case - use the classical assertionNode.CheckErrors method to determine error outputs
@@ -142,6 +214,11 @@ func run(pass *analysis.Pass) (result interface{}, _ error) {
 		// diagnostics for easier unit testing.
 		diagnostics = diagnosticEngine.Diagnostics(false /* grouping */)
 
+	case inference.DefiniteOnly:
+		inferredMap = inferenceEngine.InferredMap()
+		checkDefiniteErrors(assertionsResult.Res, diagnosticEngine)
+		diagnostics = diagnosticEngine.Diagnostics(conf.GroupErrorMessages)
+
 	default:
 		panic("Invalid mode for running NilAway")
 	}
@@ -160,6 +237,22 @@ func run(pass *analysis.Pass) (result interface{}, _ error) {
 	return diagnostics, nil
 }
 
+// checkDefiniteErrors iterates over a set of full triggers and reports the ones that fail
+// regardless of the nilability of any annotation sites, i.e., the ones whose producers always
+// produce nil values and whose consumers always consume nonnil values.
+func checkDefiniteErrors(triggers []annotation.FullTrigger, diagnosticEngine conflictHandler) {
+	for _, trigger := range triggers {
+		// Controlled triggers and the ones duplicated from contracted functions are only
+		// meaningful for inference.
+		if trigger.Controlled() || trigger.CreatedFromDuplication {
+			continue
+		}
+		if trigger.Producer.Annotation.Kind() == annotation.Always && trigger.Consumer.Annotation.Kind() == annotation.Always {
+			diagnosticEngine.AddSingleAssertionConflict(trigger)
+		}
+	}
+}
+
 type conflictHandler interface {
 	AddSingleAssertionConflict(trigger annotation.FullTrigger)
 }
@@ -398,6 +398,46 @@ func (p PtrLoadPrestring) String() string {
 	return sb.String()
 }
 
+// FuncValueCall is when a function value (e.g., a variable of a function type, or a function read
+// from a map) flows to a point where it is called, and thus must be non-nil
+type FuncValueCall struct {
+	*ConsumeTriggerTautology
+}
+
+// equals returns true if the passed ConsumingAnnotationTrigger is equal to this one
+func (f *FuncValueCall) equals(other ConsumingAnnotationTrigger) bool {
+	if other, ok := other.(*FuncValueCall); ok {
+		return f.ConsumeTriggerTautology.equals(other.ConsumeTriggerTautology)
+	}
+	return false
+}
+
+// Copy returns a deep copy of this ConsumingAnnotationTrigger
+func (f *FuncValueCall) Copy() ConsumingAnnotationTrigger {
+	copyConsumer := *f
+	copyConsumer.ConsumeTriggerTautology = f.ConsumeTriggerTautology.Copy().(*ConsumeTriggerTautology)
+	return &copyConsumer
+}
+
+// Prestring returns this FuncValueCall as a Prestring
+func (f *FuncValueCall) Prestring() Prestring {
+	return FuncValueCallPrestring{
+		AssignmentStr: f.assignmentFlow.String(),
+	}
+}
+
+// FuncValueCallPrestring is a Prestring storing the needed information to compactly encode a FuncValueCall
+type FuncValueCallPrestring struct {
+	AssignmentStr string
+}
+
+func (f FuncValueCallPrestring) String() string {
+	var sb strings.Builder
+	sb.WriteString("called as a function")
+	sb.WriteString(f.AssignmentStr)
+	return sb.String()
+}
+
 // MapAccess is when a map value flows to a point where it is indexed, and thus must be non-nil
 //
 // note: this trigger is produced only if config.ErrorOnNilableMapRead == true
@@ -835,9 +875,16 @@ func (a *ArgPass) Prestring() Prestring {
 			Location:      key.Location.String(),
 			AssignmentStr: a.assignmentFlow.String(),
 		}
+	case *FuncTypeParamAnnotationKey:
+		return ArgPassPrestring{
+			ParamName:     key.MinimalString(),
+			FuncName:      key.TypeDecl.Name(),
+			Location:      "",
+			AssignmentStr: a.assignmentFlow.String(),
+		}
 	default:
 		panic(fmt.Sprintf(
-			"Expected ParamAnnotationKey or CallSiteParamAnnotationKey but got: %T", key))
+			"Expected ParamAnnotationKey, CallSiteParamAnnotationKey or FuncTypeParamAnnotationKey but got: %T", key))
 	}
 }
 
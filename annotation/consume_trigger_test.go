@@ -28,6 +28,7 @@ var initStructsConsumingAnnotationTrigger = []any{
 	&TriggerIfDeepNonNil{Ann: newMockKey()},
 	&ConsumeTriggerTautology{},
 	&PtrLoad{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
+	&FuncValueCall{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&MapAccess{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&MapWrittenTo{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
 	&SliceAccess{ConsumeTriggerTautology: &ConsumeTriggerTautology{}},
@@ -385,6 +385,108 @@ func RetKeyFromRetNum(fdecl *types.Func, retNum int) *RetAnnotationKey {
 	}
 }
 
+// FuncTypeParamAnnotationKey allows the Lookup of a parameter's Annotation of a named function type
+// (e.g., `type Handler func(*Request) *Response`) in the Annotation Map, which is shared by all the
+// calls through values of that type
+type FuncTypeParamAnnotationKey struct {
+	TypeDecl *types.TypeName
+	ParamNum int
+}
+
+// FuncTypeParamKeyFromArgNum returns a new instance of FuncTypeParamAnnotationKey, where the
+// argument number is "rounded down" to the variadic parameter for variadic function types
+func FuncTypeParamKeyFromArgNum(tdecl *types.TypeName, num int) *FuncTypeParamAnnotationKey {
+	sig := tdecl.Type().Underlying().(*types.Signature)
+	if sig.Variadic() && num >= sig.Params().Len()-1 {
+		num = sig.Params().Len() - 1
+	}
+	return &FuncTypeParamAnnotationKey{
+		TypeDecl: tdecl,
+		ParamNum: num,
+	}
+}
+
+// ParamName returns the *types.Var naming the parameter associate with this key
+func (pk *FuncTypeParamAnnotationKey) ParamName() *types.Var {
+	return pk.TypeDecl.Type().Underlying().(*types.Signature).Params().At(pk.ParamNum)
+}
+
+// Lookup looks this key up in the passed map, returning a Val
+func (pk *FuncTypeParamAnnotationKey) Lookup(annMap Map) (Val, bool) {
+	if paramVal, ok := annMap.CheckFuncTypeParamAnn(pk.TypeDecl, pk.ParamNum); ok {
+		return paramVal, true
+	}
+	return nonAnnotatedDefault, false
+}
+
+// Object returns the types.Object that this annotation can best be interpreted as annotating
+func (pk *FuncTypeParamAnnotationKey) Object() types.Object {
+	return pk.TypeDecl
+}
+
+// equals returns true if the passed key is equal to this key
+func (pk *FuncTypeParamAnnotationKey) equals(other Key) bool {
+	if other, ok := other.(*FuncTypeParamAnnotationKey); ok {
+		return *pk == *other
+	}
+	return false
+}
+
+func (pk *FuncTypeParamAnnotationKey) copy() Key {
+	copyKey := *pk
+	return &copyKey
+}
+
+func (pk *FuncTypeParamAnnotationKey) String() string {
+	return fmt.Sprintf("Param %d of Func Type %s", pk.ParamNum, pk.TypeDecl.Name())
+}
+
+// MinimalString returns a string representation for this FuncTypeParamAnnotationKey consisting
+// only of the word "arg" followed by the name of the parameter, if named, or its position otherwise
+func (pk *FuncTypeParamAnnotationKey) MinimalString() string {
+	if name := pk.ParamName().Name(); name != "" && name != "_" {
+		return fmt.Sprintf("arg `%s`", name)
+	}
+	return fmt.Sprintf("arg %d", pk.ParamNum)
+}
+
+// FuncTypeRetAnnotationKey allows the Lookup of a result's Annotation of a named function type in
+// the Annotation Map, which is shared by all the calls through values of that type
+type FuncTypeRetAnnotationKey struct {
+	TypeDecl *types.TypeName
+	RetNum   int // which result
+}
+
+// Lookup looks this key up in the passed map, returning a Val
+func (rk *FuncTypeRetAnnotationKey) Lookup(annMap Map) (Val, bool) {
+	if retVal, ok := annMap.CheckFuncTypeRetAnn(rk.TypeDecl, rk.RetNum); ok {
+		return retVal, true
+	}
+	return nonAnnotatedDefault, false
+}
+
+// Object returns the types.Object that this annotation can best be interpreted as annotating
+func (rk *FuncTypeRetAnnotationKey) Object() types.Object {
+	return rk.TypeDecl
+}
+
+// equals returns true if the passed key is equal to this key
+func (rk *FuncTypeRetAnnotationKey) equals(other Key) bool {
+	if other, ok := other.(*FuncTypeRetAnnotationKey); ok {
+		return *rk == *other
+	}
+	return false
+}
+
+func (rk *FuncTypeRetAnnotationKey) copy() Key {
+	copyKey := *rk
+	return &copyKey
+}
+
+func (rk *FuncTypeRetAnnotationKey) String() string {
+	return fmt.Sprintf("Result %d of Func Type %s", rk.RetNum, rk.TypeDecl.Name())
+}
+
 // TypeNameAnnotationKey allows the Lookup of a named type annotations in the Annotation Map
 type TypeNameAnnotationKey struct {
 	TypeDecl *types.TypeName
@@ -29,6 +29,8 @@ var initStructsKey = []any{
 	&ParamAnnotationKey{},
 	&CallSiteRetAnnotationKey{},
 	&RetAnnotationKey{},
+	&FuncTypeParamAnnotationKey{},
+	&FuncTypeRetAnnotationKey{},
 	&TypeNameAnnotationKey{},
 	&GlobalVarAnnotationKey{},
 	&RecvAnnotationKey{},
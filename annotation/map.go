@@ -24,6 +24,7 @@ import (
 
 	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util"
+	"go.uber.org/nilaway/util/asthelper"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -37,6 +38,8 @@ type Map interface {
 	CheckGlobalVarAnn(*types.Var) (Val, bool)
 	CheckFuncCallSiteParamAnn(*CallSiteParamAnnotationKey) (Val, bool)
 	CheckFuncCallSiteRetAnn(*CallSiteRetAnnotationKey) (Val, bool)
+	CheckFuncTypeParamAnn(*types.TypeName, int) (Val, bool)
+	CheckFuncTypeRetAnn(*types.TypeName, int) (Val, bool)
 }
 
 // Val is a possible value of an Annotation
@@ -153,6 +156,13 @@ type ObservedMap struct {
 	// this maps named types to annotations describing their deep nilability
 	deepTypeAnnMap map[*types.TypeName]Val
 
+	// this maps named function types (e.g., `type Handler func(*Request) *Response`) to a slice
+	// with the annotations of their params, shared by all the values of those types
+	funcTypeParamAnnMap map[*types.TypeName][]Val
+
+	// this maps named function types to a slice with the annotations of their results
+	funcTypeRetAnnMap map[*types.TypeName][]Val
+
 	// this maps declarations of global variables to their annotations
 	globalVarsAnnMap map[*types.Var]Val
 
@@ -219,6 +229,18 @@ func (m *ObservedMap) Range(op func(key Key, isDeep bool, val bool), setSitesOnl
 		callOpOnKeyVal(&GlobalVarAnnotationKey{VarDecl: gvar}, val)
 	}
 
+	for tdecl, vals := range m.funcTypeParamAnnMap {
+		for i, val := range vals {
+			callOpOnKeyVal(&FuncTypeParamAnnotationKey{TypeDecl: tdecl, ParamNum: i}, val)
+		}
+	}
+
+	for tdecl, vals := range m.funcTypeRetAnnMap {
+		for i, val := range vals {
+			callOpOnKeyVal(&FuncTypeRetAnnotationKey{TypeDecl: tdecl, RetNum: i}, val)
+		}
+	}
+
 	for callSite, vals := range m.funcCallSiteParamAnnMap {
 		for i, argLocAndVal := range vals {
 			// the location inside the callSite is the location of the call expression, we want
@@ -241,10 +263,11 @@ var (
 	nonAnnotatedDefault = EmptyVal
 )
 
-const nilableKeyword = "nilable"
-const nonNilKeyword = "nonnil"
+const nilableKeyword = config.NilableKeyword
+const nonNilKeyword = config.NonNilKeyword
+const nonEmptyKeyword = config.NonEmptyKeyword
 
-var annotationKeyword = fmt.Sprintf("(%s|%s)", nilableKeyword, nonNilKeyword)
+var annotationKeyword = fmt.Sprintf("(%s|%s|%s)", nilableKeyword, nonNilKeyword, nonEmptyKeyword)
 
 const sep = ","
 const identRegexStr = "[a-zA-Z][a-zA-Z0-9]*"
@@ -274,11 +297,40 @@ var seqRegexStr = fmt.Sprintf("%s\\((\\s*%s\\s*(%s\\s*%s\\s*)*)\\)",
 	annotationKeyword, deepIdentRegexStr, sep, deepIdentRegexStr)
 var seqRegex = regexp.MustCompile(seqRegexStr)
 
+// aliasRegex matches an identifier immediately followed by an opening parenthesis, i.e., a
+// candidate for an aliased annotation keyword such as `opt(`.
+var aliasRegex = regexp.MustCompile(identRegexStr + "\\(")
+
+// expandAliases replaces the aliased annotation keywords in the text with the real keywords they
+// are mapped to (e.g., `opt(x)` to `nilable(x)` for alias "opt=nilable").
+func expandAliases(text string, aliases map[string]string) string {
+	if len(aliases) == 0 {
+		return text
+	}
+	return aliasRegex.ReplaceAllStringFunc(text, func(s string) string {
+		if keyword, ok := aliases[strings.TrimSuffix(s, "(")]; ok {
+			return keyword + "("
+		}
+		return s
+	})
+}
+
 type nilabilitySet map[string]Val
 
-// from a CommentGroup return a nilabilitySet of which identifiers are known annotated nilable
-func nilabilityFromCommentGroup(group *ast.CommentGroup) nilabilitySet {
+// from a CommentGroup return a nilabilitySet of which identifiers are known annotated nilable,
+// where the aliased annotation keywords are expanded first
+func nilabilityFromCommentGroup(group *ast.CommentGroup, aliases map[string]string) nilabilitySet {
 	set := make(nilabilitySet)
+	if group != nil {
+		for _, comment := range group.List {
+			set.addAnnotations(expandAliases(comment.Text, aliases))
+		}
+	}
+	return set
+}
+
+// addAnnotations parses the annotations (in NilAway's syntax) in the text and adds them to the set.
+func (set nilabilitySet) addAnnotations(text string) {
 	// in each of the following utility functions, isFinalVal=true because literally read annotations
 	// are considered final
 	markNilable := func(s string) {
@@ -310,46 +362,51 @@ func nilabilityFromCommentGroup(group *ast.CommentGroup) nilabilitySet {
 		}
 	}
 
-	if group != nil {
-		for _, comment := range group.List {
-			for _, seqMatch := range seqRegex.FindAllStringSubmatch(comment.Text, -1) {
-
-				deepFunc, shallowFunc := markDeepNonNil, markNonNil
-				if seqMatch[1] == nilableKeyword {
-					deepFunc, shallowFunc = markDeepNilable, markNilable
-				}
+	for _, seqMatch := range seqRegex.FindAllStringSubmatch(text, -1) {
 
-				for _, match := range strings.Split(seqMatch[2], sep) {
-					match = strings.TrimSpace(match)
-					n := len(match)
+		deepFunc, shallowFunc := markDeepNonNil, markNonNil
+		switch seqMatch[1] {
+		case nilableKeyword:
+			deepFunc, shallowFunc = markDeepNilable, markNilable
+		case nonEmptyKeyword:
+			// a non-empty slice is nonnil, but its contents are not affected
+			deepFunc = func(string) {}
+		}
 
-					if n >= 2 && match[0] == '*' {
-						deepFunc(match[1:])
-						continue
-					}
+		for _, match := range strings.Split(seqMatch[2], sep) {
+			match = strings.TrimSpace(match)
+			n := len(match)
 
-					if n >= 3 && match[n-2:] == "[]" {
-						deepFunc(match[:n-2])
-						continue
-					}
+			if n >= 2 && match[0] == '*' {
+				deepFunc(match[1:])
+				continue
+			}
 
-					if n >= 3 && match[:2] == "<-" {
-						deepFunc(match[2:])
-						continue
-					}
+			if n >= 3 && match[n-2:] == "[]" {
+				deepFunc(match[:n-2])
+				continue
+			}
 
-					shallowFunc(match)
-				}
+			if n >= 3 && match[:2] == "<-" {
+				deepFunc(match[2:])
+				continue
 			}
+
+			shallowFunc(match)
 		}
 	}
-
-	return set
 }
 
 // TypeIsDefaultNilable takes a type and returns true iff we assume default nilability for that
 // type - in contrast to the remaining cases, in which we assume default non-nil.
 func TypeIsDefaultNilable(t types.Type) bool {
+	return typeIsDefaultNilable(t, nil)
+}
+
+// typeIsDefaultNilable is TypeIsDefaultNilable, except that the default nilability of the type
+// categories in the overrides (see config.Config.DefaultNilability) takes precedence over the
+// built-in defaults.
+func typeIsDefaultNilable(t types.Type, overrides map[string]bool) bool {
 	if t == nil {
 		return false
 	}
@@ -359,12 +416,6 @@ func TypeIsDefaultNilable(t types.Type) bool {
 		return true
 	}
 
-	// Slice, map, and chan should also be nilable by default (after unwrapping the named type).
-	switch t.Underlying().(type) {
-	case *types.Slice, *types.Map, *types.Chan:
-		return true
-	}
-
 	// Additionally, we allow custom default nilable types provided by the users.
 	if t, ok := t.(*types.Named); ok {
 		for _, defaultNilableNamedType := range config.DefaultNilableNamedTypes {
@@ -373,13 +424,49 @@ func TypeIsDefaultNilable(t types.Type) bool {
 			}
 		}
 	}
+
+	if nilable, ok := overrides[typeCategory(t)]; ok {
+		return nilable
+	}
+
+	// Slice, map, and chan should also be nilable by default (after unwrapping the named type).
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Chan:
+		return true
+	}
 	return false
 }
 
+// typeCategory returns the category (one of the config.TypeCategory* constants) of the type
+// after unwrapping the named type, or an empty string if the type does not belong to any.
+func typeCategory(t types.Type) string {
+	switch t.Underlying().(type) {
+	case *types.Pointer:
+		return config.TypeCategoryPointer
+	case *types.Map:
+		return config.TypeCategoryMap
+	case *types.Slice:
+		return config.TypeCategorySlice
+	case *types.Chan:
+		return config.TypeCategoryChan
+	case *types.Interface:
+		return config.TypeCategoryInterface
+	case *types.Signature:
+		return config.TypeCategoryFunc
+	}
+	return ""
+}
+
 // TypeIsDeepDefaultNilable takes an `ast.Expr` that evaluates to a type, and returns true iff
 // we assume default deep nilability for that type - in contrast to the remaining cases, in which
 // we assume default deep non-nil.
 func TypeIsDeepDefaultNilable(t types.Type) bool {
+	return typeIsDeepDefaultNilable(t, nil)
+}
+
+// typeIsDeepDefaultNilable is TypeIsDeepDefaultNilable with the overrides of the default
+// nilability of the type categories (see typeIsDefaultNilable) applied to the element types.
+func typeIsDeepDefaultNilable(t types.Type, overrides map[string]bool) bool {
 	switch t := t.(type) {
 	case *types.Array:
 		// the array case is handled different from others, since an array is not default nilable,
@@ -387,38 +474,52 @@ func TypeIsDeepDefaultNilable(t types.Type) bool {
 
 		// recurse if multi-dimensional array until containing type is reached
 		if e, ok := t.Elem().(*types.Array); ok {
-			return TypeIsDeepDefaultNilable(e)
+			return typeIsDeepDefaultNilable(e, overrides)
 		}
 		// assign deep nilability based on the element type
 		return !util.TypeBarsNilness(t.Elem())
 	case *types.Slice:
-		return TypeIsDefaultNilable(t.Elem())
+		return typeIsDefaultNilable(t.Elem(), overrides)
 	case *types.Map:
-		return TypeIsDefaultNilable(t.Elem())
+		return typeIsDefaultNilable(t.Elem(), overrides)
 	case *types.Pointer:
-		return TypeIsDefaultNilable(t.Elem())
+		return typeIsDefaultNilable(t.Elem(), overrides)
 	case *types.Chan:
-		return TypeIsDefaultNilable(t.Elem())
+		return typeIsDefaultNilable(t.Elem(), overrides)
 	case *types.Named:
-		return TypeIsDeepDefaultNilable(t.Underlying())
+		return typeIsDeepDefaultNilable(t.Underlying(), overrides)
 	}
 	return false
 }
 
+// markDefaultNonnil marks the results of the function that are neither annotated nor nilable by
+// default (e.g., `error`) as annotated nonnil, for the packages with the default nonnil returns
+// directive. Since literally read annotations are final, the explicitly annotated results are
+// left untouched.
+func markDefaultNonnil(funcObj *types.Func, vals []Val) {
+	results := funcObj.Type().(*types.Signature).Results()
+	for i := range vals {
+		if i < results.Len() && !vals[i].IsNilable && !util.TypeBarsNilness(results.At(i).Type()) {
+			vals[i] = vals[i].makeNonNil(true)
+		}
+	}
+}
+
 // checkNilability for a nilabilitySet checks to see if a string is mapped to an Annotation by that
 // set. If it is, then that Annotation is returned. If not, then `nonNil` is returned.
 // the type of the Annotation site is also passed, and it can possibly serve to mark a site
-// as `nilable` when its Annotation doesn't indicate so.
-func (set nilabilitySet) checkNilability(name string, t types.Type) Val {
+// as `nilable` when its Annotation doesn't indicate so, where the overrides of the default
+// nilability of the type categories (see config.Config.DefaultNilability) are applied.
+func (set nilabilitySet) checkNilability(name string, t types.Type, overrides map[string]bool) Val {
 	val := EmptyVal
 	if v, ok := set[name]; ok {
 		val = v
 	}
 	// in each of the following cases, isFinalVal=false because defaults are not considered final
-	if TypeIsDefaultNilable(t) {
+	if typeIsDefaultNilable(t, overrides) {
 		val = val.makeNilable(false)
 	}
-	if TypeIsDeepDefaultNilable(t) {
+	if typeIsDeepDefaultNilable(t, overrides) {
 		val = val.makeDeepNilable(false)
 	}
 	return val
@@ -435,11 +536,23 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 	paramIndexMap := make(map[*types.Var]int)
 	deepTypeAnnMap := make(map[*types.TypeName]Val)
 	globalVarsAnnMap := make(map[*types.Var]Val)
+	funcTypeParamAnnMap := make(map[*types.TypeName][]Val)
+	funcTypeRetAnnMap := make(map[*types.TypeName][]Val)
 
 	funcObjToFuncDecl := make(map[*types.Func]*ast.FuncDecl)
 	funcCallSiteParamAnnMap := make(map[CallSite][]ArgLocAndVal)
 	funcCallSiteRetAnnMap := make(map[CallSite][]Val)
 
+	syntaxParsers := enabledSyntaxParsers(conf)
+
+	defaultNonnilReturns := false
+	for _, file := range files {
+		if asthelper.DocContains(file.Doc, config.DefaultNonnilReturnsDirective) {
+			defaultNonnilReturns = true
+			break
+		}
+	}
+
 	typeOf := func(expr ast.Expr) types.Type {
 		return pass.TypesInfo.Types[expr].Type
 	}
@@ -467,7 +580,7 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 					lookupKey = resultStr(len(annVals))
 				}
 
-				annVals = append(annVals, set.checkNilability(lookupKey, typeOf(field.Type)))
+				annVals = append(annVals, set.checkNilability(lookupKey, typeOf(field.Type), conf.DefaultNilability))
 			} else {
 				for _, name := range field.Names {
 					declFld := pass.TypesInfo.ObjectOf(name).(*types.Var)
@@ -485,16 +598,18 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 						fieldType = typeOf(field.Type)
 					}
 
-					if isCallSiteAnnotation {
-						if isParamList {
-							lookupKey = paramStr(len(annVals))
-						} else {
-							lookupKey = resultStr(len(annVals))
-						}
+					if isParamList {
+						lookupKey = paramStr(len(annVals))
 					} else {
+						lookupKey = resultStr(len(annVals))
+					}
+					// In docstrings, named (and non-blank) fields are preferably annotated by
+					// their names, but the positional key is still accepted otherwise, such that
+					// blank fields (e.g., `_` in `(_ *T, err error)`) can be annotated as well.
+					if _, ok := set[name.Name]; ok && !isCallSiteAnnotation && name.Name != "_" {
 						lookupKey = name.Name
 					}
-					annVals = append(annVals, set.checkNilability(lookupKey, fieldType))
+					annVals = append(annVals, set.checkNilability(lookupKey, fieldType, conf.DefaultNilability))
 				}
 			}
 		}
@@ -506,6 +621,17 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 			if len(decl.Recv.List) > 1 {
 				panic(fmt.Sprintf("Multiple receivers found for method %s", decl.Name))
 			}
+			// Receivers are only annotated by their names, so the positional keys (e.g.,
+			// `result 0`) of the results must not be matched against named receivers.
+			if names := decl.Recv.List[0].Names; len(names) > 0 {
+				recvSet := make(nilabilitySet)
+				for _, name := range names {
+					if val, ok := set[name.Name]; ok {
+						recvSet[name.Name] = val
+					}
+				}
+				set = recvSet
+			}
 			return accFromFieldList(set, decl.Recv, false, false)[0]
 		}
 		return nonAnnotatedDefault
@@ -517,9 +643,12 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 				switch decl := decl.(type) {
 				case *ast.FuncDecl:
 					funcObj := pass.TypesInfo.ObjectOf(decl.Name).(*types.Func)
-					set := nilabilityFromCommentGroup(decl.Doc)
+					set := nilabilityFromCommentGroup(decl.Doc, conf.AnnotationAliases)
 					funcParamAnnMap[funcObj] = accFromFieldList(set, decl.Type.Params, true, false)
 					funcRetAnnMap[funcObj] = accFromFieldList(set, decl.Type.Results, false, false)
+					if defaultNonnilReturns && funcObj.Exported() {
+						markDefaultNonnil(funcObj, funcRetAnnMap[funcObj])
+					}
 					funcRecvAnnMap[funcObj] = readRecvAnnotations(decl, set)
 					// store the mapping from the function object to the ast node.
 					funcObjToFuncDecl[funcObj] = decl
@@ -533,11 +662,11 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 					readDocNilabilitySet := func(specDoc *ast.CommentGroup) nilabilitySet {
 						if len(decl.Specs) == 1 {
 							// this reads declarations like type A struct {}
-							return nilabilityFromCommentGroup(decl.Doc)
+							return nilabilityFromCommentGroup(decl.Doc, conf.AnnotationAliases)
 						}
 
 						// this reads declarations like type (A struct{}, B struct{})
-						return nilabilityFromCommentGroup(specDoc)
+						return nilabilityFromCommentGroup(specDoc, conf.AnnotationAliases)
 					}
 
 					for _, spec := range decl.Specs {
@@ -550,7 +679,7 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 								for _, name := range spec.Names {
 									varObj := pass.TypesInfo.ObjectOf(name).(*types.Var)
 									globalVarsAnnMap[varObj] =
-										docNilabilitySet.checkNilability(name.Name, typeOf(spec.Type))
+										docNilabilitySet.checkNilability(name.Name, typeOf(spec.Type), conf.DefaultNilability)
 								}
 							}
 						case *ast.TypeSpec:
@@ -563,7 +692,7 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 							readDeepNilability := func() {
 								typeName := pass.TypesInfo.ObjectOf(spec.Name).(*types.TypeName)
 								deepTypeAnnMap[typeName] =
-									docNilabilitySet.checkNilability(spec.Name.Name, typeOf(spec.Type))
+									docNilabilitySet.checkNilability(spec.Name.Name, typeOf(spec.Type), conf.DefaultNilability)
 							}
 							var handleTypeVal func(expr ast.Expr)
 							handleTypeVal = func(expr ast.Expr) {
@@ -571,8 +700,12 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 								case *ast.StructType:
 									for _, field := range typeVal.Fields.List {
 										for _, name := range field.Names {
+											set := docNilabilitySet
+											if len(syntaxParsers) > 0 {
+												set = docNilabilitySet.withFieldSyntaxes(syntaxParsers, field, name.Name)
+											}
 											fieldAnnMap[pass.TypesInfo.ObjectOf(name).(*types.Var)] =
-												docNilabilitySet.checkNilability(name.Name, typeOf(field.Type))
+												set.checkNilability(name.Name, typeOf(field.Type), conf.DefaultNilability)
 										}
 									}
 								case *ast.InterfaceType:
@@ -581,7 +714,7 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 										switch len(method.Names) {
 										case 1:
 											// this is the common case - a simply declared method
-											set := nilabilityFromCommentGroup(method.Doc)
+											set := nilabilityFromCommentGroup(method.Doc, conf.AnnotationAliases)
 											funcObj := pass.TypesInfo.ObjectOf(method.Names[0]).(*types.Func)
 											funcParamAnnMap[funcObj] = accFromFieldList(set, method.Type.(*ast.FuncType).Params, true, false)
 											funcRetAnnMap[funcObj] = accFromFieldList(set, method.Type.(*ast.FuncType).Results, false, false)
@@ -603,7 +736,13 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 									readDeepNilability()
 								case *ast.Ident: // type alias - do nothing
 								case *ast.SelectorExpr: // type alias - do nothing
-								case *ast.FuncType: // function type - do nothing (for now)
+								case *ast.FuncType:
+									// the params and results of a named function type are annotated
+									// just like those of a function declaration, e.g.,
+									// `// nilable(result 0)` on `type Handler func(*Request) *Response`
+									typeName := pass.TypesInfo.ObjectOf(spec.Name).(*types.TypeName)
+									funcTypeParamAnnMap[typeName] = accFromFieldList(docNilabilitySet, typeVal.Params, true, false)
+									funcTypeRetAnnMap[typeName] = accFromFieldList(docNilabilitySet, typeVal.Results, false, false)
 								case *ast.ChanType:
 									// TODO - treat channel types as deeply nilable at the typedef level
 								case *ast.IndexExpr, *ast.IndexListExpr:
@@ -622,6 +761,40 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 					}
 				}
 			}
+
+			// Anonymous struct types (e.g., in `v := struct{ p *T }{}`) and the struct types
+			// nested in other declarations have no docstrings to read the annotations from, so
+			// their fields are annotated via their own doc or line comments instead (e.g.,
+			// `p *T // nilable(p)`).
+			ast.Inspect(file, func(node ast.Node) bool {
+				structType, ok := node.(*ast.StructType)
+				if !ok {
+					return true
+				}
+				for _, field := range structType.Fields.List {
+					for _, name := range field.Names {
+						fieldObj, ok := pass.TypesInfo.ObjectOf(name).(*types.Var)
+						if !ok {
+							continue
+						}
+						if _, ok := fieldAnnMap[fieldObj]; ok {
+							// the field of a declared struct type, which is already read above
+							continue
+						}
+						set := nilabilityFromCommentGroup(field.Doc, conf.AnnotationAliases)
+						if field.Comment != nil {
+							for _, comment := range field.Comment.List {
+								set.addAnnotations(expandAliases(comment.Text, conf.AnnotationAliases))
+							}
+						}
+						if len(syntaxParsers) > 0 {
+							set = set.withFieldSyntaxes(syntaxParsers, field, name.Name)
+						}
+						fieldAnnMap[fieldObj] = set.checkNilability(name.Name, typeOf(field.Type), conf.DefaultNilability)
+					}
+				}
+				return true
+			})
 		}
 	}
 
@@ -656,7 +829,7 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 				return true
 			}
 
-			ident := util.FuncIdentFromCallExpr(expr)
+			ident := util.FuncIdentFromCallExpr(pass.TypesInfo, expr)
 			// if ident is nil, keep searching for nested CallExpr nodes.
 			if ident == nil {
 				return true
@@ -667,7 +840,7 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 				return true
 			}
 
-			set := nilabilityFromCommentGroup(commentGroup)
+			set := nilabilityFromCommentGroup(commentGroup, conf.AnnotationAliases)
 			if len(set) == 0 {
 				// empty set, no annotation, keep searching for nested CallExpr nodes.
 				return true
@@ -696,6 +869,8 @@ func newObservedMap(pass *analysis.Pass, files []*ast.File) *ObservedMap {
 		funcRecvAnnMap:          funcRecvAnnMap,
 		deepTypeAnnMap:          deepTypeAnnMap,
 		globalVarsAnnMap:        globalVarsAnnMap,
+		funcTypeParamAnnMap:     funcTypeParamAnnMap,
+		funcTypeRetAnnMap:       funcTypeRetAnnMap,
 		funcCallSiteParamAnnMap: funcCallSiteParamAnnMap,
 		funcCallSiteRetAnnMap:   funcCallSiteRetAnnMap,
 	}
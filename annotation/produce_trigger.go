@@ -432,6 +432,313 @@ func (n NoVarAssignPrestring) String() string {
 	return fmt.Sprintf("unassigned variable `%s`", n.VarName)
 }
 
+// UnassignedArrayElem is when a value is determined to flow from an element of an array that was
+// not assigned at initialization, e.g., `var a [3]*int` or `a := [3]*int{}`, where the elements
+// hold the zero value (nil).
+type UnassignedArrayElem struct {
+	*ProduceTriggerTautology
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (u *UnassignedArrayElem) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*UnassignedArrayElem); ok {
+		return u.ProduceTriggerTautology.equals(other.ProduceTriggerTautology)
+	}
+	return false
+}
+
+// Prestring returns this Prestring as a Prestring
+func (*UnassignedArrayElem) Prestring() Prestring {
+	return UnassignedArrayElemPrestring{}
+}
+
+// UnassignedArrayElemPrestring is a Prestring storing the needed information to compactly encode a UnassignedArrayElem
+type UnassignedArrayElemPrestring struct{}
+
+func (UnassignedArrayElemPrestring) String() string {
+	return "uninitialized array element"
+}
+
+// NewZeroValue is when a value is determined to flow from dereferencing a fresh allocation of the
+// builtin `new` (i.e., `*new(T)`), which is the zero value (nil) of a nilable type `T`. This is
+// the idiomatic way to obtain the zero value of a type parameter, e.g., in the "not found" paths
+// of generic containers, which is nil if the type parameter is instantiated with a pointer.
+type NewZeroValue struct {
+	*ProduceTriggerTautology
+	TypeName string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (n *NewZeroValue) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*NewZeroValue); ok {
+		return n.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) && n.TypeName == other.TypeName
+	}
+	return false
+}
+
+// Prestring returns this NewZeroValue as a Prestring
+func (n *NewZeroValue) Prestring() Prestring {
+	return NewZeroValuePrestring{TypeName: n.TypeName}
+}
+
+// NewZeroValuePrestring is a Prestring storing the needed information to compactly encode a NewZeroValue
+type NewZeroValuePrestring struct {
+	TypeName string
+}
+
+func (n NewZeroValuePrestring) String() string {
+	return fmt.Sprintf("zero value `*new(%s)`", n.TypeName)
+}
+
+// DeletedMapValue is when a value is determined to flow from a read of a map after the same (stable)
+// key is deleted from it, e.g., `delete(m, "k"); v := m["k"]`, where the read yields the zero
+// value (nil).
+type DeletedMapValue struct {
+	*ProduceTriggerTautology
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (d *DeletedMapValue) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*DeletedMapValue); ok {
+		return d.ProduceTriggerTautology.equals(other.ProduceTriggerTautology)
+	}
+	return false
+}
+
+// Prestring returns this DeletedMapValue as a Prestring
+func (*DeletedMapValue) Prestring() Prestring {
+	return DeletedMapValuePrestring{}
+}
+
+// DeletedMapValuePrestring is a Prestring storing the needed information to compactly encode a DeletedMapValue
+type DeletedMapValuePrestring struct{}
+
+func (DeletedMapValuePrestring) String() string {
+	return "value read from map after its key is deleted"
+}
+
+// StrictMapRead is when a value is determined to flow from a single-value read of a map (e.g.,
+// `m[k]`) whose deep nilability is otherwise unknown (e.g., for nested maps), under the
+// `strict-map-reads` flag. It always needs a guard, i.e., it is nonnil only in the comma-ok form
+// (`v, ok := m[k]`) with `ok` checked, and nilable otherwise.
+type StrictMapRead struct {
+	*ProduceTriggerNever
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (s *StrictMapRead) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*StrictMapRead); ok {
+		return s.ProduceTriggerNever.equals(other.ProduceTriggerNever)
+	}
+	return false
+}
+
+// Prestring returns this StrictMapRead as a Prestring
+func (*StrictMapRead) Prestring() Prestring {
+	return StrictMapReadPrestring{}
+}
+
+// StrictMapReadPrestring is a Prestring storing the needed information to compactly encode a StrictMapRead
+type StrictMapReadPrestring struct{}
+
+func (StrictMapReadPrestring) String() string {
+	return "single-value read from map"
+}
+
+// UnresolvedCallResult is when a value is determined to flow from a result of a call whose callee
+// cannot be resolved statically, e.g., a call through a function value (`fn()`), which is
+// conservatively considered nilable. It is only created if the `conservative-unknown-calls` flag
+// is set, otherwise such results are assumed to be nonnil.
+type UnresolvedCallResult struct {
+	*ProduceTriggerTautology
+	// Callee is the printed callee expression of the call, e.g., `fn` or `s.fn`.
+	Callee string
+	// ResultNum is the index of the result.
+	ResultNum int
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (u *UnresolvedCallResult) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*UnresolvedCallResult); ok {
+		return u.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) &&
+			u.Callee == other.Callee && u.ResultNum == other.ResultNum
+	}
+	return false
+}
+
+// Prestring returns this UnresolvedCallResult as a Prestring
+func (u *UnresolvedCallResult) Prestring() Prestring {
+	return UnresolvedCallResultPrestring{Callee: u.Callee, ResultNum: u.ResultNum}
+}
+
+// UnresolvedCallResultPrestring is a Prestring storing the needed information to compactly encode a UnresolvedCallResult
+type UnresolvedCallResultPrestring struct {
+	Callee    string
+	ResultNum int
+}
+
+func (u UnresolvedCallResultPrestring) String() string {
+	return fmt.Sprintf("result %d of unresolved call `%s()`", u.ResultNum, u.Callee)
+}
+
+// UnsafePointerConversion is when a value is determined to flow from a conversion from
+// `unsafe.Pointer` (e.g., `(*T)(unsafe.Pointer(p))`), which is opaque to the analysis and hence
+// conservatively considered nilable. It is not created if the `nonnil-unsafe-conversions` flag is
+// set, in which case such conversions are assumed to be nonnil like any other conversions.
+type UnsafePointerConversion struct {
+	*ProduceTriggerTautology
+	// Type is the printed target type of the conversion, e.g., `*T`.
+	Type string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (u *UnsafePointerConversion) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*UnsafePointerConversion); ok {
+		return u.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) && u.Type == other.Type
+	}
+	return false
+}
+
+// Prestring returns this UnsafePointerConversion as a Prestring
+func (u *UnsafePointerConversion) Prestring() Prestring {
+	return UnsafePointerConversionPrestring{Type: u.Type}
+}
+
+// UnsafePointerConversionPrestring is a Prestring storing the needed information to compactly encode a UnsafePointerConversion
+type UnsafePointerConversionPrestring struct {
+	Type string
+}
+
+func (u UnsafePointerConversionPrestring) String() string {
+	return fmt.Sprintf("unsafe conversion to `%s`", u.Type)
+}
+
+// ProtobufGetterResult is when a value is determined to flow from the result of a generated
+// protobuf getter that returns a message (e.g., `req.GetUser()`), which is nil if the field is
+// unset. It is only created if the `model-protobuf-getters` flag is set.
+type ProtobufGetterResult struct {
+	*ProduceTriggerTautology
+	// Getter is the name of the getter, e.g., `GetUser`.
+	Getter string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (p *ProtobufGetterResult) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*ProtobufGetterResult); ok {
+		return p.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) && p.Getter == other.Getter
+	}
+	return false
+}
+
+// Prestring returns this ProtobufGetterResult as a Prestring
+func (p *ProtobufGetterResult) Prestring() Prestring {
+	return ProtobufGetterResultPrestring{Getter: p.Getter}
+}
+
+// ProtobufGetterResultPrestring is a Prestring storing the needed information to compactly encode a ProtobufGetterResult
+type ProtobufGetterResultPrestring struct {
+	Getter string
+}
+
+func (p ProtobufGetterResultPrestring) String() string {
+	return fmt.Sprintf("result of protobuf getter `%s()` for a possibly unset field", p.Getter)
+}
+
+// OkTypeAssertion is when a value is determined to flow from the result of a failed type assertion
+// in the comma-ok form (i.e., `v` in the `if !ok { ... }` branch of `v, ok := x.(*T)`), which is
+// the zero value (i.e., nil) since the assertion is known to have failed.
+type OkTypeAssertion struct {
+	*ProduceTriggerTautology
+	// Type is the printed type of the assertion, e.g., `*T`.
+	Type string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (o *OkTypeAssertion) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*OkTypeAssertion); ok {
+		return o.ProduceTriggerTautology.equals(other.ProduceTriggerTautology) && o.Type == other.Type
+	}
+	return false
+}
+
+// Prestring returns this OkTypeAssertion as a Prestring
+func (o *OkTypeAssertion) Prestring() Prestring {
+	return OkTypeAssertionPrestring{Type: o.Type}
+}
+
+// OkTypeAssertionPrestring is a Prestring storing the needed information to compactly encode a OkTypeAssertion
+type OkTypeAssertionPrestring struct {
+	Type string
+}
+
+func (o OkTypeAssertionPrestring) String() string {
+	return fmt.Sprintf("result of failed comma-ok type assertion to `%s`", o.Type)
+}
+
+// MapZeroValueFld is when a value is determined to flow from a field of a struct read from a map
+// (e.g., `m[k].f`), which is a field of the zero value of the struct (i.e., nil) if the key is
+// missing. It should always be instantiated with NeedsGuard = true, such that the field is nonnil
+// only if the presence of the key is checked (e.g., `if _, ok := m[k]; ok { ... }`).
+type MapZeroValueFld struct {
+	*ProduceTriggerNever
+	// FieldName is the name of the field read from the struct.
+	FieldName string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (m *MapZeroValueFld) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*MapZeroValueFld); ok {
+		return m.ProduceTriggerNever.equals(other.ProduceTriggerNever) && m.FieldName == other.FieldName
+	}
+	return false
+}
+
+// Prestring returns this MapZeroValueFld as a Prestring
+func (m *MapZeroValueFld) Prestring() Prestring {
+	return MapZeroValueFldPrestring{FieldName: m.FieldName}
+}
+
+// MapZeroValueFldPrestring is a Prestring storing the needed information to compactly encode a MapZeroValueFld
+type MapZeroValueFldPrestring struct {
+	FieldName string
+}
+
+func (m MapZeroValueFldPrestring) String() string {
+	return fmt.Sprintf("field `%s` of zero value read from map for a possibly missing key", m.FieldName)
+}
+
+// ChanZeroValueFld is when a value is determined to flow from a field of a struct received from a
+// channel in the ok form (e.g., `s.f` after `s, ok := <-ch`), which is a field of the zero value of
+// the struct (i.e., nil) if the channel is closed. It should always be instantiated with
+// NeedsGuard = true, such that the field is nonnil only if the `ok` is checked.
+type ChanZeroValueFld struct {
+	*ProduceTriggerNever
+	// FieldName is the name of the field read from the struct.
+	FieldName string
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (c *ChanZeroValueFld) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*ChanZeroValueFld); ok {
+		return c.ProduceTriggerNever.equals(other.ProduceTriggerNever) && c.FieldName == other.FieldName
+	}
+	return false
+}
+
+// Prestring returns this ChanZeroValueFld as a Prestring
+func (c *ChanZeroValueFld) Prestring() Prestring {
+	return ChanZeroValueFldPrestring{FieldName: c.FieldName}
+}
+
+// ChanZeroValueFldPrestring is a Prestring storing the needed information to compactly encode a ChanZeroValueFld
+type ChanZeroValueFldPrestring struct {
+	FieldName string
+}
+
+func (c ChanZeroValueFldPrestring) String() string {
+	return fmt.Sprintf("field `%s` of zero value received from a possibly closed channel", c.FieldName)
+}
+
 // BlankVarReturn is when a value is determined to flow from a blank variable ('_') to a return of the function
 type BlankVarReturn struct {
 	*ProduceTriggerTautology
@@ -757,13 +1064,17 @@ type FuncReturn struct {
 	*TriggerIfNilable
 
 	IsFromRichCheckEffectFunc bool
+	// IsErrDiscarded is true if the result is from an error-returning function whose error result
+	// is explicitly discarded at the call site, e.g., `v, _ := f()`.
+	IsErrDiscarded bool
 }
 
 // equals returns true if the passed ProducingAnnotationTrigger is equal to this one
 func (f *FuncReturn) equals(other ProducingAnnotationTrigger) bool {
 	if other, ok := other.(*FuncReturn); ok {
 		return f.TriggerIfNilable.equals(other.TriggerIfNilable) &&
-			f.IsFromRichCheckEffectFunc == other.IsFromRichCheckEffectFunc
+			f.IsFromRichCheckEffectFunc == other.IsFromRichCheckEffectFunc &&
+			f.IsErrDiscarded == other.IsErrDiscarded
 	}
 	return false
 }
@@ -775,8 +1086,10 @@ func (f *FuncReturn) Prestring() Prestring {
 		return FuncReturnPrestring{key.RetNum, key.FuncDecl.Name(), ""}
 	case *CallSiteRetAnnotationKey:
 		return FuncReturnPrestring{key.RetNum, key.FuncDecl.Name(), key.Location.String()}
+	case *FuncTypeRetAnnotationKey:
+		return FuncReturnPrestring{key.RetNum, key.TypeDecl.Name(), ""}
 	default:
-		panic(fmt.Sprintf("Expected RetAnnotationKey or CallSiteRetAnnotationKey but got: %T", key))
+		panic(fmt.Sprintf("Expected RetAnnotationKey, CallSiteRetAnnotationKey or FuncTypeRetAnnotationKey but got: %T", key))
 	}
 }
 
@@ -1100,6 +1413,37 @@ func (f FuncParamDeepPrestring) String() string {
 	return fmt.Sprintf("deep read from parameter `%s`", f.ParamName)
 }
 
+// OutParamWrite is used when a value is determined to be written by a callee through its
+// pointer-to-pointer parameter (i.e., an out parameter, e.g., `p` after `fill(&p)` for
+// `func fill(out **T)`), and thus be nilable iff the deep Annotation on that parameter is nilable
+type OutParamWrite struct {
+	*TriggerIfDeepNilable
+}
+
+// equals returns true if the passed ProducingAnnotationTrigger is equal to this one
+func (o *OutParamWrite) equals(other ProducingAnnotationTrigger) bool {
+	if other, ok := other.(*OutParamWrite); ok {
+		return o.TriggerIfDeepNilable.equals(other.TriggerIfDeepNilable)
+	}
+	return false
+}
+
+// Prestring returns this OutParamWrite as a Prestring
+func (o *OutParamWrite) Prestring() Prestring {
+	key := o.Ann.(*ParamAnnotationKey)
+	return OutParamWritePrestring{key.ParamNameString(), key.FuncDecl.Name()}
+}
+
+// OutParamWritePrestring is a Prestring storing the needed information to compactly encode a OutParamWrite
+type OutParamWritePrestring struct {
+	ParamName string
+	FuncName  string
+}
+
+func (o OutParamWritePrestring) String() string {
+	return fmt.Sprintf("written through out parameter `%s` of `%s()`", o.ParamName, o.FuncName)
+}
+
 // VariadicFuncParamDeep is used when a value is determined to flow deeply from a variadic function
 // parameter, and thus be nilable iff the shallow Annotation on that parameter is nilable
 type VariadicFuncParamDeep struct {
@@ -1269,15 +1613,25 @@ func (g *GuardMissing) equals(other ProducingAnnotationTrigger) bool {
 
 // Prestring returns this GuardMissing as a Prestring
 func (g *GuardMissing) Prestring() Prestring {
-	return GuardMissingPrestring{g.OldAnnotation.Prestring()}
+	f, ok := g.OldAnnotation.(*FuncReturn)
+	return GuardMissingPrestring{
+		OldPrestring: g.OldAnnotation.Prestring(),
+		ErrDiscarded: ok && f.IsErrDiscarded,
+	}
 }
 
 // GuardMissingPrestring is a Prestring storing the needed information to compactly encode a GuardMissing
 type GuardMissingPrestring struct {
 	OldPrestring Prestring
+	// ErrDiscarded is true if the guard is missing because the error result guarding the value
+	// is explicitly discarded (e.g., `v, _ := f()`), which is reported with a dedicated message.
+	ErrDiscarded bool
 }
 
 func (g GuardMissingPrestring) String() string {
+	if g.ErrDiscarded {
+		return fmt.Sprintf("%s used without checking the discarded error;", g.OldPrestring.String())
+	}
 	return fmt.Sprintf("%s lacking guarding;", g.OldPrestring.String())
 }
 
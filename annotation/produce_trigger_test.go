@@ -49,6 +49,16 @@ func (s *ProducingAnnotationTriggerTestSuite) SetupTest() {
 		&ConstNil{ProduceTriggerTautology: &ProduceTriggerTautology{}},
 		&UnassignedFld{ProduceTriggerTautology: &ProduceTriggerTautology{}},
 		&NoVarAssign{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&UnassignedArrayElem{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&NewZeroValue{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&DeletedMapValue{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&StrictMapRead{ProduceTriggerNever: &ProduceTriggerNever{}},
+		&UnresolvedCallResult{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&UnsafePointerConversion{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&ProtobufGetterResult{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&OkTypeAssertion{ProduceTriggerTautology: &ProduceTriggerTautology{}},
+		&MapZeroValueFld{ProduceTriggerNever: &ProduceTriggerNever{}},
+		&ChanZeroValueFld{ProduceTriggerNever: &ProduceTriggerNever{}},
 		&BlankVarReturn{ProduceTriggerTautology: &ProduceTriggerTautology{}},
 		&FuncParam{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&MethodRecv{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
@@ -70,6 +80,7 @@ func (s *ProducingAnnotationTriggerTestSuite) SetupTest() {
 		&PtrRead{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&ChanRecv{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&FuncParamDeep{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
+		&OutParamWrite{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&VariadicFuncParamDeep{TriggerIfNilable: &TriggerIfNilable{Ann: mockedKey}},
 		&FuncReturnDeep{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
 		&FldReadDeep{TriggerIfDeepNilable: &TriggerIfDeepNilable{Ann: mockedKey}},
@@ -0,0 +1,83 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"go/ast"
+	"maps"
+	"strings"
+
+	"go.uber.org/nilaway/config"
+)
+
+// SyntaxParser is an extension point for recognizing alternate annotation comment syntaxes (e.g.,
+// `// +optional` from Kubernetes), such that existing conventions can be reused as annotations.
+// Currently, the parsers are consulted for the doc and line comments of struct fields.
+type SyntaxParser interface {
+	// ParseField returns the annotations in NilAway's syntax (e.g., "nilable(name)") expressed by
+	// the comment group attached to the struct field `name`, or an empty string if there are none.
+	ParseField(group *ast.CommentGroup, name string) string
+}
+
+// syntaxParsers stores the registered syntax parsers.
+var syntaxParsers []SyntaxParser
+
+// RegisterSyntaxParser registers a parser for an alternate annotation syntax, which will be
+// consulted for all subsequently analyzed packages. It is not safe for concurrent use, and hence
+// must be called before the analysis starts (e.g., in the `init` function of a custom driver).
+func RegisterSyntaxParser(p SyntaxParser) {
+	syntaxParsers = append(syntaxParsers, p)
+}
+
+// enabledSyntaxParsers returns the registered syntax parsers along with the built-in ones that are
+// enabled in the config.
+func enabledSyntaxParsers(conf *config.Config) []SyntaxParser {
+	if !conf.OptionalAnnotations {
+		return syntaxParsers
+	}
+	return append([]SyntaxParser{OptionalSyntaxParser{}}, syntaxParsers...)
+}
+
+// withFieldSyntaxes returns a copy of the set extended with the annotations that the parsers
+// recognize on the doc and line comments of the struct field `name`.
+func (set nilabilitySet) withFieldSyntaxes(parsers []SyntaxParser, field *ast.Field, name string) nilabilitySet {
+	extended := maps.Clone(set)
+	for _, p := range parsers {
+		for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+			if group == nil {
+				continue
+			}
+			if text := p.ParseField(group, name); text != "" {
+				extended.addAnnotations(text)
+			}
+		}
+	}
+	return extended
+}
+
+// OptionalSyntaxParser is the built-in SyntaxParser for the `// +optional` comment convention
+// (e.g., from Kubernetes), which marks the annotated struct field as nilable.
+type OptionalSyntaxParser struct{}
+
+// ParseField returns a `nilable` annotation for the field if the comment group contains a
+// `+optional` line.
+func (OptionalSyntaxParser) ParseField(group *ast.CommentGroup, name string) string {
+	for _, comment := range group.List {
+		if strings.TrimSpace(strings.TrimPrefix(comment.Text, "//")) == "+optional" {
+			return nilableKeyword + "(" + name + ")"
+		}
+	}
+	return ""
+}
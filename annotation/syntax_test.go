@@ -0,0 +1,75 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package annotation
+
+import (
+	"go/ast"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// deepSyntaxParser is a custom SyntaxParser that marks the contents of a field as nonnil if it
+// has a `// +required-elems` comment.
+type deepSyntaxParser struct{}
+
+func (deepSyntaxParser) ParseField(group *ast.CommentGroup, name string) string {
+	for _, comment := range group.List {
+		if comment.Text == "// +required-elems" {
+			return "nonnil(" + name + "[])"
+		}
+	}
+	return ""
+}
+
+func TestOptionalSyntaxParser(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		comments []string
+		want     string
+	}{
+		{comments: []string{"// +optional"}, want: "nilable(f)"},
+		{comments: []string{"// F is a field.", "//+optional "}, want: "nilable(f)"},
+		{comments: []string{"// +optional is not on its own line"}, want: ""},
+		{comments: []string{"// +required"}, want: ""},
+	}
+	for _, tt := range tests {
+		group := &ast.CommentGroup{}
+		for _, text := range tt.comments {
+			group.List = append(group.List, &ast.Comment{Text: text})
+		}
+		require.Equal(t, tt.want, OptionalSyntaxParser{}.ParseField(group, "f"), "comments: %v", tt.comments)
+	}
+}
+
+func TestWithFieldSyntaxes(t *testing.T) {
+	t.Parallel()
+
+	set := make(nilabilitySet)
+	set.addAnnotations("nonnil(g)")
+	field := &ast.Field{
+		Doc:     &ast.CommentGroup{List: []*ast.Comment{{Text: "// +required-elems"}}},
+		Comment: &ast.CommentGroup{List: []*ast.Comment{{Text: "// +optional"}}},
+	}
+	extended := set.withFieldSyntaxes([]SyntaxParser{OptionalSyntaxParser{}, deepSyntaxParser{}}, field, "f")
+
+	require.True(t, extended["f"].IsNilable)
+	require.True(t, extended["f"].IsDeepNilableSet)
+	require.False(t, extended["f"].IsDeepNilable)
+	require.Equal(t, set["g"], extended["g"])
+	// The original set must not be modified since it is shared by all fields of the struct.
+	require.NotContains(t, set, "f")
+}
@@ -75,6 +75,7 @@ func (a *Affiliation) extractAffiliations(pass *analysis.Pass) {
 	}
 
 	a.computeTriggersForCastingSites(pass, upstreamCache, currentCache)
+	a.computeTriggersForEmbeddedMethods(pass)
 
 	// export upstreamCache from this package by adding new entries (if any)
 	if len(currentCache) > 0 {
@@ -134,7 +135,7 @@ func (a *Affiliation) computeTriggersForCastingSites(pass *analysis.Pass, upstre
 					}
 				case *ast.CallExpr:
 					// e.g., func foo(i I), foo(&S{})
-					if ident := util.FuncIdentFromCallExpr(node); ident != nil {
+					if ident := util.FuncIdentFromCallExpr(pass.TypesInfo, node); ident != nil {
 						if declObj := pass.TypesInfo.Uses[ident]; declObj != nil {
 							if fdecl, ok := declObj.(*types.Func); ok {
 								fsig := fdecl.Type().(*types.Signature)
@@ -234,6 +235,52 @@ func (a *Affiliation) computeTriggersForCastingSites(pass *analysis.Pass, upstre
 	}
 }
 
+// computeTriggersForEmbeddedMethods analyzes the interfaces (including the inline constraints of
+// type parameters) that embed multiple interfaces declaring the same method, e.g.,
+// `interface{ I1; I2 }` where both I1 and I2 declare `Get() *T`. The type checker resolves the
+// promoted method (and hence the calls through the interface) to only one of the declarations,
+// so here we treat the other declarations as implementing the resolved one, such that their
+// nilability annotations must agree with the annotations of the resolved declaration.
+func (a *Affiliation) computeTriggersForEmbeddedMethods(pass *analysis.Pass) {
+	visited := make(map[annotation.AffiliationPair]bool)
+	for _, file := range pass.Files {
+		if !a.conf.IsFileInScope(file) {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			node, ok := n.(*ast.InterfaceType)
+			if !ok {
+				return true
+			}
+			iface, ok := pass.TypesInfo.TypeOf(node).(*types.Interface)
+			if !ok || iface.NumEmbeddeds() < 2 {
+				return true
+			}
+			for i := 0; i < iface.NumEmbeddeds(); i++ {
+				embedded, ok := iface.EmbeddedType(i).Underlying().(*types.Interface)
+				if !ok {
+					continue
+				}
+				for j := 0; j < embedded.NumMethods(); j++ {
+					method := embedded.Method(j)
+					resolved, _, _ := types.LookupFieldOrMethod(iface, false, method.Pkg(), method.Name())
+					resolvedMethod, ok := resolved.(*types.Func)
+					if !ok || resolvedMethod == method || !a.conf.IsPkgInScope(method.Pkg()) || !a.conf.IsPkgInScope(resolvedMethod.Pkg()) {
+						continue
+					}
+					pair := annotation.AffiliationPair{ImplementingMethod: method, InterfaceMethod: resolvedMethod}
+					if visited[pair] {
+						continue
+					}
+					visited[pair] = true
+					a.triggers = append(a.triggers, createFunctionTriggers(method, resolvedMethod)...)
+				}
+			}
+			return true
+		})
+	}
+}
+
 // computeTriggersForTypes finds corresponding concrete implementation and their declared methods and populates them in a map
 func (a *Affiliation) computeTriggersForTypes(lhsType types.Type, rhsType types.Type, upstreamCache ImplementedDeclaredTypesCache, currentCache ImplementedDeclaredTypesCache) []annotation.FullTrigger {
 	if lhsType == nil || rhsType == nil {
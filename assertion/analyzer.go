@@ -18,13 +18,16 @@ package assertion
 
 import (
 	"errors"
+	"go/types"
 	"reflect"
+	"slices"
 
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/assertion/affiliation"
 	"go.uber.org/nilaway/assertion/function"
 	"go.uber.org/nilaway/assertion/global"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/util"
 	"go.uber.org/nilaway/util/analysishelper"
 	"golang.org/x/tools/go/analysis"
 )
@@ -64,5 +67,34 @@ func run(pass *analysis.Pass) ([]annotation.FullTrigger, error) {
 		triggers = append(triggers, t...)
 	}
 
+	// Drop the triggers rooted in the results of the excluded symbols, which are known to be
+	// nonnil by external guarantees. Since the nilability never flows out of such results, all
+	// the diagnostics rooted in them are suppressed.
+	if len(conf.ExcludeSymbols) > 0 {
+		triggers = slices.DeleteFunc(triggers, func(t annotation.FullTrigger) bool {
+			return isRootedInExcludedSymbol(t.Producer.Annotation, conf.ExcludeSymbols)
+		})
+	}
+
 	return triggers, nil
 }
+
+// isRootedInExcludedSymbol returns true if the producer produces (or, for the results that are
+// not guarded by their error results, wraps the production of) a result of one of the excluded
+// functions.
+func isRootedInExcludedSymbol(p annotation.ProducingAnnotationTrigger, excluded map[string]bool) bool {
+	if g, ok := p.(*annotation.GuardMissing); ok {
+		p = g.OldAnnotation
+	}
+	var funcObj *types.Func
+	switch key := p.UnderlyingSite().(type) {
+	case *annotation.RetAnnotationKey:
+		funcObj = key.FuncDecl
+	case *annotation.CallSiteRetAnnotationKey:
+		funcObj = key.FuncDecl
+	default:
+		return false
+	}
+	name, ok := util.FullyQualifiedFuncName(funcObj)
+	return ok && excluded[name]
+}
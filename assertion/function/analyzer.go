@@ -30,6 +30,7 @@ import (
 	"go.uber.org/nilaway/assertion/anonymousfunc"
 	"go.uber.org/nilaway/assertion/function/assertiontree"
 	"go.uber.org/nilaway/assertion/function/functioncontracts"
+	"go.uber.org/nilaway/assertion/function/preprocess"
 	"go.uber.org/nilaway/assertion/structfield"
 	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util"
@@ -94,9 +95,16 @@ func run(pass *analysis.Pass) ([]annotation.FullTrigger, error) {
 		// TODO: enable struct initialization flag (tracked in Issue #23).
 		// TODO: enable anonymous function flag.
 	} else {
-		functionConfig.EnableStructInitCheck = conf.ExperimentalStructInitEnable
-		functionConfig.EnableAnonymousFunc = conf.ExperimentalAnonymousFuncEnable
+		functionConfig.EnableStructInitCheck = conf.IsExperimentalStructInitEnabled(pass.Pkg)
+		functionConfig.EnableAnonymousFunc = conf.IsExperimentalAnonymousFuncEnabled(pass.Pkg)
 	}
+	functionConfig.ConservativeUnknownCalls = conf.ConservativeUnknownCalls
+	functionConfig.StrictMapReads = conf.StrictMapReads
+	functionConfig.NonnilUnsafeConversions = conf.NonnilUnsafeConversions
+	functionConfig.ConcreteInterfaceReceivers = conf.ConcreteInterfaceReceivers
+	functionConfig.StrictInternalErrors = conf.StrictInternalErrors
+	functionConfig.PositiveNilChecks = conf.NoInference
+	functionConfig.NilableFuncParams = conf.DefaultNilability[config.TypeCategoryFunc]
 
 	ctrlflowResult := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
 	anonymousFuncResult := pass.ResultOf[anonymousfunc.Analyzer].(*analysishelper.Result[map[*ast.FuncLit]*anonymousfunc.FuncLitInfo])
@@ -106,6 +114,7 @@ func run(pass *analysis.Pass) ([]annotation.FullTrigger, error) {
 	}
 
 	funcLitMap, funcContracts := anonymousFuncResult.Res, contractsResult.Res
+	taggedUnions, oneOfs := preprocess.CollectTaggedUnions(pass), preprocess.CollectOneOfs(pass)
 
 	// Create a fake ident map for the fake func decl nodes to be shared for all function contexts.
 	pkgFakeIdentMap := make(map[*ast.Ident]types.Object)
@@ -183,7 +192,7 @@ func run(pass *analysis.Pass) ([]annotation.FullTrigger, error) {
 			// Now, analyze the function declarations concurrently.
 			wg.Add(1)
 			funcContext := assertiontree.NewFunctionContext(
-				pass, funcDecl, funcLit, functionConfig, funcLitMap, pkgFakeIdentMap, funcContracts)
+				pass, funcDecl, funcLit, functionConfig, funcLitMap, pkgFakeIdentMap, funcContracts, taggedUnions, oneOfs)
 			go analyzeFunc(ctx, pass, funcDecl, funcContext, graph, funcIndex, funcChan, &wg)
 			funcIndex++
 		}
@@ -380,7 +389,7 @@ func findCallsToContractedFunctions(
 			return true
 		}
 
-		ident := util.FuncIdentFromCallExpr(callExpr)
+		ident := util.FuncIdentFromCallExpr(pass.TypesInfo, callExpr)
 		if ident == nil {
 			return true
 		}
@@ -406,8 +415,8 @@ func findCallsToContractedFunctions(
 // hasOnlyNonNilToNonNilContract returns whether the given function has only one contract that is
 // nonnil->nonnil.
 func hasOnlyNonNilToNonNilContract(funcContracts functioncontracts.Map, funcObj *types.Func) bool {
-	contracts, ok := funcContracts[funcObj]
-	if !ok || len(contracts) != 1 {
+	contracts := funcContracts[funcObj].Forward()
+	if len(contracts) != 1 {
 		return false
 	}
 	ctr := contracts[0]
@@ -440,6 +449,11 @@ func analyzeFunc(
 	defer func() {
 		if r := recover(); r != nil {
 			e := fmt.Errorf("INTERNAL PANIC: %s\n%s", r, string(debug.Stack()))
+			if pass.Fset != nil && funcDecl.Name != nil {
+				// Attach the offending function for bug reports.
+				pos := pass.Fset.Position(funcDecl.Pos())
+				e = fmt.Errorf("analyzing function %s at %s:%d.%d: %w", funcDecl.Name, pos.Filename, pos.Line, pos.Column, e)
+			}
 			funcChan <- functionResult{err: e, index: index, funcDecl: funcDecl}
 		}
 	}()
@@ -87,7 +87,7 @@ func TestCancelledContext(t *testing.T) {
 	emptyPkgFakeIdentMap := make(map[*ast.Ident]types.Object)
 	emptyFuncContracts := make(functioncontracts.Map)
 	funcContext := assertiontree.NewFunctionContext(pass, funcDecl, nil, /* funcLit */
-		funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts)
+		funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts, nil /* taggedUnions */, nil /* oneOfs */)
 	// (3) Set up synchronization and communication for the goroutine we are going to spawn.
 	resultChan := make(chan functionResult)
 	wg := new(sync.WaitGroup)
@@ -144,6 +144,45 @@ func TestAnalyzeFuncPanic(t *testing.T) {
 	require.ErrorContains(t, res.err, "panic")
 }
 
+func TestAnalyzeFuncPanicPosition(t *testing.T) {
+	t.Parallel()
+
+	testdata := analysistest.TestData()
+
+	// First do an analysis test run just to get the pass variable.
+	r := analysistest.Run(t, testdata, Analyzer, "go.uber.org/pkg")
+	pass := r[0].Pass
+
+	var funcDecl *ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if f, ok := decl.(*ast.FuncDecl); ok && funcDecl == nil {
+				funcDecl = f
+			}
+		}
+	}
+	require.NotNil(t, funcDecl, "Cannot find a function declaration in test code")
+
+	resultChan := make(chan functionResult)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	// Intentionally give an empty function context to cause a panic while analyzing a real
+	// function, whose position should be attached to the error.
+	ctrlflowResult := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	go analyzeFunc(context.Background(), pass, funcDecl, assertiontree.FunctionContext{},
+		ctrlflowResult.FuncDecl(funcDecl), 0, resultChan, &wg)
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	res := <-resultChan
+	pos := pass.Fset.Position(funcDecl.Pos())
+	require.ErrorContains(t, res.err, "INTERNAL PANIC")
+	require.ErrorContains(t, res.err, fmt.Sprintf("analyzing function %s at %s:%d.%d", funcDecl.Name, pos.Filename, pos.Line, pos.Column))
+}
+
 func TestBackpropFixpointConvergence(t *testing.T) {
 	t.Parallel()
 
@@ -174,7 +213,7 @@ func TestBackpropFixpointConvergence(t *testing.T) {
 		emptyPkgFakeIdentMap := make(map[*ast.Ident]types.Object)
 		emptyFuncContracts := make(functioncontracts.Map)
 		funcContext := assertiontree.NewFunctionContext(pass, funcDecl, nil, /* funcLit */
-			funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts)
+			funcConfig, emptyFuncLitMap, emptyPkgFakeIdentMap, emptyFuncContracts, nil /* taggedUnions */, nil /* oneOfs */)
 		ctrlflowResult := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
 
 		ctx, cancel := context.WithCancel(context.Background())
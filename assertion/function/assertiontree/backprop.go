@@ -75,9 +75,24 @@ func backpropAcrossNode(rootNode *RootAssertionNode, node ast.Node) error {
 				return err
 			}
 		}
+		// Declarations without values (e.g., `var a [3]*int`) initialize arrays with zero values,
+		// i.e., all elements of an array of nilable type are nil.
+		if len(n.Values) == 0 {
+			for _, name := range n.Names {
+				if elem := rootNode.arrayZeroElemProducer(name); elem != nil {
+					rootNode.AddProduction(&annotation.ProduceTrigger{
+						Annotation: &annotation.ProduceTriggerNever{},
+						Expr:       name,
+					}, elem)
+				}
+			}
+		}
 	case *ast.SendStmt:
 		return backpropAcrossSend(rootNode, n)
 	case *ast.ExprStmt:
+		if call, ok := astutil.Unparen(n.X).(*ast.CallExpr); ok {
+			backpropAcrossDelete(rootNode, call)
+		}
 		rootNode.AddComputation(n.X)
 	case *ast.GoStmt:
 		rootNode.AddComputation(n.Call)
@@ -144,6 +159,72 @@ func backpropAcrossSend(rootNode *RootAssertionNode, node *ast.SendStmt) error {
 	return nil
 }
 
+// backpropAcrossDelete handles backpropagation for calls to the builtin `delete` function, e.g.,
+// `delete(m, "k")`, after which the reads of `m["k"]` yield the zero value (i.e., nil for maps of
+// nilable values) until the key is written again. It is designed to be called from
+// backpropAcrossNode as a special handler for expression statements.
+func backpropAcrossDelete(rootNode *RootAssertionNode, call *ast.CallExpr) {
+	fun, ok := astutil.Unparen(call.Fun).(*ast.Ident)
+	if !ok || rootNode.ObjectOf(fun) != util.BuiltinDelete || len(call.Args) != 2 {
+		return
+	}
+	t := rootNode.Pass().TypesInfo.TypeOf(call.Args[0])
+	if t == nil {
+		return
+	}
+	mapType, ok := t.Underlying().(*types.Map)
+	if !ok || util.TypeBarsNilness(mapType.Elem()) {
+		return
+	}
+	// We only handle the deletions of stable (e.g., constant) keys, since otherwise the key could
+	// have been changed between the deletion and the read.
+	if !rootNode.isStable(call.Args[1]) {
+		return
+	}
+
+	// The index expression is artificial, and it is only used for looking up the reads of the
+	// same key in the assertion tree.
+	rootNode.AddProduction(&annotation.ProduceTrigger{
+		Annotation: &annotation.DeletedMapValue{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}},
+		Expr:       &ast.IndexExpr{X: call.Args[0], Index: call.Args[1]},
+	})
+}
+
+// addProductionsForMapLitKeys handles the assignments of map literals of struct values (e.g.,
+// `m = map[string]S{"a": {...}}`), where the reads of the stable keys present in the literal (e.g.,
+// `m["a"]`) yield the values in the literal instead of the zero values. Therefore, the fields of
+// such reads are produced by the field annotations instead of as the fields of zero values (see
+// fldAssertionNode.isMapZeroValueFld).
+func (r *RootAssertionNode) addProductionsForMapLitKeys(lhs, rhs ast.Expr) {
+	lit, ok := astutil.Unparen(rhs).(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	t := r.Pass().TypesInfo.TypeOf(lit)
+	if t == nil {
+		return
+	}
+	mapType, ok := t.Underlying().(*types.Map)
+	if !ok {
+		return
+	}
+	if _, ok := mapType.Elem().Underlying().(*types.Struct); !ok {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok || !r.isStable(kv.Key) {
+			continue
+		}
+		// Similar to backpropAcrossDelete, the index expression is artificial, and it is only
+		// used for looking up the reads of the same key in the assertion tree.
+		r.AddProduction(&annotation.ProduceTrigger{
+			Annotation: &annotation.ProduceTriggerNever{},
+			Expr:       &ast.IndexExpr{X: lhs, Index: kv.Key},
+		})
+	}
+}
+
 // backpropAcrossReturn handles backpropagation for return statements. It is designed to be called
 // from backpropAcrossNode as a special handler.
 func backpropAcrossReturn(rootNode *RootAssertionNode, node *ast.ReturnStmt) error {
@@ -153,6 +234,7 @@ func backpropAcrossReturn(rootNode *RootAssertionNode, node *ast.ReturnStmt) err
 	if rootNode.functionContext.functionConfig.EnableStructInitCheck {
 		rootNode.addConsumptionsForFieldsOfParams()
 	}
+	rootNode.addDeferredResultConsumers(node)
 
 	if len(node.Results) == 1 {
 		if call, ok := node.Results[0].(*ast.CallExpr); ok {
@@ -170,7 +252,7 @@ func backpropAcrossReturn(rootNode *RootAssertionNode, node *ast.ReturnStmt) err
 				return true
 			}
 
-			switch fun := call.Fun.(type) {
+			switch fun := util.UnwrapInstantiation(rootNode.Pass().TypesInfo, call.Fun).(type) {
 			case *ast.Ident:
 				if !handleIdent(fun) {
 					return computeAndConsumeResults(rootNode, node)
@@ -322,7 +404,7 @@ func backpropAcrossAssignment(rootNode *RootAssertionNode, lhs, rhs []ast.Expr)
 		// currently handle the following cases in NilAway:
 		// 1. Map read: `v, ok := m[k]`
 		// 2. Channel receive: `v, ok := <-ch`
-		// TODO: 3. Type assertion: `v, ok := y.(*type)`
+		// 3. Type assertion: `v, ok := y.(*type)`
 		if len(lhs) == 2 {
 			rootNode.AddGuardMatch(lhs[0], ContinueTracking)
 
@@ -340,14 +422,19 @@ func backpropAcrossAssignment(rootNode *RootAssertionNode, lhs, rhs []ast.Expr)
 			// essentially required if the map is determined to be nilable, however, for a
 			// channel guarding logic is enforced only if it is in an `ok` form. That is why
 			// the NeedsGuard of ChanRecv is set to false in all other cases, but this one,
-			// where we know that it is an `ok` receive case.
+			// where we know that it is an `ok` receive case. The only exception is a channel
+			// annotated to be open (i.e., `//nilaway:open(ch)`), where `ok` is always true.
 			if r, ok := rhsNode.(*ast.UnaryExpr); ok && r.Op == token.ARROW {
 				rootNode.AddGuardMatch(r.X, ProduceAsNonnil)
 				// Add produce trigger for channel receive on the expression `v` here itself,
 				// since we want to set guarding = true.
 				if !util.IsEmptyExpr(lhs[0]) {
+					open := rootNode.functionContext.isOpenChan(r.X)
+					if !open {
+						rootNode.produceChanZeroValueFlds(lhs[0])
+					}
 					producer := exprAsDeepProducer(rootNode, r.X)
-					producer.SetNeedsGuard(true)
+					producer.SetNeedsGuard(!open)
 
 					rootNode.AddProduction(&annotation.ProduceTrigger{
 						// set the guard on channel receive since it is an ok form
@@ -361,8 +448,12 @@ func backpropAcrossAssignment(rootNode *RootAssertionNode, lhs, rhs []ast.Expr)
 			}
 
 			// Type assertion
+			// The value `v` is the zero value (i.e., nil for the types admitting nil) if the
+			// assertion fails, hence we produce it as nil for the uses in the branch where the
+			// `ok` is known to be false (e.g., `if !ok { ... }`). The other uses are handled as
+			// normal assignments.
 			if r, ok := rhsNode.(*ast.TypeAssertExpr); ok && r.Type != nil {
-				// TODO: properly handle type assertions' "OK" contract
+				rootNode.produceFailedTypeAssertion(lhs[0], r)
 				return backpropAcrossOneToOneAssignment(rootNode, lhs[0:1], rhs)
 			}
 		}
@@ -639,6 +730,7 @@ buildShadowMask:
 
 				} else {
 					// We're in case B
+					rootNode.addProductionsForMapLitKeys(lhsVal, rhsVal)
 					switch len(rproducers) {
 					case 0:
 						// lhsVal expression will never be nil here because rhsVal will never be nil
@@ -709,6 +801,16 @@ buildShadowMask:
 	return nil
 }
 
+// isErrReturningCall returns true if the call expression calls an error-returning function.
+func isErrReturningCall(rootNode *RootAssertionNode, call *ast.CallExpr) bool {
+	ident := util.FuncIdentFromCallExpr(rootNode.Pass().TypesInfo, call)
+	if ident == nil {
+		return false
+	}
+	funcObj, ok := rootNode.ObjectOf(ident).(*types.Func)
+	return ok && util.FuncIsErrReturning(funcObj)
+}
+
 // backpropAcrossManyToOneAssignment handles normal many-to-one assignment (e.g, "a, b := foo()"),
 // it is designed to be called from backpropAcrossAssignment as a finer-grained handler for
 // many-to-one normal assignments.
@@ -731,6 +833,10 @@ func backpropAcrossManyToOneAssignment(rootNode *RootAssertionNode, lhs, rhs []a
 		return errors.New("rhsVal function returned different number of results than expression " +
 			"present on lhs of assignment")
 	}
+	// If the error result of an error-returning function is explicitly discarded (e.g.,
+	// `v, _ := f()`), we mark the value results such that using them unsafely is reported with a
+	// dedicated message.
+	errDiscarded := len(lhs) > 0 && util.IsEmptyExpr(lhs[len(lhs)-1]) && isErrReturningCall(rootNode, rhsVal)
 	for i := range producers {
 
 		lhsVal := lhs[i]
@@ -749,9 +855,16 @@ func backpropAcrossManyToOneAssignment(rootNode *RootAssertionNode, lhs, rhs []a
 		// beforeTriggersLastIndex is used to find the newly added triggers on the next line
 		beforeTriggersLastIndex := len(rootNode.triggers)
 
+		shallow := producers[i].GetShallow().Annotation
+		if f, ok := shallow.(*annotation.FuncReturn); ok && errDiscarded {
+			discarded := *f
+			discarded.IsErrDiscarded = true
+			shallow = &discarded
+		}
+
 		rootNode.AddGuardMatch(lhsVal, ContinueTracking)
 		rootNode.AddProduction(&annotation.ProduceTrigger{
-			Annotation: producers[i].GetShallow().Annotation,
+			Annotation: shallow,
 			Expr:       lhsVal,
 		}, producers[i].GetDeepSlice()...)
 
@@ -846,7 +959,7 @@ func BackpropAcrossFunc(
 ) ([]annotation.FullTrigger, int, int, error) {
 	// We transform the CFG to have it reflect the implicit control flow that happens
 	// inside short-circuiting boolean expressions.
-	preprocessor := preprocess.New(pass)
+	preprocessor := preprocess.New(pass, functionContext.funcContracts, functionContext.taggedUnions, functionContext.oneOfs)
 	graph = preprocessor.CFG(graph, functionContext.funcDecl)
 
 	// Generate rick check effects.
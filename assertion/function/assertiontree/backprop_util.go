@@ -92,14 +92,14 @@ const knownNilableErrFunc = "sometimesErrs"
 // The best mechanism for this would be to somehow expose a fixed library function that serves this
 // purpose, but for now, we simply check that it has the special name "sometimesErrs" set above through
 // the constant `knownNilableErrFunc`
-func exprCallsKnownNilableErrFunc(expr ast.Expr) bool {
+func exprCallsKnownNilableErrFunc(info *types.Info, expr ast.Expr) bool {
 	callExpr, ok := expr.(*ast.CallExpr)
 
 	if !ok {
 		return false
 	}
 
-	ident := util.FuncIdentFromCallExpr(callExpr)
+	ident := util.FuncIdentFromCallExpr(info, callExpr)
 
 	if ident == nil {
 		// no ident - anonymous function
@@ -235,7 +235,7 @@ func isErrorReturnNil(rootNode *RootAssertionNode, errRet ast.Expr) bool {
 		return true
 	}
 
-	if exprCallsKnownNilableErrFunc(errRet) {
+	if exprCallsKnownNilableErrFunc(rootNode.Pass().TypesInfo, errRet) {
 		// error value is the return of a known nilable function
 		return true
 	}
@@ -546,7 +546,7 @@ func exprAsAssignmentConsumer(rootNode *RootAssertionNode, expr ast.Node, exprRH
 				}
 			case *ast.CallExpr:
 				// check if this is a call to a function by name
-				if ident := util.FuncIdentFromCallExpr(expr); ident != nil {
+				if ident := util.FuncIdentFromCallExpr(rootNode.Pass().TypesInfo, expr); ident != nil {
 					obj := rootNode.ObjectOf(ident).(*types.Func)
 					if obj.Type().(*types.Signature).Results().Len() != 1 {
 						return nil, errors.New("multiply returning function treated as assignment consumer")
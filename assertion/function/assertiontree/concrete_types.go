@@ -0,0 +1,129 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"go.uber.org/nilaway/util"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// collectConcreteTypes returns the concrete pointer types of the interface variables declared
+// within the body of the function, for the variables that are only assigned values of the same
+// pointer type (e.g., `var i I = t` and `i = u` for `t, u *T`). The variables that are declared
+// without values (i.e., initialized to nil interfaces), have their addresses taken, or are
+// assigned in any other way (e.g., by multiple-value assignments or range statements) are
+// omitted, since their dynamic types cannot be determined syntactically.
+func collectConcreteTypes(pass *analysis.Pass, decl *ast.FuncDecl) map[*types.Var]types.Type {
+	if decl == nil || decl.Body == nil {
+		return nil
+	}
+
+	concreteTypes := make(map[*types.Var]types.Type)
+	invalid := make(map[*types.Var]bool)
+	// localInterfaceVar returns the variable of the identifier if it is an interface variable
+	// declared within the function body.
+	localInterfaceVar := func(expr ast.Expr) *types.Var {
+		ident, ok := astutil.Unparen(expr).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		v, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+		if !ok || v.Pos() < decl.Body.Pos() || v.Pos() >= decl.Body.End() || !types.IsInterface(v.Type()) {
+			return nil
+		}
+		return v
+	}
+	// assign records the assignment of the value to the variable.
+	assign := func(v *types.Var, value ast.Expr) {
+		if v == nil || invalid[v] {
+			return
+		}
+		var t types.Type
+		if value != nil {
+			t = pass.TypesInfo.TypeOf(value)
+		}
+		if t == nil || !util.TypeIsDeeplyPtr(t) {
+			invalid[v] = true
+			return
+		}
+		if prev, ok := concreteTypes[v]; ok && !types.Identical(prev, t) {
+			invalid[v] = true
+			return
+		}
+		concreteTypes[v] = t
+	}
+
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				var value ast.Expr
+				if len(node.Lhs) == len(node.Rhs) {
+					value = node.Rhs[i]
+				}
+				assign(localInterfaceVar(lhs), value)
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				var value ast.Expr
+				if len(node.Names) == len(node.Values) {
+					value = node.Values[i]
+				}
+				assign(localInterfaceVar(name), value)
+			}
+		case *ast.RangeStmt:
+			assign(localInterfaceVar(node.Key), nil)
+			if node.Value != nil {
+				assign(localInterfaceVar(node.Value), nil)
+			}
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				assign(localInterfaceVar(node.X), nil)
+			}
+		}
+		return true
+	})
+
+	for v := range invalid {
+		delete(concreteTypes, v)
+	}
+	return concreteTypes
+}
+
+// concreteMethodOf returns the method of the concrete type for the method invocation on a local
+// interface variable (e.g., `i.M()` for `var i I = t` and `t *T`), or nil if the concrete type of
+// the variable is unknown.
+func (r *RootAssertionNode) concreteMethodOf(expr *ast.SelectorExpr) *types.Func {
+	ident, ok := astutil.Unparen(expr.X).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := r.ObjectOf(ident).(*types.Var)
+	if !ok {
+		return nil
+	}
+	t, ok := r.functionContext.concreteTypes[v]
+	if !ok {
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, r.Pass().Pkg, expr.Sel.Name)
+	method, _ := obj.(*types.Func)
+	return method
+}
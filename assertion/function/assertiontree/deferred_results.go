@@ -0,0 +1,159 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// deferredResultWrite is an assignment to a named result within a deferred function literal, which
+// runs after the return statements and hence overwrites the values they return. For example:
+//
+//	func f() (p *T) {
+//		defer func() { p = maybeNil() }()
+//		return &T{}
+//	}
+type deferredResultWrite struct {
+	// stmt is the defer statement, which only affects the return statements after it.
+	stmt *ast.DeferStmt
+	// index is the index of the named result among the results of the function.
+	index int
+	// value is the right-hand side of the assignment.
+	value ast.Expr
+}
+
+// collectDeferredResultWrites returns the assignments to the named results of the function within
+// the function literals that are directly deferred (i.e., `defer func() { ... }()`). The writes
+// are collected regardless of whether they happen conditionally, since the overwrite may happen.
+//
+// The error-returning and ok-returning functions are skipped, since their deferred writes (e.g.,
+// clearing the results if the error is non-nil) are bound to the error or ok contract that we
+// cannot model after the return statements. The values referencing any named result or any
+// variable declared within the function literal are skipped as well, since they cannot be
+// evaluated at the return statements.
+func collectDeferredResultWrites(pass *analysis.Pass, decl *ast.FuncDecl) []deferredResultWrite {
+	if decl == nil || decl.Body == nil || decl.Type.Results == nil {
+		return nil
+	}
+	if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && (util.FuncIsErrReturning(fn) || util.FuncIsOkReturning(fn)) {
+		return nil
+	}
+
+	results := make(map[types.Object]int)
+	i := 0
+	for _, field := range decl.Type.Results.List {
+		for _, name := range field.Names {
+			if obj := pass.TypesInfo.Defs[name]; obj != nil {
+				results[obj] = i
+			}
+			i++
+		}
+		if len(field.Names) == 0 {
+			i++
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	var writes []deferredResultWrite
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		stmt, ok := node.(*ast.DeferStmt)
+		if !ok {
+			return true
+		}
+		lit, ok := astutil.Unparen(stmt.Call.Fun).(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		ast.Inspect(lit.Body, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.FuncLit:
+				// The nested function literals are not necessarily called in the deferred call.
+				return false
+			case *ast.AssignStmt:
+				if node.Tok != token.ASSIGN || len(node.Lhs) != len(node.Rhs) {
+					return true
+				}
+				for j, lhs := range node.Lhs {
+					ident, ok := astutil.Unparen(lhs).(*ast.Ident)
+					if !ok {
+						continue
+					}
+					index, ok := results[pass.TypesInfo.Uses[ident]]
+					if !ok || !isEvaluableAfterReturn(pass, node.Rhs[j], lit, results) {
+						continue
+					}
+					writes = append(writes, deferredResultWrite{stmt: stmt, index: index, value: node.Rhs[j]})
+				}
+			}
+			return true
+		})
+		// The function literal has been inspected above.
+		return false
+	})
+	return writes
+}
+
+// isEvaluableAfterReturn returns true if the value assigned in the deferred function literal only
+// references the variables that are not changed by the return statements, i.e., it references
+// neither the named results nor the variables declared within the function literal.
+func isEvaluableAfterReturn(pass *analysis.Pass, value ast.Expr, lit *ast.FuncLit, results map[types.Object]int) bool {
+	evaluable := true
+	ast.Inspect(value, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok {
+			return evaluable
+		}
+		obj := pass.TypesInfo.Uses[ident]
+		if _, ok := results[obj]; ok {
+			evaluable = false
+		}
+		if obj != nil && obj.Pos() >= lit.Pos() && obj.Pos() < lit.End() {
+			evaluable = false
+		}
+		return evaluable
+	})
+	return evaluable
+}
+
+// addDeferredResultConsumers consumes the values written to the named results by the deferred
+// function literals (see collectDeferredResultWrites) as the results of the return statement, if
+// the defer statement precedes the return statement.
+func (r *RootAssertionNode) addDeferredResultConsumers(retStmt *ast.ReturnStmt) {
+	for _, w := range r.functionContext.deferredResultWrites {
+		if w.stmt.Pos() > retStmt.Pos() {
+			continue
+		}
+		r.AddComputation(w.value)
+		r.AddConsumption(&annotation.ConsumeTrigger{
+			Annotation: &annotation.UseAsReturn{
+				TriggerIfNonNil: &annotation.TriggerIfNonNil{
+					Ann: annotation.RetKeyFromRetNum(r.FuncObj(), w.index)},
+				IsNamedReturn: true,
+				RetStmt:       retStmt,
+			},
+			Expr:   w.value,
+			Guards: util.NoGuards(),
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"go/ast"
+	"go/types"
+
+	"go.uber.org/nilaway/annotation"
+)
+
+// derefAssertionNode is the node for the pointer dereference (i.e., `*x`) of its parent, such that
+// the nilability of the nilable values pointed to by the pointers can be tracked (e.g., `*p` is
+// nil after `p := new(*T)`).
+type derefAssertionNode struct {
+	assertionNodeCommon
+
+	// similar to indexAssertionNode, we need to remember the type of the dereferenced value
+	// because there is no identifier to look it up
+	valType types.Type
+}
+
+func (d *derefAssertionNode) MinimalString() string {
+	return "deref"
+}
+
+// DefaultTrigger for a deref node is the deep nilability annotation of its parent type
+func (d *derefAssertionNode) DefaultTrigger() annotation.ProducingAnnotationTrigger {
+	return deepNilabilityTriggerOf(d.Parent())
+}
+
+// BuildExpr for a deref node adds that dereference to `expr`
+func (d *derefAssertionNode) BuildExpr(expr ast.Expr) ast.Expr {
+	return &ast.StarExpr{X: expr}
+}
@@ -20,6 +20,7 @@ import (
 	"go/types"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util"
 )
 
 type fldAssertionNode struct {
@@ -51,8 +52,20 @@ func (f *fldAssertionNode) GetAncestorVarAssertionNode() *varAssertionNode {
 	return nil
 }
 
-// DefaultTrigger for a field node is that field's annotation
+// DefaultTrigger for a field node is that field's annotation, unless the field is read from a
+// struct read from a map, where it is the field of the zero value if the key is missing
 func (f *fldAssertionNode) DefaultTrigger() annotation.ProducingAnnotationTrigger {
+	if f.isMapZeroValueFld() {
+		return &annotation.MapZeroValueFld{
+			ProduceTriggerNever: &annotation.ProduceTriggerNever{NeedsGuard: true},
+			FieldName:           f.decl.Name(),
+		}
+	}
+	return f.fldTrigger()
+}
+
+// fldTrigger returns the trigger of the field's annotation
+func (f *fldAssertionNode) fldTrigger() annotation.ProducingAnnotationTrigger {
 	if f.functionContext.functionConfig.EnableStructInitCheck {
 		varNode := f.GetAncestorVarAssertionNode()
 		// If the field is not produced by a variable we default to the FieldAnnotationKey
@@ -72,6 +85,43 @@ func (f *fldAssertionNode) DefaultTrigger() annotation.ProducingAnnotationTrigge
 			}}}
 }
 
+// isMapZeroValueFld returns true if the field node is a nilable field of a struct value read from a
+// map (e.g., `m[k].f` for `m` of type `map[K]S`), which is nil if the key is missing.
+func (f *fldAssertionNode) isMapZeroValueFld() bool {
+	idx, ok := f.Parent().(*indexAssertionNode)
+	if !ok || idx.recvType == nil || idx.valType == nil || util.TypeBarsNilness(f.decl.Type()) {
+		return false
+	}
+	if _, ok := idx.recvType.Underlying().(*types.Map); !ok {
+		return false
+	}
+	_, ok = idx.valType.Underlying().(*types.Struct)
+	return ok
+}
+
+// mapZeroValueFldChildren returns the children of the node that are nilable fields of a struct
+// value read from a map (see fldAssertionNode.isMapZeroValueFld).
+func mapZeroValueFldChildren(node AssertionNode) []AssertionNode {
+	var children []AssertionNode
+	for _, child := range node.Children() {
+		if fld, ok := child.(*fldAssertionNode); ok && fld.isMapZeroValueFld() {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// nilableFldChildren returns the children of the node that are fields whose types admit nil.
+func nilableFldChildren(node AssertionNode) []*fldAssertionNode {
+	var children []*fldAssertionNode
+	for _, child := range node.Children() {
+		if fld, ok := child.(*fldAssertionNode); ok && !util.TypeBarsNilness(fld.decl.Type()) {
+			children = append(children, fld)
+		}
+	}
+	return children
+}
+
 // BuildExpr for a field node adds that field access to the expression `expr`
 func (f *fldAssertionNode) BuildExpr(expr ast.Expr) ast.Expr {
 	if f.Root() == nil {
@@ -20,6 +20,7 @@ import (
 
 	"go.uber.org/nilaway/assertion/anonymousfunc"
 	"go.uber.org/nilaway/assertion/function/functioncontracts"
+	"go.uber.org/nilaway/assertion/function/preprocess"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -63,6 +64,23 @@ type FunctionContext struct {
 
 	// funcContracts stores the function contracts of all the functions.
 	funcContracts functioncontracts.Map
+
+	// taggedUnions stores the fields of tagged union structs guarded by their tag fields.
+	taggedUnions preprocess.TaggedUnions
+
+	// oneOfs stores the oneof groups of struct fields.
+	oneOfs preprocess.OneOfs
+
+	// concreteTypes stores the concrete pointer types of the local interface variables that are
+	// only assigned values of a single concrete pointer type (see collectConcreteTypes).
+	concreteTypes map[*types.Var]types.Type
+
+	// openChans stores the channel variables annotated to be never closed (see collectOpenChans).
+	openChans map[*types.Var]bool
+
+	// deferredResultWrites stores the writes to the named results within the deferred function
+	// literals (see collectDeferredResultWrites).
+	deferredResultWrites []deferredResultWrite
 }
 
 // FunctionConfig is meant to hold all the user set configuration for analyzing a function
@@ -71,6 +89,28 @@ type FunctionConfig struct {
 	EnableStructInitCheck bool
 	// EnableAnonymousFunc is a flag to enable checking anonymous functions.
 	EnableAnonymousFunc bool
+	// ConservativeUnknownCalls is a flag to treat the results of unresolved calls (e.g., calls
+	// through function values) as nilable.
+	ConservativeUnknownCalls bool
+	// StrictMapReads is a flag to require the comma-ok form for all reads from maps whose values
+	// can be nil.
+	StrictMapReads bool
+	// NonnilUnsafeConversions is a flag to treat the results of conversions from `unsafe.Pointer`
+	// as nonnil instead of nilable.
+	NonnilUnsafeConversions bool
+	// ConcreteInterfaceReceivers is a flag to resolve the method calls on local interface variables
+	// with a single known concrete pointer type to the methods of the concrete type.
+	ConcreteInterfaceReceivers bool
+	// StrictInternalErrors is a flag to propagate the internal errors (i.e., panics) instead of
+	// recovering from them and degrading the analysis silently.
+	StrictInternalErrors bool
+	// PositiveNilChecks is a flag to treat the expressions checked to be nil (e.g., `x` in the
+	// true branch of `if x == nil`) as definitely nil in the corresponding branches. It is only
+	// enabled in the no-inference mode, where these are among the syntactically-certain nil panics.
+	PositiveNilChecks bool
+	// NilableFuncParams is a flag to check the calls of the function-typed parameters for nil,
+	// since the function types are configured nilable by default (see config.TypeCategoryFunc).
+	NilableFuncParams bool
 }
 
 // NewFunctionContext returns a new FunctionContext and initializes all the maps
@@ -82,7 +122,13 @@ func NewFunctionContext(
 	funcLitMap map[*ast.FuncLit]*anonymousfunc.FuncLitInfo,
 	pkgFakeIdentMap map[*ast.Ident]types.Object,
 	funcContracts functioncontracts.Map,
+	taggedUnions preprocess.TaggedUnions,
+	oneOfs preprocess.OneOfs,
 ) FunctionContext {
+	var concreteTypes map[*types.Var]types.Type
+	if functionConfig.ConcreteInterfaceReceivers {
+		concreteTypes = collectConcreteTypes(pass, decl)
+	}
 	return FunctionContext{
 		pass:                    pass,
 		funcDecl:                decl,
@@ -93,6 +139,11 @@ func NewFunctionContext(
 		funcLitMap:              funcLitMap,
 		pkgFakeIdentMap:         pkgFakeIdentMap,
 		funcContracts:           funcContracts,
+		taggedUnions:            taggedUnions,
+		oneOfs:                  oneOfs,
+		concreteTypes:           concreteTypes,
+		openChans:               collectOpenChans(pass, decl),
+		deferredResultWrites:    collectDeferredResultWrites(pass, decl),
 	}
 }
 
@@ -0,0 +1,92 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assertiontree
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"go.uber.org/nilaway/util"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// _openKeyword is the keyword for annotating the channels that are never closed while the
+// function receives from them, such that the receives always succeed.
+const _openKeyword = "nilaway:open"
+
+// _openRE matches the open directive in its own line, which looks like
+// `nilaway:open(CH1, CH2, ...)`. The RE captures the comma-separated list of variable names.
+var _openRE = regexp.MustCompile(
+	fmt.Sprintf("^\\s*//\\s*%s\\s*\\(\\s*([\\p{L}_][\\p{L}\\p{N}_]*(?:\\s*,\\s*[\\p{L}_][\\p{L}\\p{N}_]*)*)\\s*\\)\\s*$", _openKeyword))
+
+// collectOpenChans returns the channel variables (i.e., the parameters, receiver, or local
+// variables of the function) named in the open directives in the doc comment of the function. For
+// example, the following directive states that `ch` is never closed, such that the `ok` of the
+// receive is always true and the received value is not the zero value of a closed channel:
+//
+//	//nilaway:open(ch)
+//	func consume(ch chan *T) {
+//		v, ok := <-ch
+//		...
+//	}
+func collectOpenChans(pass *analysis.Pass, decl *ast.FuncDecl) map[*types.Var]bool {
+	if decl == nil || decl.Doc == nil {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for _, comment := range decl.Doc.List {
+		matching := _openRE.FindStringSubmatch(comment.Text)
+		if matching == nil {
+			continue
+		}
+		// matching is a slice of two elements; the first is the whole matched string and the
+		// second is the captured list of variable names.
+		for _, name := range strings.Split(matching[1], ",") {
+			names[strings.TrimSpace(name)] = true
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	openChans := make(map[*types.Var]bool)
+	ast.Inspect(decl, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok || !names[ident.Name] {
+			return true
+		}
+		if v, ok := pass.TypesInfo.Defs[ident].(*types.Var); ok && util.TypeIsDeeplyChan(v.Type()) {
+			openChans[v] = true
+		}
+		return true
+	})
+	return openChans
+}
+
+// isOpenChan returns true if the expression is a channel variable annotated to be open (see
+// collectOpenChans).
+func (fc *FunctionContext) isOpenChan(expr ast.Expr) bool {
+	ident, ok := astutil.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := fc.pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	return ok && fc.openChans[v]
+}
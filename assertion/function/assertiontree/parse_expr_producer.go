@@ -23,6 +23,8 @@ import (
 	"go.uber.org/nilaway/assertion/function/producer"
 	"go.uber.org/nilaway/assertion/function/trustedfunc"
 	"go.uber.org/nilaway/util"
+	"go.uber.org/nilaway/util/asthelper"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 // ParseExprAsProducer takes an expression, and determines whether it is `trackable` - i.e. if it is a
@@ -246,9 +248,21 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			}
 		}
 
+		// Conversions from `unsafe.Pointer` are opaque, so their results are nilable unless
+		// configured otherwise (unlike other conversions, which are assumed to be nonnil below).
+		if r.isUnsafePointerConversion(expr) {
+			return nil, r.getUnsafePointerConversionProducers(expr)
+		}
+
+		// Calls to generic functions that only forward the results of their callback arguments
+		// (e.g., `Apply(f)`) carry the nilability of the results of the callbacks.
+		if producers := r.getForwardedCallbackProducers(expr); producers != nil {
+			return nil, producers
+		}
+
 		// the cases of a function and method call are different enough here that it would be useless
 		// to try to subsume this switch with funcIdentFromCallExpr
-		switch fun := expr.Fun.(type) {
+		switch fun := util.UnwrapInstantiation(r.Pass().TypesInfo, expr.Fun).(type) {
 		case *ast.Ident: // direct function call
 			if !r.isFunc(fun) {
 				// The following block implements the basic support for append function where it has
@@ -276,6 +290,24 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 					}
 				}
 
+				// The builtin `new` of a nilable type (e.g., `p := new(*T)`) returns a nonnil
+				// pointer to the nil zero value of the type, hence the result is deeply nilable.
+				if elem := r.newZeroValueElemProducer(expr, expr); elem != nil {
+					return nil, []producer.ParsedProducer{producer.DeepParsedProducer{
+						ShallowProducer: &annotation.ProduceTrigger{
+							Annotation: &annotation.ProduceTriggerNever{},
+							Expr:       expr,
+						},
+						DeepProducer: elem,
+					}}
+				}
+
+				// Calls through function values (e.g., `fn()` where `fn` is a variable or a
+				// parameter) cannot be resolved statically.
+				if r.isVariable(fun) {
+					return nil, r.getUnresolvedCallProducers(expr)
+				}
+
 				// for builtin funcs (e.g. new, make), we assume their return is never nil
 				// similarly, we assume type casts (e.g. `int(x)`) never return nil
 				// anonymous functions will also fall into this case
@@ -292,6 +324,10 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 
 		case *ast.SelectorExpr: // method call
 			if !r.isFunc(fun.Sel) {
+				// Calls through function-typed fields (e.g., `s.fn()`) cannot be resolved statically.
+				if r.isVariable(fun.Sel) {
+					return nil, r.getUnresolvedCallProducers(expr)
+				}
 				// we assume builtins and type casts don't return nil
 				return nil, nil
 			}
@@ -318,11 +354,32 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			// this could result from calling a function returned anonymously from another function, such as f(4)(3), and
 			// although theoretically we should track that, we're going to leave it as an unhandled edge case for now
 			// TODO: consider handling this case (and similar case in backPropAcrossReturn)
+			// Calls of function literals are not considered unresolved since their bodies are known.
+			if _, ok := astutil.Unparen(expr.Fun).(*ast.FuncLit); ok {
+				return nil, nil
+			}
+			if tv, ok := r.Pass().TypesInfo.Types[expr.Fun]; ok && !tv.IsType() {
+				return nil, r.getUnresolvedCallProducers(expr)
+			}
 			return nil, nil
 		}
 	case *ast.IndexExpr:
 		recv, rproducers := r.ParseExprAsProducer(expr.X, false)
 
+		if r.isStrictMapRead(expr) {
+			// In the strict map reads mode, the read is never tracked (such that the writes to or
+			// the nil checks on the same index do not apply) and always requires a guard, i.e.,
+			// it is nilable unless it is in the `v, ok := m[k]` form and `ok` is checked.
+			parsed := parseDeepRead(recv, expr.X, expr, rproducers)
+			shallow := parsed[0].GetShallow()
+			if _, ok := shallow.Annotation.(*annotation.ProduceTriggerNever); ok {
+				// The deep nilability of the map is unknown (e.g., for nested maps), where we
+				// give a more informative annotation for the error message.
+				shallow.Annotation = &annotation.StrictMapRead{ProduceTriggerNever: &annotation.ProduceTriggerNever{}}
+			}
+			shallow.Annotation.SetNeedsGuard(true)
+			return nil, parsed
+		}
 		if doNotTrack {
 			return nil, parseDeepRead(recv, expr.X, expr, rproducers)
 		}
@@ -409,9 +466,19 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			return nil, nil
 		}
 	case *ast.StarExpr:
-		recv, rproducers := r.ParseExprAsProducer(expr.X, false)
+		if p := r.newZeroValueProducer(expr); p != nil {
+			return nil, []producer.ParsedProducer{producer.ShallowParsedProducer{Producer: p}}
+		}
+
+		recv, rproducers := r.ParseExprAsProducer(expr.X, doNotTrack)
 
-		// TODO - if `recv` is trackable, then track expression instead, as in the index case
+		// if `recv` is trackable and the dereferenced value is nilable, then track the
+		// dereference too, as in the index case (e.g., such that `*p` is nil after `p := new(*T)`)
+		if recv != nil {
+			if valType := r.Pass().TypesInfo.TypeOf(expr); valType != nil && !util.TypeBarsNilness(valType) {
+				return append(recv, &derefAssertionNode{valType: valType}), nil
+			}
+		}
 
 		return nil, parseDeepRead(recv, expr.X, expr, rproducers)
 	case *ast.UnaryExpr:
@@ -421,8 +488,10 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 			return nil, parseDeepRead(nil, expr.X, expr, rproducers)
 		}
 		if expr.Op == token.AND {
-			// we treat a struct object pointer (e.g., &A{}) and struct object (e.g., A{}) identically for creating field producers
-			if s := util.TypeAsDeeplyStruct(r.Pass().TypesInfo.TypeOf(expr.X)); s != nil {
+			// we treat a struct object pointer (e.g., &A{}) and struct object (e.g., A{}) identically for creating field producers.
+			// The address of a pointer (e.g., `&p` for `p *A`) is a different (nonnil) value than
+			// the pointer itself, so it is not treated as such.
+			if t := r.Pass().TypesInfo.TypeOf(expr.X); !util.TypeIsDeeplyPtr(t) && util.TypeAsDeeplyStruct(t) != nil {
 				return r.ParseExprAsProducer(expr.X, doNotTrack)
 			}
 		}
@@ -437,12 +506,79 @@ func (r *RootAssertionNode) ParseExprAsProducer(expr ast.Expr, doNotTrack bool)
 				return nil, []producer.ParsedProducer{rproducer}
 			}
 		}
+		// TODO: we currently only handle empty array literals (e.g., `[3]*int{}`) since a deeper
+		//  producer applies to all elements. Partially initialized array literals (e.g.,
+		//  `[3]*int{x}`) should produce nil only for the elements that are not initialized.
+		if elem := r.arrayZeroElemProducer(expr); elem != nil && len(expr.Elts) == 0 {
+			return nil, []producer.ParsedProducer{producer.DeepParsedProducer{
+				ShallowProducer: &annotation.ProduceTrigger{
+					Annotation: &annotation.ProduceTriggerNever{},
+					Expr:       expr,
+				},
+				DeepProducer: elem,
+			}}
+		}
 		return nil, nil
 	}
 	// TODO: right now this default case assumes that unhandled expressions are non-nil, consider changing this
 	return nil, nil
 }
 
+// arrayZeroElemProducer returns a producer for the zero-valued (i.e., nil) elements of the
+// array-typed expression `expr`, or nil if `expr` is not a non-empty array of a nilable type.
+func (r *RootAssertionNode) arrayZeroElemProducer(expr ast.Expr) *annotation.ProduceTrigger {
+	t := r.Pass().TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	arr, ok := t.Underlying().(*types.Array)
+	if !ok || arr.Len() == 0 || util.TypeBarsNilness(arr.Elem()) {
+		return nil
+	}
+	return &annotation.ProduceTrigger{
+		Annotation: &annotation.UnassignedArrayElem{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}},
+		Expr:       expr,
+	}
+}
+
+// newZeroValueProducer returns a producer for the zero value obtained by dereferencing a fresh
+// allocation of the builtin `new` (i.e., `*new(T)`), or nil if `expr` is not of that form or `T`
+// is not nilable. Note that `T` is nilable if it is a type parameter that admits nilable types,
+// where the nilability at the call sites depends on the instantiations (e.g., `*new(V)` is nil
+// only for pointer instantiations of `V`).
+func (r *RootAssertionNode) newZeroValueProducer(expr *ast.StarExpr) *annotation.ProduceTrigger {
+	call, ok := astutil.Unparen(expr.X).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	return r.newZeroValueElemProducer(call, expr)
+}
+
+// newZeroValueElemProducer returns a producer at `expr` for the zero value pointed to by the
+// result of `call`, or nil if `call` is not a call to the builtin `new` (i.e., `new(T)`) or `T` is
+// not nilable. The result of `new(T)` itself is always nonnil, but it points to the zero value of
+// `T`, which is nil for the nilable types (e.g., `**new(*T)` panics).
+func (r *RootAssertionNode) newZeroValueElemProducer(call *ast.CallExpr, expr ast.Expr) *annotation.ProduceTrigger {
+	if len(call.Args) != 1 {
+		return nil
+	}
+	fun, ok := astutil.Unparen(call.Fun).(*ast.Ident)
+	if !ok || r.ObjectOf(fun) != util.BuiltinNew {
+		return nil
+	}
+	t := r.Pass().TypesInfo.TypeOf(call.Args[0])
+	if t == nil || util.TypeBarsNilness(t) {
+		return nil
+	}
+	return &annotation.ProduceTrigger{
+		Annotation: &annotation.NewZeroValue{
+			ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+			TypeName:                types.TypeString(t, types.RelativeTo(r.Pass().Pkg)),
+		},
+		Expr: expr,
+	}
+}
+
 // getFuncReturnProducers returns a list of producers that are triggered at the call expression
 func (r *RootAssertionNode) getFuncReturnProducers(ident *ast.Ident, expr *ast.CallExpr) []producer.ParsedProducer {
 	funcObj := r.ObjectOf(ident).(*types.Func)
@@ -495,6 +631,271 @@ func (r *RootAssertionNode) getFuncReturnProducers(ident *ast.Ident, expr *ast.C
 	return producers
 }
 
+// getUnresolvedCallProducers returns a list of producers for the results of a call whose callee
+// cannot be resolved statically (e.g., a call through a function value). The results are assumed
+// to be nonnil (i.e., nil is returned) unless the conservative handling of such calls is enabled,
+// in which case the nilable results are produced as always nilable.
+func (r *RootAssertionNode) getUnresolvedCallProducers(expr *ast.CallExpr) []producer.ParsedProducer {
+	if tdecl := r.calleeFuncType(expr); tdecl != nil {
+		return r.getFuncTypeReturnProducers(expr, tdecl)
+	}
+	if !r.functionContext.functionConfig.ConservativeUnknownCalls {
+		return nil
+	}
+	sig, ok := r.Pass().TypesInfo.TypeOf(expr.Fun).Underlying().(*types.Signature)
+	if !ok || sig.Results().Len() == 0 {
+		return nil
+	}
+	callee, err := asthelper.PrintExpr(expr.Fun, r.Pass(), true /* isShortenExpr */)
+	if err != nil {
+		callee = "<func value>"
+	}
+
+	producers := make([]producer.ParsedProducer, sig.Results().Len())
+	for i := range producers {
+		var ann annotation.ProducingAnnotationTrigger = &annotation.ProduceTriggerNever{}
+		if !util.TypeBarsNilness(sig.Results().At(i).Type()) {
+			ann = &annotation.UnresolvedCallResult{
+				ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+				Callee:                  callee,
+				ResultNum:               i,
+			}
+		}
+		producers[i] = producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{Annotation: ann, Expr: expr}}
+	}
+	return producers
+}
+
+// calleeFuncType returns the declaration of the named function type (e.g., `Handler` for
+// `type Handler func(*Request) *Response`) of the callee of the call, or nil if the callee is not
+// a value of a named function type.
+func (r *RootAssertionNode) calleeFuncType(expr *ast.CallExpr) *types.TypeName {
+	tv, ok := r.Pass().TypesInfo.Types[expr.Fun]
+	if !ok || tv.IsType() {
+		return nil
+	}
+	named, ok := tv.Type.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Signature); !ok {
+		return nil
+	}
+	return named.Origin().Obj()
+}
+
+// funcReadFromMap returns the callee of the call if it is a function value read from a map (e.g.,
+// `handlers[k]` in `handlers[k]()`), and nil otherwise.
+func (r *RootAssertionNode) funcReadFromMap(expr *ast.CallExpr) *ast.IndexExpr {
+	index, ok := astutil.Unparen(expr.Fun).(*ast.IndexExpr)
+	if !ok {
+		return nil
+	}
+	t := r.Pass().TypesInfo.TypeOf(index.X)
+	if t == nil {
+		return nil
+	}
+	if m, ok := t.Underlying().(*types.Map); ok {
+		if _, ok := m.Elem().Underlying().(*types.Signature); ok {
+			return index
+		}
+	}
+	return nil
+}
+
+// isFuncParam returns true iff the callee expression is a (possibly parenthesized) parameter of
+// a function type of the current function.
+func (r *RootAssertionNode) isFuncParam(fun ast.Expr) bool {
+	ident, ok := astutil.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := r.ObjectOf(ident).(*types.Var)
+	if !ok || !annotation.VarIsParam(r.FuncObj(), v) {
+		return false
+	}
+	_, ok = v.Type().Underlying().(*types.Signature)
+	return ok
+}
+
+// getFuncTypeReturnProducers returns a list of producers for the results of a call through a value
+// of the named function type, which are produced by the annotations of the results of that type.
+func (r *RootAssertionNode) getFuncTypeReturnProducers(expr *ast.CallExpr, tdecl *types.TypeName) []producer.ParsedProducer {
+	sig := r.Pass().TypesInfo.TypeOf(expr.Fun).Underlying().(*types.Signature)
+	producers := make([]producer.ParsedProducer, sig.Results().Len())
+	for i := range producers {
+		var ann annotation.ProducingAnnotationTrigger = &annotation.ProduceTriggerNever{}
+		if !util.TypeBarsNilness(sig.Results().At(i).Type()) {
+			ann = &annotation.FuncReturn{
+				TriggerIfNilable: &annotation.TriggerIfNilable{
+					Ann: &annotation.FuncTypeRetAnnotationKey{TypeDecl: tdecl, RetNum: i},
+				},
+			}
+		}
+		producers[i] = producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{Annotation: ann, Expr: expr}}
+	}
+	return producers
+}
+
+// getForwardedCallbackProducers returns a list of producers for the results of a call to a generic
+// function (declared in the current package) whose return statements all return the results of
+// calling the same parameter typed by a function-typed type parameter, e.g.,
+//
+//	func Apply[F func() *T, T any](f F) *T { return f() }
+//
+// If the argument for the parameter is a declared function, the results of the call are produced
+// as the results of that function (e.g., `Apply(g)` is treated as `g()`). Otherwise, or if the
+// call is not of this form, nil is returned.
+func (r *RootAssertionNode) getForwardedCallbackProducers(expr *ast.CallExpr) []producer.ParsedProducer {
+	var calleeIdent *ast.Ident
+	switch fun := util.UnwrapInstantiation(r.Pass().TypesInfo, expr.Fun).(type) {
+	case *ast.Ident:
+		calleeIdent = fun
+	case *ast.SelectorExpr:
+		calleeIdent = fun.Sel
+	default:
+		return nil
+	}
+	callee, ok := r.ObjectOf(calleeIdent).(*types.Func)
+	if !ok || callee.Pkg() != r.Pass().Pkg {
+		return nil
+	}
+	callee = callee.Origin()
+	sig := callee.Type().(*types.Signature)
+	if sig.TypeParams().Len() == 0 || sig.Recv() != nil || sig.Variadic() || sig.Results().Len() == 0 {
+		return nil
+	}
+
+	param := r.forwardedCallbackParam(callee)
+	if param == nil {
+		return nil
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		if sig.Params().At(i) != param || i >= len(expr.Args) {
+			continue
+		}
+		var argIdent *ast.Ident
+		switch arg := util.UnwrapInstantiation(r.Pass().TypesInfo, astutil.Unparen(expr.Args[i])).(type) {
+		case *ast.Ident:
+			argIdent = arg
+		case *ast.SelectorExpr:
+			argIdent = arg.Sel
+		default:
+			return nil
+		}
+		argFunc, ok := r.ObjectOf(argIdent).(*types.Func)
+		if !ok || util.FuncNumResults(argFunc) != sig.Results().Len() {
+			return nil
+		}
+		return r.getFuncReturnProducers(argIdent, expr)
+	}
+	return nil
+}
+
+// forwardedCallbackParam returns the parameter of the generic function (declared in the current
+// package) typed by a type parameter, such that all return statements of the function directly
+// return the results of calling it, e.g., `f` in `func Apply[F func() *T, T any](f F) *T`. Nil is
+// returned if there is no such parameter.
+func (r *RootAssertionNode) forwardedCallbackParam(callee *types.Func) *types.Var {
+	var decl *ast.FuncDecl
+	for _, file := range r.Pass().Files {
+		for _, d := range file.Decls {
+			if funcDecl, ok := d.(*ast.FuncDecl); ok && r.Pass().TypesInfo.Defs[funcDecl.Name] == callee {
+				decl = funcDecl
+				break
+			}
+		}
+	}
+	if decl == nil || decl.Body == nil {
+		return nil
+	}
+
+	var param *types.Var
+	forwarding := true
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		if !forwarding {
+			return false
+		}
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			// The return statements in the function literals do not return from the function.
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) != 1 {
+				forwarding = false
+				return false
+			}
+			call, ok := astutil.Unparen(node.Results[0]).(*ast.CallExpr)
+			if !ok {
+				forwarding = false
+				return false
+			}
+			ident, ok := astutil.Unparen(call.Fun).(*ast.Ident)
+			if !ok {
+				forwarding = false
+				return false
+			}
+			v, ok := r.Pass().TypesInfo.Uses[ident].(*types.Var)
+			if !ok || (param != nil && v != param) || !isParamOf(v, callee) {
+				forwarding = false
+				return false
+			}
+			if _, ok := v.Type().(*types.TypeParam); !ok {
+				forwarding = false
+				return false
+			}
+			param = v
+		}
+		return true
+	})
+	if !forwarding {
+		return nil
+	}
+	return param
+}
+
+// isParamOf returns true if the variable is one of the parameters of the function.
+func isParamOf(v *types.Var, fn *types.Func) bool {
+	params := fn.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		if params.At(i) == v {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnsafePointerConversion returns true if the call is a conversion from `unsafe.Pointer` to a
+// type that can be nil, e.g., `(*T)(unsafe.Pointer(p))`. Note that `unsafe.Pointer` itself is
+// not considered nilable (it cannot be dereferenced), so the conversions to it need no handling.
+func (r *RootAssertionNode) isUnsafePointerConversion(expr *ast.CallExpr) bool {
+	if tv, ok := r.Pass().TypesInfo.Types[expr.Fun]; !ok || !tv.IsType() || len(expr.Args) != 1 {
+		return false
+	}
+	to, from := r.Pass().TypesInfo.TypeOf(expr), r.Pass().TypesInfo.TypeOf(expr.Args[0])
+	if to == nil || from == nil || util.TypeBarsNilness(to) {
+		return false
+	}
+	basic, ok := from.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.UnsafePointer
+}
+
+// getUnsafePointerConversionProducers returns a list of producers for the result of a conversion
+// from `unsafe.Pointer`, which is produced as always nilable, unless the conversions are
+// configured to be nonnil (in which case nil is returned, as for other conversions).
+func (r *RootAssertionNode) getUnsafePointerConversionProducers(expr *ast.CallExpr) []producer.ParsedProducer {
+	if r.functionContext.functionConfig.NonnilUnsafeConversions {
+		return nil
+	}
+	return []producer.ParsedProducer{producer.ShallowParsedProducer{Producer: &annotation.ProduceTrigger{
+		Annotation: &annotation.UnsafePointerConversion{
+			ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+			Type:                    types.TypeString(r.Pass().TypesInfo.TypeOf(expr), types.RelativeTo(r.Pass().Pkg)),
+		},
+		Expr: expr,
+	}}}
+}
+
 // parseStructCreateExprAsProducer parses composite expressions used to initialize a struct e.g. A{f1: v1, f2: v2}
 func (r *RootAssertionNode) parseStructCreateExprAsProducer(expr ast.Expr, fieldInitializations []ast.Expr) producer.ParsedProducer {
 	exprType := r.Pass().TypesInfo.TypeOf(expr)
@@ -540,3 +941,17 @@ func (r *RootAssertionNode) parseStructCreateExprAsProducer(expr ast.Expr, field
 
 	return nil
 }
+
+// isStrictMapRead returns true if the strict map reads mode is enabled and the index expression
+// reads from a map whose values can be nil.
+func (r *RootAssertionNode) isStrictMapRead(expr *ast.IndexExpr) bool {
+	if !r.functionContext.functionConfig.StrictMapReads {
+		return false
+	}
+	t := r.Pass().TypesInfo.TypeOf(expr.X)
+	if t == nil {
+		return false
+	}
+	m, ok := t.Underlying().(*types.Map)
+	return ok && !util.TypeBarsNilness(m.Elem())
+}
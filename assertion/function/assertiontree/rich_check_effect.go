@@ -104,6 +104,7 @@ func (f *FuncErrRet) equals(effect RichCheckEffect) bool {
 // Concrete examples of patterns supported are:
 // - map ok read: `v, ok := m[k]`
 // - channel ok receive: `v, ok := <-ch`
+// - type assertion ok: `v, ok := x.(*T)`
 // - function ok return: `r0, r1, r2, ..., ok := f()`
 type okRead struct {
 	root  *RootAssertionNode // an associated root node
@@ -166,12 +167,41 @@ type ChannelOkRecv struct {
 	okRead
 }
 
+// effectIfTrue guards the received value, as well as its fields if it is a struct, since they are
+// the fields of the zero value only if the channel is closed (see produceChanZeroValueFlds).
+func (c *ChannelOkRecv) effectIfTrue(node *RootAssertionNode) {
+	c.okRead.effectIfTrue(node)
+	if lookedUpNode, _ := node.lookupPath(c.value); lookedUpNode != nil {
+		for _, child := range nilableFldChildren(lookedUpNode) {
+			child.SetConsumeTriggers(annotation.ConsumeTriggerSliceAsGuarded(child.ConsumeTriggers(), c.guard))
+		}
+	}
+}
+
 // A ChannelOkRecvRefl indicates that a channel receive was encountered with a `v, ok := <-chan` assignment, and now
 // if `ok` is checked it should produce non-nil for `chan` because it cannot be nil if `ok` is true.
 type ChannelOkRecvRefl struct {
 	okRead
 }
 
+// A TypeAssertOkRead is a RichCheckEffect for the `ok` in `v, ok := x.(*T)` assignment, where `v`
+// is nil if the assertion fails. Unlike the other ok forms, it guards `v` in the branch where `ok`
+// is false instead (e.g., `if !ok { }`), such that the uses of `v` in that branch are produced as
+// nil at the assignment (see produceFailedTypeAssertion), while the uses elsewhere are unaffected.
+// To have the intended effect, the check must be encountered before an assignment to either `v`
+// or `ok`.
+type TypeAssertOkRead struct {
+	okRead
+}
+
+func (t *TypeAssertOkRead) effectIfTrue(*RootAssertionNode) {
+	// no-op
+}
+
+func (t *TypeAssertOkRead) effectIfFalse(node *RootAssertionNode) {
+	guardExpr(node, t.value, t.guard)
+}
+
 // A FuncOkReturn is a RichCheckEffect for the `ok` in `r0, r1, r2, ..., ok := f()`, where the
 // function `f` has a final result of type `bool` - and until this is checked all other results are
 // assumed nilable. For proper invalidation, each stored return of a function is treated as a separate effect
@@ -221,9 +251,11 @@ func RichCheckFromNode(rootNode *RootAssertionNode, nonceGenerator *util.GuardNo
 //     analysis pass before we call ParseExprAsProducer below)
 func parseExpr(rootNode *RootAssertionNode, expr ast.Expr) TrackableExpr {
 	defer func() {
-		// This handles unexpected panics during parsing.
+		// This handles unexpected panics during parsing, unless the internal errors are strict.
 		// TODO: consider removing this hack.
-		_ = recover()
+		if r := recover(); r != nil && rootNode.functionContext.functionConfig.StrictInternalErrors {
+			panic(r)
+		}
 	}()
 	// this handles being passed the empty expression
 	if util.IsEmptyExpr(expr) {
@@ -233,10 +265,11 @@ func parseExpr(rootNode *RootAssertionNode, expr ast.Expr) TrackableExpr {
 	return parsed
 }
 
-// NodeTriggersOkRead is a case of a node creating a rich bool effect for map reads, channel receives, and user-defined
-// functions in the "ok" form. Specifically, it matches on `AssignStmt`s of the form
+// NodeTriggersOkRead is a case of a node creating a rich bool effect for map reads, channel receives, type assertions,
+// and user-defined functions in the "ok" form. Specifically, it matches on `AssignStmt`s of the form
 // - `v, ok := mp[k]`
 // - `v, ok := <-ch`
+// - `v, ok := x.(*T)`
 // - `r0, r1, r2, ..., ok := f()`
 func NodeTriggersOkRead(rootNode *RootAssertionNode, nonceGenerator *util.GuardNonceGenerator, node ast.Node) ([]RichCheckEffect, bool) {
 	lhs, rhs := asthelper.ExtractLHSRHS(node)
@@ -335,8 +368,24 @@ func NodeTriggersOkRead(rootNode *RootAssertionNode, nonceGenerator *util.GuardN
 					}})
 			}
 		}
+	case *ast.TypeAssertExpr:
+		// this is the case of `v, ok := x.(*T)`. Early return if the lhs is not a type assertion of the expected format
+		if len(lhs) != 2 || rhs.Type == nil || util.ExprBarsNilness(rootNode.Pass(), lhs[0]) {
+			return nil, false
+		}
+
+		if lhsValueParsed := parseExpr(rootNode, lhs[0]); lhsValueParsed != nil {
+			// here, the lhs `value` operand is trackable
+			effects = append(effects, &TypeAssertOkRead{
+				okRead{
+					root:  rootNode,
+					value: lhsValueParsed,
+					ok:    lhsOkParsed,
+					guard: nonceGenerator.Next(rhs),
+				}})
+		}
 	case *ast.CallExpr:
-		callIdent := util.FuncIdentFromCallExpr(rhs)
+		callIdent := util.FuncIdentFromCallExpr(rootNode.Pass().TypesInfo, rhs)
 		if callIdent == nil {
 			// this discards the case of an anonymous function
 			// perhaps in the future we could change this
@@ -389,7 +438,7 @@ func NodeTriggersFuncErrRet(rootNode *RootAssertionNode, nonceGenerator *util.Gu
 		return nil, false
 	}
 
-	callIdent := util.FuncIdentFromCallExpr(callExpr)
+	callIdent := util.FuncIdentFromCallExpr(rootNode.Pass().TypesInfo, callExpr)
 
 	if callIdent == nil {
 		// this discards the case of an anonymous function
@@ -491,6 +540,10 @@ func guardExpr(rootNode *RootAssertionNode, expr TrackableExpr, guard util.Guard
 		lookedUpNode.SetConsumeTriggers(
 			annotation.ConsumeTriggerSliceAsGuarded(
 				lookedUpNode.ConsumeTriggers(), guard))
+		// The fields of a struct read from a map are guarded by the same check as the read itself.
+		for _, child := range mapZeroValueFldChildren(lookedUpNode) {
+			child.SetConsumeTriggers(annotation.ConsumeTriggerSliceAsGuarded(child.ConsumeTriggers(), guard))
+		}
 	}
 }
 
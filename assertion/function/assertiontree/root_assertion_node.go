@@ -55,10 +55,9 @@ func (r *RootAssertionNode) LocationOf(expr ast.Expr) token.Position {
 	return util.PosToLocation(expr.Pos(), r.Pass())
 }
 
-// HasContract returns if the given function has any contracts.
+// HasContract returns if the given function has any (non-predicate) contracts.
 func (r *RootAssertionNode) HasContract(funcObj *types.Func) bool {
-	_, ok := r.functionContext.funcContracts[funcObj]
-	return ok
+	return len(r.functionContext.funcContracts[funcObj].Forward()) != 0
 }
 
 // MinimalString for a RootAssertionNode returns a minimal string representation of that root node
@@ -158,9 +157,15 @@ func (r *RootAssertionNode) GetDeclaringIdent(obj types.Object) *ast.Ident {
 
 // ObjectOf is the same as [types.Info.ObjectOf], but if an identifier cannot be looked up (e.g.,
 // it is an artificial identifier we created to aid the analysis), we look up the internal backup
-// map instead. ObjectOf returns nil if and only if both attempts fail.
+// map instead. ObjectOf returns nil if and only if both attempts fail. For methods of instantiated
+// generic types (e.g., `Get` in `m.Get()` where `m` is of type `SafeMap[string, *int]`), the
+// generic method is returned instead, since the annotation sites are associated with the generic
+// declaration.
 func (r *RootAssertionNode) ObjectOf(ident *ast.Ident) types.Object {
 	obj := r.Pass().TypesInfo.ObjectOf(ident)
+	if f, ok := obj.(*types.Func); ok {
+		return f.Origin()
+	}
 	if obj != nil {
 		return obj
 	}
@@ -252,7 +257,12 @@ func (r *RootAssertionNode) AddConsumption(consumer *annotation.ConsumeTrigger)
 
 	// we check if the type of the expression `expr` prevents it from ever being nil in the first place
 	if util.ExprBarsNilness(r.Pass(), consumer.Expr) {
-		return // expr cannot be nil, so do nothing
+		// The function values are considered nonnil by type, except for the calls of the
+		// function-typed parameters when the function types are configured nilable (see
+		// FunctionConfig.NilableFuncParams).
+		if _, ok := consumer.Annotation.(*annotation.FuncValueCall); !ok {
+			return // expr cannot be nil, so do nothing
+		}
 	}
 
 	path, producers := r.ParseExprAsProducer(consumer.Expr, false)
@@ -352,7 +362,7 @@ func (r *RootAssertionNode) AddProduction(producer *annotation.ProduceTrigger, d
 func (r *RootAssertionNode) triggerProductions(node AssertionNode, producer *annotation.ProduceTrigger, deeperProducer ...*annotation.ProduceTrigger) {
 
 	// first we check if we were passed a deeper producer. If so, we use it to produce any \
-	// indexAssertionNode children of the currNode
+	// indexAssertionNode or derefAssertionNode children of the currNode
 	if len(deeperProducer) != 0 {
 		if len(deeperProducer) != 1 {
 			// TODO: consider allowing multiple levels of deeper producers to be passed -
@@ -360,8 +370,14 @@ func (r *RootAssertionNode) triggerProductions(node AssertionNode, producer *ann
 			panic("for now - only one level of deeper producer is supported, don't pass more")
 		}
 		for _, child := range node.Children() {
-			if child, ok := child.(*indexAssertionNode); ok {
+			switch child.(type) {
+			case *indexAssertionNode:
 				r.triggerProductions(child, deeperProducer[0])
+			case *derefAssertionNode:
+				// not all deep producers are known (e.g., for the values without deep types)
+				if deeperProducer[0] != nil {
+					r.triggerProductions(child, deeperProducer[0])
+				}
 			}
 		}
 	}
@@ -387,13 +403,20 @@ func (r *RootAssertionNode) triggerProductions(node AssertionNode, producer *ann
 	// to use to symbolize the production, and the latter allows us to point out the particular
 	// annotation that will yield the production of the found consumeTrigger
 
+	producedNode := node
 	var processChildren func(ast.Expr, AssertionNode)
 	processChildren = func(producingSubexpr ast.Expr, node AssertionNode) {
 		for _, child := range node.Children() {
 			producingExpr := child.BuildExpr(producingSubexpr)
 
+			trigger := child.DefaultTrigger()
+			// The fields of the produced node itself are read from the produced value, which is
+			// not a zero value even if the node is a read from a map (e.g., `m[k] = S{...}`).
+			if fld, ok := child.(*fldAssertionNode); ok && node == producedNode && fld.isMapZeroValueFld() {
+				trigger = fld.fldTrigger()
+			}
 			matchConsumeTriggers(child, &annotation.ProduceTrigger{
-				Annotation: child.DefaultTrigger(),
+				Annotation: trigger,
 				Expr:       producingExpr,
 			})
 			processChildren(producingExpr, child)
@@ -501,6 +524,14 @@ func (r *RootAssertionNode) AddGuardMatch(expr ast.Expr, behavior GuardMatchBeha
 				consumers[i].GuardMatched = true
 			}
 		}
+		// The fields of a struct read from a map are guarded by the same check as the read itself.
+		for _, child := range mapZeroValueFldChildren(currNode) {
+			for _, consumer := range child.ConsumeTriggers() {
+				if consumer.Guards.Contains(guard) && !consumer.GuardMatched {
+					consumer.GuardMatched = true
+				}
+			}
+		}
 	case ProduceAsNonnil:
 		var newConsumers []*annotation.ConsumeTrigger
 		for _, consumer := range consumers {
@@ -523,6 +554,75 @@ func (r *RootAssertionNode) AddGuardMatch(expr ast.Expr, behavior GuardMatchBeha
 	currNode.SetConsumeTriggers(consumers)
 }
 
+// produceChanZeroValueFlds produces the nilable fields of a struct value received from a channel
+// in the ok form (e.g., `s.f` after `s, ok := <-ch`), which are the fields of the zero value (i.e.,
+// nil) if the channel is closed, unless the receive is guarded by the `ok`. The consumers are kept
+// in place, such that they are still matched with the annotations of the fields once the value
+// itself is produced.
+func (r *RootAssertionNode) produceChanZeroValueFlds(expr ast.Expr) {
+	if _, ok := r.Pass().TypesInfo.TypeOf(expr).Underlying().(*types.Struct); !ok {
+		return
+	}
+	path, _ := r.ParseExprAsProducer(expr, false)
+	node, _ := r.lookupPath(path)
+	if node == nil {
+		return
+	}
+	guard, hasGuard := r.GetNonce(expr)
+	for _, fld := range nilableFldChildren(node) {
+		for _, consumer := range fld.ConsumeTriggers() {
+			if hasGuard && consumer.Guards.Contains(guard) {
+				consumer.GuardMatched = true
+			}
+			r.AddNewTriggers(annotation.FullTrigger{
+				Producer: &annotation.ProduceTrigger{
+					Annotation: &annotation.ChanZeroValueFld{
+						ProduceTriggerNever: &annotation.ProduceTriggerNever{NeedsGuard: true},
+						FieldName:           fld.decl.Name(),
+					},
+					Expr: fld.BuildExpr(expr),
+				},
+				Consumer: consumer,
+			})
+		}
+	}
+}
+
+// produceFailedTypeAssertion produces the value `v` of the comma-ok type assertion
+// `v, ok := x.(*T)` as nil for its consumers guarded by the failure of the assertion, i.e., the
+// uses of `v` in the branch where `ok` is false (see TypeAssertOkRead), removing them from the
+// tree. The other consumers are left for the assignment to handle.
+func (r *RootAssertionNode) produceFailedTypeAssertion(lhs ast.Expr, assertion *ast.TypeAssertExpr) {
+	guard, ok := r.GetNonce(assertion)
+	if !ok || util.IsEmptyExpr(lhs) || util.ExprBarsNilness(r.Pass(), lhs) {
+		return
+	}
+	path, _ := r.ParseExprAsProducer(lhs, false)
+	node, _ := r.lookupPath(path)
+	if node == nil {
+		return
+	}
+	producer := &annotation.ProduceTrigger{
+		Annotation: &annotation.OkTypeAssertion{
+			ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+			Type:                    types.TypeString(r.Pass().TypesInfo.TypeOf(assertion.Type), types.RelativeTo(r.Pass().Pkg)),
+		},
+		Expr: lhs,
+	}
+	var remaining []*annotation.ConsumeTrigger
+	for _, consumer := range node.ConsumeTriggers() {
+		if !consumer.Guards.Contains(guard) {
+			remaining = append(remaining, consumer)
+			continue
+		}
+		r.AddNewTriggers(annotation.FullTrigger{
+			Producer: producer,
+			Consumer: consumer,
+		})
+	}
+	node.SetConsumeTriggers(remaining)
+}
+
 func (r *RootAssertionNode) consumeIndexExpr(expr ast.Expr) {
 	t := r.Pass().TypesInfo.Types[expr].Type
 	if util.TypeIsDeeplySlice(t) {
@@ -585,6 +685,28 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 
 		r.AddComputation(expr.X)
 	case *ast.CallExpr:
+		if index := r.funcReadFromMap(expr); index != nil {
+			// Calling a nil function panics, and a function read from a map is nil for a missing
+			// key. Since the function values are otherwise considered nonnil (see
+			// util.TypeBarsNilness), we directly match the read against the call here instead of
+			// adding a consumption (which would be dropped for the type of the read).
+			if _, producers := r.ParseExprAsProducer(index, true /* doNotTrack */); len(producers) == 1 {
+				r.AddNewTriggers(annotation.FullTrigger{
+					Producer: producers[0].GetShallow(),
+					Consumer: &annotation.ConsumeTrigger{
+						Annotation: &annotation.FuncValueCall{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}},
+						Expr:       index,
+						Guards:     util.NoGuards(),
+					},
+				})
+			}
+		} else if r.functionContext.functionConfig.NilableFuncParams && r.isFuncParam(expr.Fun) {
+			r.AddConsumption(&annotation.ConsumeTrigger{
+				Annotation: &annotation.FuncValueCall{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}},
+				Expr:       expr.Fun,
+				Guards:     util.NoGuards(),
+			})
+		}
 		r.AddComputation(expr.Fun)
 		exprArgs := r.funcArgsFromCallExpr(expr)
 		var consumeArg func(int, ast.Expr)
@@ -728,6 +850,11 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 			// so we can mark its arguments as consumed
 			consumeArg = consumeArgTrigger(r.ObjectOf(fun).(*types.Func))
 
+			// Add productions for the pointers passed by address (e.g., `&p`) to the out
+			// parameters, before the consumptions of the arguments since the callee writes them
+			// only after all arguments are evaluated
+			r.addProductionsForOutParamArgs(expr, r.ObjectOf(fun).(*types.Func))
+
 			if r.functionContext.functionConfig.EnableStructInitCheck {
 				// Add Productions for struct field params
 				r.addProductionForFuncCallArgAndReceiverFields(expr, fun)
@@ -735,6 +862,23 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 				// Add Consumptions for struct field params
 				r.addConsumptionsForArgAndReceiverFields(expr, fun)
 			}
+		} else if tdecl := r.calleeFuncType(expr); tdecl != nil {
+			// here we have found a call through a value of a named function type, whose
+			// arguments are consumed with the annotations of the params of that type
+			consumeArg = func(i int, arg ast.Expr) {
+				if expr.Ellipsis != token.NoPos && i == len(expr.Args)-1 {
+					// the unpacking of a variadic argument, i.e., `h(_, a...)`, is not checked
+					return
+				}
+				r.AddConsumption(&annotation.ConsumeTrigger{
+					Annotation: &annotation.ArgPass{
+						TriggerIfNonNil: &annotation.TriggerIfNonNil{
+							Ann: annotation.FuncTypeParamKeyFromArgNum(tdecl, i),
+						}},
+					Expr:   arg,
+					Guards: util.NoGuards(),
+				})
+			}
 		} else {
 			// here we have found either a builtin function like make or new,
 			// or a typecast like int(x) - in either case (at least for now), do nothing to try
@@ -771,6 +915,13 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 			}
 		}
 
+		// A field or method promoted through embedded pointers (e.g., `d.m()` for `d.Base.m()`)
+		// implicitly dereferences them, so we handle its explicit form instead.
+		if explicit := r.explicitPromotedSelector(expr); explicit != nil {
+			r.AddComputation(explicit)
+			return
+		}
+
 		// A selector expression (`X.Sel`, where X is an expression and Sel is a selector) can be handled in the following two ways:
 		// - (1) Allow the expression X to be nilable by creating a TriggerIfNonNil consumer for it. This is a special case,
 		//       with so far the only known case being of method invocations for supporting nilable receivers. Our support
@@ -784,6 +935,9 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 		//       	- Check 3: the invoked method is in scope
 		//       	- Check 4: the invoking expression (caller) is of a non-interface type (e.g., struct or named). (We are
 		//       		restricting support only for non-interfaces due to the challenges of secret nil for interfaces.)
+		//       		Under the `concrete-interface-receivers` flag, the invocations on local interface variables with a
+		//       		single known concrete pointer type are also supported, where the method of the concrete type is
+		//       		invoked instead (see collectConcreteTypes).
 		//       - Out-of-scope flow:
 		//          - Check 5: consider the criteria satisfied to support optimistic default
 		//
@@ -792,12 +946,16 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 
 		allowNilable := false
 		if funcObj, ok := r.ObjectOf(expr.Sel).(*types.Func); ok { // Check 1:  selector expression is a method invocation
+			concrete := r.concreteMethodOf(expr)
+			if concrete != nil {
+				funcObj = concrete
+			}
 			recv := funcObj.Type().(*types.Signature).Recv()
 			if util.TypeIsDeeplyPtr(recv.Type()) { // Check 2: receiver is a pointer receiver
 				conf := r.Pass().ResultOf[config.Analyzer].(*config.Config)
 				if conf.IsPkgInScope(funcObj.Pkg()) { // Check 3: invoked method is in scope
 					// Here, `t` can only be of type interface, struct, or named, of which we only support for struct and named types.
-					if !util.TypeIsDeeplyInterface(r.Pass().TypesInfo.TypeOf(expr.X)) { // Check 4: invoking expression (caller) is of a non-interface type (e.g., struct or named)
+					if concrete != nil || !util.TypeIsDeeplyInterface(r.Pass().TypesInfo.TypeOf(expr.X)) { // Check 4: invoking expression (caller) is of a non-interface type (e.g., struct or named)
 						allowNilable = true
 						// We are in the special case of supporting nilable receivers! Can be nilable depending on declaration annotation/inferred nilability.
 						r.AddConsumption(&annotation.ConsumeTrigger{
@@ -877,11 +1035,88 @@ func (r *RootAssertionNode) AddComputation(expr ast.Expr) {
 	}
 }
 
+// explicitPromotedSelector returns the explicit form of the selector expression if it selects a
+// field or method promoted through at least one embedded pointer field, where the embedded fields
+// are given by artificial selector expressions (e.g., `d.Base.m` for `d.m`, where `d` embeds
+// `*Base` and `m` is declared by `Base`). Otherwise, nil is returned.
+func (r *RootAssertionNode) explicitPromotedSelector(expr *ast.SelectorExpr) *ast.SelectorExpr {
+	sel, ok := r.Pass().TypesInfo.Selections[expr]
+	if !ok || len(sel.Index()) < 2 {
+		return nil
+	}
+
+	x, t, viaPtr := expr.X, sel.Recv(), false
+	for _, i := range sel.Index()[:len(sel.Index())-1] {
+		s := util.TypeAsDeeplyStruct(t)
+		if s == nil {
+			return nil
+		}
+		field := s.Field(i)
+		x = r.getSelectorExpr(field, x)
+		t = field.Type()
+		viaPtr = viaPtr || util.TypeIsDeeplyPtr(t)
+	}
+	if !viaPtr {
+		return nil
+	}
+	return &ast.SelectorExpr{X: x, Sel: expr.Sel}
+}
+
+// addProductionsForOutParamArgs adds productions for the pointers whose addresses are passed to the
+// pointer-to-pointer parameters (i.e., out parameters) of the callee. For example,
+// ```
+// var p *T
+// fill(&p) // <-- `fill(out **T)` may write nil to `*out`, hence `p` here
+// p.f
+// ```
+// the value of `p` after the call may be the one written by `fill` through `out`, which is
+// modeled by the deep nilability of the parameter `out`. However, the callee may not write `*out`
+// on every path (or at all), leaving the value of `p` before the call in place, so the consumers
+// of `p` are matched with the production without being removed from the tree, i.e., the written
+// value is joined with the value before the call.
+//
+// TODO: only join the value before the call if the callee does not write `*out` on every path,
+// which would require a fact for the callees that always write their out parameters. Until then,
+// the values left nil before the calls of such callees are false positives.
+func (r *RootAssertionNode) addProductionsForOutParamArgs(call *ast.CallExpr, fdecl *types.Func) {
+	sig := fdecl.Type().(*types.Signature)
+	for i, arg := range call.Args {
+		if i >= sig.Params().Len() || (sig.Variadic() && i == sig.Params().Len()-1) {
+			break
+		}
+		unary, ok := astutil.Unparen(arg).(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND || !util.TypeIsDeeplyPtr(r.Pass().TypesInfo.TypeOf(unary.X)) {
+			continue
+		}
+		if !util.TypeIsDeeplyPtr(sig.Params().At(i).Type()) {
+			continue
+		}
+		path, _ := r.ParseExprAsProducer(unary.X, false)
+		node, _ := r.lookupPath(path)
+		if node == nil {
+			continue
+		}
+		producer := &annotation.ProduceTrigger{
+			Annotation: &annotation.OutParamWrite{
+				TriggerIfDeepNilable: &annotation.TriggerIfDeepNilable{
+					Ann: annotation.ParamKeyFromArgNum(fdecl, i),
+				}},
+			Expr: unary.X,
+		}
+		for _, consumer := range node.ConsumeTriggers() {
+			r.AddNewTriggers(annotation.FullTrigger{
+				Producer: producer,
+				Consumer: consumer,
+			})
+		}
+	}
+}
+
 // getFuncIdent returns the function identified from a call expression. If the function
 // is an anonymous function, it will return the fake function declaration created in the
 // function analyzer
 func getFuncIdent(expr *ast.CallExpr, fc *FunctionContext) *ast.Ident {
-	ident := util.FuncIdentFromCallExpr(expr)
+	ident := util.FuncIdentFromCallExpr(fc.pass.TypesInfo, expr)
 
 	var funcLit *ast.FuncLit
 	// if ident is nil, check if the expr represents a FuncLit node
@@ -1090,6 +1325,10 @@ func (r *RootAssertionNode) shallowEqNodes(left, right AssertionNode) bool {
 		if !r.eqStable(left.index, right.index) {
 			return false
 		}
+	case *derefAssertionNode:
+		if _, ok := right.(*derefAssertionNode); !ok {
+			return false
+		}
 	default:
 		panic("unrecognized node type")
 	}
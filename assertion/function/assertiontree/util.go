@@ -73,6 +73,8 @@ func deepNilabilityTriggerOf(node AssertionNode) annotation.ProducingAnnotationT
 		return annotation.DeepNilabilityOfFld(node.decl)
 	case *indexAssertionNode:
 		return annotation.DeepNilabilityAsNamedType(node.valType)
+	case *derefAssertionNode:
+		return annotation.DeepNilabilityAsNamedType(node.valType)
 	case *RootAssertionNode:
 		panic("deepNilabilityTriggerOf should NOT be called not the root node - as this would" +
 			" imply an indexNode is a child of the root node")
@@ -258,6 +260,16 @@ func AddNilCheck(pass *analysis.Pass, expr ast.Expr) (trueCheck, falseCheck Root
 		return produceExprByTrigger(expr, &annotation.NegativeNilCheck{ProduceTriggerNever: &annotation.ProduceTriggerNever{}})
 	}
 
+	producePositiveNilCheck := func(expr ast.Expr) RootFunc {
+		produce := produceExprByTrigger(expr, &annotation.PositiveNilCheck{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}})
+		return func(self *RootAssertionNode) {
+			// The expression is only treated as definitely nil if enabled (see FunctionConfig.PositiveNilChecks).
+			if self.functionContext.functionConfig.PositiveNilChecks {
+				produce(self)
+			}
+		}
+	}
+
 	// An exprCheck is a pattern that we match on that, if successful, will give us a pair
 	// of functions updating a root node in the true and false branches of a conditional
 	// to indicate the information that can be gained from that conditional
@@ -277,7 +289,7 @@ func AddNilCheck(pass *analysis.Pass, expr ast.Expr) (trueCheck, falseCheck Root
 			op: token.EQL,
 			matcher: func(x, y ast.Expr) (RootFunc, RootFunc, bool) {
 				if util.IsLiteral(x, "nil") && !util.IsLiteral(y, "nil") {
-					return noop, produceNegativeNilCheck(y), false
+					return producePositiveNilCheck(y), produceNegativeNilCheck(y), false
 				}
 				return noop, noop, true
 			},
@@ -402,6 +414,8 @@ func CopyNode(node AssertionNode) AssertionNode {
 			index:    node.index,
 			valType:  node.valType,
 			recvType: node.recvType}
+	case *derefAssertionNode:
+		fresh = &derefAssertionNode{valType: node.valType}
 	default:
 		panic("unrecognized node type")
 	}
@@ -76,6 +76,16 @@ func TestContractCollection(t *testing.T) {
 			Contract{Ins: []ContractVal{Any, NonNil}, Outs: []ContractVal{NonNil, True}},
 			Contract{Ins: []ContractVal{NonNil, Any}, Outs: []ContractVal{NonNil, True}},
 		},
+		getFuncObj(pass, "predicate"): {
+			Contract{Ins: []ContractVal{NonNil}, Outs: []ContractVal{True}, Reverse: true},
+		},
+		getFuncObj(pass, "multiArgPredicate"): {
+			Contract{Ins: []ContractVal{Any, NonNil, NonNil}, Outs: []ContractVal{False}, Reverse: true},
+		},
+		getFuncObj(pass, "noReturn"): {
+			Contract{NoReturn: true},
+		},
+		// function noReturnWithExtraText should not exist in the map as its directive is malformed.
 		// function contractCommentInOtherLine should not exist in the map as it has no contract.
 	}
 	if diff := cmp.Diff(expected, actual); diff != "" {
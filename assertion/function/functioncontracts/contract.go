@@ -14,6 +14,8 @@
 
 package functioncontracts
 
+import "slices"
+
 // ContractVal represents the possible value appearing in a function contract.
 type ContractVal string
 
@@ -53,4 +55,40 @@ type Contract struct {
 	Ins []ContractVal
 	// Outs is the list of output contract values, where the index is the index of the return.
 	Outs []ContractVal
+	// Reverse indicates that the implication goes from Outs to Ins instead, i.e., the contract is
+	// a predicate contract `nilaway:contract(argN=VALUE <- result=VALUE)` stating that the
+	// parameters satisfy Ins whenever the (boolean) results match Outs.
+	Reverse bool
+	// NoReturn indicates that the contract is a `nilaway:noreturn` directive stating that the
+	// function never returns (e.g., it always exits the program), where Ins and Outs are empty.
+	NoReturn bool
+}
+
+// Forward returns the contracts that are not reversed, i.e., the ones stating that the results
+// satisfy Outs whenever the parameters satisfy Ins.
+func (cs Contracts) Forward() Contracts {
+	var forward Contracts
+	for _, c := range cs {
+		if !c.Reverse && !c.NoReturn {
+			forward = append(forward, c)
+		}
+	}
+	return forward
+}
+
+// Predicates returns the reversed contracts, i.e., the ones stating that the parameters satisfy
+// Ins whenever the results match Outs.
+func (cs Contracts) Predicates() Contracts {
+	var predicates Contracts
+	for _, c := range cs {
+		if c.Reverse {
+			predicates = append(predicates, c)
+		}
+	}
+	return predicates
+}
+
+// NoReturn returns true if the function is annotated to never return.
+func (cs Contracts) NoReturn() bool {
+	return slices.ContainsFunc(cs, func(c Contract) bool { return c.NoReturn })
 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"go/ast"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -35,6 +36,24 @@ var _contractRE = regexp.MustCompile(
 	fmt.Sprintf("^\\s*//\\s*(?:\\s*%s\\s*\\(\\s*((?:%s)(?:\\s*,\\s*(?:%s))*)\\s*->\\s*((?:%s)(?:\\s*,\\s*(?:%s))*)\\s*\\)\\s*)+$",
 		_contractKeyword, _contractValKeyword, _contractValKeyword, _contractValKeyword, _contractValKeyword))
 
+// _predicateContractKeyword is the keyword for predicate contracts, which are written in the
+// reverse direction and hence must be distinguished from the usual contracts.
+const _predicateContractKeyword = "nilaway:contract"
+
+// _predicateContractRE matches a predicate contract in its own line, which looks like
+// `nilaway:contract(argN=nonnil(,argM=nonnil)* <- result=(true|false))`. The RE captures the list
+// of parameter conditions and the boolean result value.
+var _predicateContractRE = regexp.MustCompile(
+	fmt.Sprintf("^\\s*//\\s*%s\\s*\\(\\s*(arg[0-9]+\\s*=\\s*%s(?:\\s*,\\s*arg[0-9]+\\s*=\\s*%s)*)\\s*<-\\s*result\\s*=\\s*(%s|%s)\\s*\\)\\s*$",
+		_predicateContractKeyword, NonNil, NonNil, True, False))
+
+// _noReturnKeyword is the keyword for annotating a function that never returns, such that the
+// code after the calls to it is unreachable.
+const _noReturnKeyword = "nilaway:noreturn"
+
+// _noReturnRE matches the noreturn directive in its own line, i.e., `//nilaway:noreturn`.
+var _noReturnRE = regexp.MustCompile(fmt.Sprintf("^\\s*//\\s*%s\\s*$", _noReturnKeyword))
+
 // parseContracts parses a slice of function contracts from a singe comment group. If no contract
 // is found from the comment group, an empty slice is returned.
 func parseContracts(doc *ast.CommentGroup) Contracts {
@@ -54,10 +73,44 @@ func parseContracts(doc *ast.CommentGroup) Contracts {
 				Outs: outs,
 			})
 		}
+		if matching := _predicateContractRE.FindStringSubmatch(lineComment.Text); matching != nil {
+			// matching is a slice of three elements; the first is the whole matched string and the
+			// next two are the captured parameter conditions and the result value.
+			contracts = append(contracts, Contract{
+				Ins:     parseListOfArgConditions(matching[1]),
+				Outs:    []ContractVal{newContractVal(matching[2])},
+				Reverse: true,
+			})
+		}
+		if _noReturnRE.MatchString(lineComment.Text) {
+			contracts = append(contracts, Contract{NoReturn: true})
+		}
 	}
 	return contracts
 }
 
+// parseListOfArgConditions splits a string of comma separated `argN=VALUE` conditions and returns
+// a slice of ContractVal indexed by the parameter index, where the unmentioned parameters are Any.
+func parseListOfArgConditions(wholeStr string) []ContractVal {
+	vals := make(map[int]ContractVal)
+	maxIndex := -1
+	for _, cond := range strings.Split(wholeStr, _sep) {
+		arg, val, _ := strings.Cut(strings.TrimSpace(cond), "=")
+		// The RE guarantees that the index is a valid non-negative integer.
+		i, _ := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(arg), "arg"))
+		vals[i] = newContractVal(strings.TrimSpace(val))
+		maxIndex = max(maxIndex, i)
+	}
+	contractVals := make([]ContractVal, maxIndex+1)
+	for i := range contractVals {
+		contractVals[i] = Any
+		if v, ok := vals[i]; ok {
+			contractVals[i] = v
+		}
+	}
+	return contractVals
+}
+
 // parseListOfContractValues splits a string of comma separated contract value keywords and returns
 // a slice of ContractVal.
 func parseListOfContractValues(wholeStr string) []ContractVal {
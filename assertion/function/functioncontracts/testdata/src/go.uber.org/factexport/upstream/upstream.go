@@ -17,7 +17,7 @@ package upstream
 // This tests the export of contracts from the upstream package.
 
 //contract(nonnil -> nonnil)
-func ExportedManual(p *int) *int { //want ExportedManual:"&\\[{\\[nonnil\\] \\[nonnil\\]}\\]"
+func ExportedManual(p *int) *int { //want ExportedManual:"&\\[{\\[nonnil\\] \\[nonnil\\] false false}\\]"
 	if p != nil {
 		a := 1
 		return &a
@@ -25,7 +25,7 @@ func ExportedManual(p *int) *int { //want ExportedManual:"&\\[{\\[nonnil\\] \\[n
 	return nil
 }
 
-func ExportedInferred(p *int) *int { //want ExportedInferred:"&\\[{\\[nonnil\\] \\[nonnil\\]}\\]"
+func ExportedInferred(p *int) *int { //want ExportedInferred:"&\\[{\\[nonnil\\] \\[nonnil\\] false false}\\]"
 	if p != nil {
 		a := 1
 		return &a
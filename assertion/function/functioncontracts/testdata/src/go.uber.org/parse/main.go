@@ -60,6 +60,30 @@ func multipleContracts(x *int, y *int) (*int, bool) {
 	return new(int), true
 }
 
+// nilaway:contract(arg0=nonnil <- result=true)
+func predicate(x *int) bool {
+	return x != nil
+}
+
+// nilaway:contract(arg1=nonnil, arg2=nonnil <- result=false)
+func multiArgPredicate(s string, x *int, y *int) bool {
+	return s == "" || x == nil || y == nil
+}
+
+//nilaway:noreturn
+func noReturn(msg string) {
+	panic(msg)
+}
+
+// Directives with extra text, e.g., `//nilaway:noreturn unless x`, are not parsed.
+//
+//nilaway:noreturn unless x
+func noReturnWithExtraText(x bool) {
+	if !x {
+		panic("x")
+	}
+}
+
 // This contract `// contract(nonnil -> nonnil)` does not hold for the function because the
 // function has no param or return. Only a contract in its own line should be parsed, not even `//
 // contract(nonnil -> nonnil)`.
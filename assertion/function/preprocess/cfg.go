@@ -15,12 +15,18 @@
 package preprocess
 
 import (
+	"cmp"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
+	"go/types"
+	"slices"
 
+	"go.uber.org/nilaway/assertion/function/functioncontracts"
 	"go.uber.org/nilaway/assertion/function/trustedfunc"
 	"go.uber.org/nilaway/util"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/cfg"
 )
 
@@ -42,6 +48,37 @@ import (
 // Canonicalize explicit boolean comparisons:
 // - replace `if x == true {T} {F}` with `if x {T} {F}`
 // - replace `if x == false {T} {F}` with `if !x {T} {F}`
+//
+// Expand calls to predicate functions:
+// - replace `if f(x) {T} {F}` with `if f(x) {if x == nil {F} else {T}} {F}` if f has a predicate
+// contract `nilaway:contract(arg0=nonnil <- result=true)`
+// - replace `if f(x) {T} {F}` with `if f(x) {T} else {if x == nil {T} else {F}}` if f has a
+// predicate contract `nilaway:contract(arg0=nonnil <- result=false)`
+// - replace `if errors.As(err, &x) {T} {F}` with `if errors.As(err, &x) {if x == nil {F} else {T}} {F}`
+//
+// Expand tag checks of tagged union structs:
+// - replace `if v.tag == C {T} {F}` with `if v.tag == C {if v.f == nil {F} else {T}} {F}` if the
+// field f is annotated with `//nilaway:nonnil-when(tag == C)` (and similarly for `!=`, where the
+// false branch is guarded instead)
+//
+// Expand nil checks of oneof fields:
+// - replace `if v.b == nil {T} {F}` with `if v.b == nil {if v.c == nil {F} else {T}} {F}` if the
+// struct is annotated with `//nilaway:oneof(a, b, c)` and the check is only reachable from the
+// true branch of `v.a == nil`
+//
+// Truncate blocks at calls to noreturn functions:
+// - remove the nodes after a call to a function annotated with `//nilaway:noreturn` and the
+// successors of its block, such that the code after the call is unreachable (the calls to
+// intrinsically noreturn functions, e.g., `panic`, `os.Exit` and `log.Fatal`, are already
+// handled when the CFG is built)
+//
+// Prune exhaustive switch statements over booleans:
+// - remove the edge for "no case matched" of `switch b { case true: ... case false: ... }`, such
+// that the variables assigned in all cases are not considered unassigned after the switch
+//
+// Restructure select statements:
+// - move the assignments in the comm clauses (e.g., `case v = <-ch:`) from the block before the
+// select statement (where they are unconditionally evaluated) to the beginning of their case bodies
 func (p *Preprocessor) CFG(graph *cfg.CFG, funcDecl *ast.FuncDecl) *cfg.CFG {
 	// The ASTs and CFGs are shared across all analyzers in the nogo framework, so we should never
 	// modify them directly. Here, we make a copy of the graph (and all blocks in it) and modify
@@ -56,6 +93,11 @@ func (p *Preprocessor) CFG(graph *cfg.CFG, funcDecl *ast.FuncDecl) *cfg.CFG {
 	graph.Blocks = append(graph.Blocks, failureBlock)
 
 	// Perform the (series of) CFG transformations.
+	for _, block := range graph.Blocks {
+		if block.Live {
+			p.truncateOnNoReturnCalls(block)
+		}
+	}
 	for _, block := range graph.Blocks {
 		if block.Live {
 			p.splitBlockOnTrustedFuncs(graph, block, failureBlock)
@@ -66,6 +108,7 @@ func (p *Preprocessor) CFG(graph *cfg.CFG, funcDecl *ast.FuncDecl) *cfg.CFG {
 			p.restructureConditional(graph, block)
 		}
 	}
+	p.expandOneOfChecks(graph)
 
 	// Next, we need to re-insert information that is lost during CFG build for *ast.RangeStmt
 	// and *ast.SwitchStmt by iterating through all blocks. This requires knowing the links between
@@ -73,7 +116,9 @@ func (p *Preprocessor) CFG(graph *cfg.CFG, funcDecl *ast.FuncDecl) *cfg.CFG {
 	// So, here establish the link and then do the work.
 	rangeChildren, switchChildren := collectChildren(funcDecl)
 	markRangeStatements(graph, rangeChildren)
+	p.pruneExhaustiveBoolSwitches(graph, switchChildren)
 	markSwitchStatements(graph, switchChildren)
+	markSelectStatements(graph)
 
 	return graph
 }
@@ -101,6 +146,8 @@ func copyGraph(graph *cfg.CFG) *cfg.CFG {
 			Nodes: newNodes,
 			Live:  block.Live,
 			Index: block.Index,
+			Kind:  block.Kind,
+			Stmt:  block.Stmt,
 		}
 		newGraph.Blocks = append(newGraph.Blocks, newBlock)
 
@@ -119,6 +166,32 @@ func copyGraph(graph *cfg.CFG) *cfg.CFG {
 	return newGraph
 }
 
+// truncateOnNoReturnCalls removes the nodes after the first call to a noreturn function (i.e.,
+// annotated with `//nilaway:noreturn`) in the block, along with the successors of the block.
+func (p *Preprocessor) truncateOnNoReturnCalls(block *cfg.Block) {
+	for i, node := range block.Nodes {
+		expr, ok := node.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		call, ok := expr.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		ident := util.FuncIdentFromCallExpr(p.pass.TypesInfo, call)
+		if ident == nil {
+			continue
+		}
+		funcObj, ok := p.pass.TypesInfo.ObjectOf(ident).(*types.Func)
+		if !ok || !p.funcContracts[funcObj.Origin()].NoReturn() {
+			continue
+		}
+		block.Nodes = block.Nodes[:i+1]
+		block.Succs = nil
+		return
+	}
+}
+
 func (p *Preprocessor) splitBlockOnTrustedFuncs(graph *cfg.CFG, thisBlock, failureBlock *cfg.Block) {
 	var expr *ast.ExprStmt
 	var call *ast.CallExpr
@@ -190,6 +263,23 @@ func (p *Preprocessor) restructureConditional(graph *cfg.CFG, thisBlock *cfg.Blo
 	}
 
 	switch cond := cond.(type) {
+	case *ast.CallExpr:
+		// A successful `errors.As(err, &target)` sets the target to the (nonnil) error found in
+		// the chain, so we chain a nil check of the target after the call on the true branch. The
+		// fields of the target are still read from their own sites.
+		if target := p.errorsAsTarget(cond); target != nil {
+			p.chainNilChecks(graph, thisBlock, []ast.Expr{target}, true /* onTrue */)
+			return
+		}
+
+		// For calls to predicate functions, we chain nil checks of the guarded arguments after the
+		// call on the branch where the contract applies, such that the arguments are known to be
+		// nonnil there.
+		args, result := p.predicateGuardedArgs(cond)
+		if len(args) == 0 {
+			return
+		}
+		p.chainNilChecks(graph, thisBlock, args, result)
 	case *ast.ParenExpr:
 		// if a parenexpr, strip and restart - this is done with recursion to account for ((((x)))) case
 		replaceCond(cond.X)
@@ -270,6 +360,10 @@ func (p *Preprocessor) restructureConditional(graph *cfg.CFG, thisBlock *cfg.Blo
 				// to a EQL one.
 				replaceCond(newCond)
 				swapTrueFalseBranches()
+				// Checking the nilness of a variable also checks the nilness of its aliases.
+				if aliases := p.aliasesOf(thisBlock, x); len(aliases) != 0 {
+					p.chainNilChecks(graph, thisBlock, aliases, false /* onTrue */)
+				}
 				break
 			}
 
@@ -285,9 +379,21 @@ func (p *Preprocessor) restructureConditional(graph *cfg.CFG, thisBlock *cfg.Blo
 				}
 				replaceCond(newCond)                       // replaces `ok != true` with `!ok`
 				p.restructureConditional(graph, thisBlock) // recur to swap true and false branches for the unary expr `!ok`
+			} else if fields := p.taggedUnionGuardedFields(x, y); len(fields) != 0 {
+				// A tag check of a tagged union struct guards the fields tagged by the value on
+				// the false branch.
+				p.chainNilChecks(graph, thisBlock, fields, false /* onTrue */)
 			}
 
 		case token.EQL:
+			if util.IsLiteral(y, "nil") {
+				// Checking the nilness of a variable also checks the nilness of its aliases.
+				if aliases := p.aliasesOf(thisBlock, x); len(aliases) != 0 {
+					p.chainNilChecks(graph, thisBlock, aliases, false /* onTrue */)
+				}
+				break
+			}
+
 			// For explicit boolean EQL checks, we replace the AST nodes for `ok == true` and `ok == false`
 			// (also, `true == ok` and `false == ok`) with `ok` and `!ok` form for the true and false cases, respectively.
 			if util.IsLiteral(y, "true") {
@@ -300,11 +406,193 @@ func (p *Preprocessor) restructureConditional(graph *cfg.CFG, thisBlock *cfg.Blo
 				}
 				replaceCond(newCond)                       // replaces `ok == false` with `!ok`
 				p.restructureConditional(graph, thisBlock) // recur to swap true and false branches for the unary expr `!ok`
+			} else if fields := p.taggedUnionGuardedFields(x, y); len(fields) != 0 {
+				// A tag check of a tagged union struct guards the fields tagged by the value on
+				// the true branch.
+				p.chainNilChecks(graph, thisBlock, fields, true /* onTrue */)
 			}
 		}
 	}
 }
 
+// chainNilChecks chains nil checks `expr == nil` of the given expressions after the condition of
+// the branching block, such that the expressions are known to be nonnil on the true branch (if
+// onTrue is set) or the false branch (otherwise) of the condition. Specifically, it rewrites
+// `if cond {T} {F}` to `if cond {if expr == nil {F} else {T}} {F}` if onTrue is set, and to
+// `if cond {T} else {if expr == nil {T} else {F}}` otherwise.
+func (p *Preprocessor) chainNilChecks(graph *cfg.CFG, thisBlock *cfg.Block, exprs []ast.Expr, onTrue bool) {
+	trueBranch, falseBranch := thisBlock.Succs[0], thisBlock.Succs[1]
+	for _, expr := range exprs {
+		newBlock := &cfg.Block{
+			Nodes: []ast.Node{&ast.BinaryExpr{
+				X:     expr,
+				OpPos: expr.Pos(),
+				Op:    token.EQL,
+				Y:     &ast.Ident{NamePos: expr.Pos(), Name: "nil"},
+			}},
+			Index: int32(len(graph.Blocks)),
+			Live:  true,
+		}
+		graph.Blocks = append(graph.Blocks, newBlock)
+		// The nil check is in the canonical form `x == nil`, so its true branch (i.e., the
+		// expression is nil) leads to the branch where the expression is not guaranteed to be nonnil.
+		if onTrue {
+			newBlock.Succs = []*cfg.Block{falseBranch, thisBlock.Succs[0]}
+			thisBlock.Succs[0] = newBlock
+		} else {
+			newBlock.Succs = []*cfg.Block{trueBranch, thisBlock.Succs[1]}
+			thisBlock.Succs[1] = newBlock
+		}
+	}
+}
+
+// aliasesOf returns the local variables that are known to hold the same value as the given
+// expression (a local variable) at the end of the block, i.e., the variables that are linked to it
+// via simple assignments (e.g., `b := a` or `b = a`) in the block without being reassigned later.
+// Note that the conditional, i.e., the last node of the block, is not considered.
+func (p *Preprocessor) aliasesOf(block *cfg.Block, expr ast.Expr) []ast.Expr {
+	target := p.localVar(expr)
+	if target == nil || util.TypeBarsNilness(target.Type()) {
+		return nil
+	}
+
+	// aliases maps each variable to the (shared) set of variables holding the same value.
+	aliases := make(map[*types.Var]map[*types.Var]bool)
+	unlink := func(v *types.Var) {
+		if set, ok := aliases[v]; ok {
+			delete(set, v)
+			delete(aliases, v)
+		}
+	}
+	idents := make(map[*types.Var]*ast.Ident)
+	for _, node := range block.Nodes[:len(block.Nodes)-1] {
+		if assign, ok := node.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 &&
+			(assign.Tok == token.DEFINE || assign.Tok == token.ASSIGN) {
+			lhs, rhs := p.localVar(assign.Lhs[0]), p.localVar(assign.Rhs[0])
+			if lhs != nil && rhs != nil && lhs != rhs {
+				unlink(lhs)
+				set, ok := aliases[rhs]
+				if !ok {
+					set = map[*types.Var]bool{rhs: true}
+					aliases[rhs] = set
+				}
+				set[lhs] = true
+				aliases[lhs] = set
+				idents[lhs], idents[rhs] = assign.Lhs[0].(*ast.Ident), assign.Rhs[0].(*ast.Ident)
+				continue
+			}
+		}
+
+		// Any other writes to the variables (or taking their addresses, which allows indirect
+		// writes) break the aliasing.
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if v := p.localVar(lhs); v != nil {
+						unlink(v)
+					}
+				}
+			case *ast.ValueSpec:
+				for _, name := range n.Names {
+					if v := p.localVar(name); v != nil {
+						unlink(v)
+					}
+				}
+			case *ast.IncDecStmt:
+				if v := p.localVar(n.X); v != nil {
+					unlink(v)
+				}
+			case *ast.UnaryExpr:
+				if v := p.localVar(n.X); n.Op == token.AND && v != nil {
+					unlink(v)
+				}
+			}
+			return true
+		})
+	}
+
+	var exprs []ast.Expr
+	for v := range aliases[target] {
+		if v != target {
+			exprs = append(exprs, idents[v])
+		}
+	}
+	// The map iteration order is random, so we sort the aliases for determinism.
+	slices.SortFunc(exprs, func(a, b ast.Expr) int {
+		return cmp.Compare(a.(*ast.Ident).Name, b.(*ast.Ident).Name)
+	})
+	return exprs
+}
+
+// localVar returns the local variable the expression refers to if it is an identifier of a local
+// variable, or nil otherwise.
+func (p *Preprocessor) localVar(expr ast.Expr) *types.Var {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := p.pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+		return nil
+	}
+	return v
+}
+
+// predicateGuardedArgs returns the arguments of the call that are guaranteed to be nonnil by a
+// predicate contract of the called function, along with the (boolean) result value under which
+// the guarantee holds. If the called function has no applicable predicate contracts, nil is
+// returned.
+func (p *Preprocessor) predicateGuardedArgs(call *ast.CallExpr) ([]ast.Expr, bool) {
+	ident := util.FuncIdentFromCallExpr(p.pass.TypesInfo, call)
+	if ident == nil {
+		return nil, false
+	}
+	funcObj, ok := p.pass.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	sig := funcObj.Type().(*types.Signature)
+	if sig.Results().Len() != 1 || len(call.Args) != sig.Params().Len() {
+		return nil, false
+	}
+
+	// We only support a single predicate contract for now.
+	predicates := p.funcContracts[funcObj.Origin()].Predicates()
+	if len(predicates) != 1 {
+		return nil, false
+	}
+	ctr := predicates[0]
+	var args []ast.Expr
+	for i, val := range ctr.Ins {
+		if val != functioncontracts.NonNil || i >= len(call.Args) ||
+			(sig.Variadic() && i == sig.Params().Len()-1) ||
+			util.TypeBarsNilness(p.pass.TypesInfo.TypeOf(call.Args[i])) {
+			continue
+		}
+		args = append(args, call.Args[i])
+	}
+	return args, ctr.Outs[0] == functioncontracts.True
+}
+
+// errorsAsTarget returns the target (e.g., `target` in `errors.As(err, &target)`) if the call is
+// to `errors.As` with the address of a nilable target, and nil otherwise.
+func (p *Preprocessor) errorsAsTarget(call *ast.CallExpr) ast.Expr {
+	ident := util.FuncIdentFromCallExpr(p.pass.TypesInfo, call)
+	if ident == nil || len(call.Args) != 2 {
+		return nil
+	}
+	funcObj, ok := p.pass.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok || funcObj.Pkg() == nil || funcObj.Pkg().Path() != "errors" || funcObj.Name() != "As" {
+		return nil
+	}
+	addr, ok := astutil.Unparen(call.Args[1]).(*ast.UnaryExpr)
+	if !ok || addr.Op != token.AND || util.TypeBarsNilness(p.pass.TypesInfo.TypeOf(addr.X)) {
+		return nil
+	}
+	return addr.X
+}
+
 // collectChildren establishes the links between the range / switch statement nodes and their child
 // nodes. This is specifically designed for our preprocess function: when we rewrite the CFG to
 // re-insert the lost information, we need to know if a block in CFG belongs to a certain range
@@ -488,3 +776,117 @@ func markSwitchStatements(graph *cfg.CFG, switchChildren map[ast.Node]*ast.Switc
 		}
 	}
 }
+
+// pruneExhaustiveBoolSwitches removes the edges for "no case matched" (i.e., to the `default`
+// case or after the switch statement) of the switch statements over booleans whose cases cover
+// both `true` and `false`, since such edges are infeasible. Otherwise, the nilability of a
+// variable assigned in all cases would be joined with its (nil) value before the switch statement.
+//
+// As described in markSwitchStatements, the case expressions are in a chain of blocks, where the
+// first one follows the switch tag, and each block branches to its case body or to the next block.
+// The chain ends with an empty block jumping to the `default` case (or after the switch).
+func (p *Preprocessor) pruneExhaustiveBoolSwitches(graph *cfg.CFG, switchChildren map[ast.Node]*ast.SwitchStmt) {
+	for _, block := range graph.Blocks {
+		n := len(block.Nodes)
+		if n < 2 || len(block.Succs) != 2 {
+			continue
+		}
+		tag, ok := block.Nodes[n-2].(ast.Expr)
+		if !ok {
+			continue
+		}
+		stmt := switchChildren[tag]
+		if stmt == nil || stmt.Tag != tag {
+			continue
+		}
+		if b, ok := p.pass.TypesInfo.TypeOf(tag).Underlying().(*types.Basic); !ok || b.Info()&types.IsBoolean == 0 {
+			continue
+		}
+
+		caseExprs := make(map[ast.Node]bool)
+		for _, clause := range stmt.Body.List {
+			for _, expr := range clause.(*ast.CaseClause).List {
+				caseExprs[expr] = true
+			}
+		}
+
+		covered := make(map[bool]bool)
+		last := block
+		for {
+			caseExpr := last.Nodes[len(last.Nodes)-1].(ast.Expr)
+			if v := p.pass.TypesInfo.Types[caseExpr].Value; v != nil && v.Kind() == constant.Bool {
+				covered[constant.BoolVal(v)] = true
+			}
+			next := last.Succs[1]
+			if len(next.Nodes) != 1 || len(next.Succs) != 2 || !caseExprs[next.Nodes[0]] {
+				break
+			}
+			last = next
+		}
+		if covered[true] && covered[false] {
+			last.Succs = last.Succs[:1]
+		}
+	}
+}
+
+// markSelectStatements restructures a cfg to reflect the assignments in select statements.
+//
+// In particular, `select { case v = <-ch1: e1 case <-ch2: e2 }` will be parsed by the CFG into:
+//
+// Block0: Nodes: v = <-ch1, <-ch2, Succs: Block1, Block2
+// Block1: Nodes: v, e1
+// Block2: Succs: Block3, ...
+// Block3: e2
+//
+// That is, the comm statements of all cases are evaluated unconditionally before the select
+// statement branches, making `v` look assigned on all paths. We transform it into:
+//
+// Block0: Nodes: <-ch2, Succs: Block1, Block2
+// Block1: Nodes: v = <-ch1, e1
+// Block2: Succs: Block3, ...
+// Block3: e2
+//
+// Such that the assignment only happens in the case that actually received the value, and the
+// cases without assignments (including the `default` case) keep the prior values of the variables.
+func markSelectStatements(graph *cfg.CFG) {
+	// Collect the assignments in the comm clauses and the case bodies they belong to.
+	caseBodies := make(map[*ast.AssignStmt]*cfg.Block)
+	for _, block := range graph.Blocks {
+		if block.Kind != cfg.KindSelectCaseBody {
+			continue
+		}
+		clause, ok := block.Stmt.(*ast.CommClause)
+		if !ok {
+			continue
+		}
+		if assign, ok := clause.Comm.(*ast.AssignStmt); ok {
+			caseBodies[assign] = block
+		}
+	}
+	if len(caseBodies) == 0 {
+		return
+	}
+
+	// Remove the assignments from the blocks where they are unconditionally evaluated. Note that
+	// block.Nodes is already a copy (see copyGraph), so it is safe to filter it in place.
+	for _, block := range graph.Blocks {
+		nodes := block.Nodes[:0]
+		for _, node := range block.Nodes {
+			if assign, ok := node.(*ast.AssignStmt); ok && caseBodies[assign] != nil {
+				continue
+			}
+			nodes = append(nodes, node)
+		}
+		block.Nodes = nodes
+	}
+
+	// Re-insert the assignments at the beginning of their case bodies, replacing the lhs
+	// expression that the CFG builder places there.
+	for assign, block := range caseBodies {
+		if len(block.Nodes) > 0 && block.Nodes[0] == assign.Lhs[0] {
+			block.Nodes[0] = assign
+			continue
+		}
+		block.Nodes = append([]ast.Node{assign}, block.Nodes...)
+	}
+}
@@ -0,0 +1,256 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"go.uber.org/nilaway/util"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/cfg"
+)
+
+// _oneOfKeyword is the keyword for annotating a struct whose (nilable) fields in the given list
+// are never all nil at the same time, e.g., a sum type where exactly one of the fields is set.
+const _oneOfKeyword = "nilaway:oneof"
+
+// _oneOfRE matches the oneof directive in its own line, which looks like
+// `nilaway:oneof(FIELD1, FIELD2, ...)`. The RE captures the comma-separated list of field names.
+var _oneOfRE = regexp.MustCompile(
+	fmt.Sprintf("^\\s*//\\s*%s\\s*\\(\\s*([\\p{L}_][\\p{L}\\p{N}_]*(?:\\s*,\\s*[\\p{L}_][\\p{L}\\p{N}_]*)+)\\s*\\)\\s*$", _oneOfKeyword))
+
+// OneOfs maps the fields of structs to the oneof groups they belong to, where each group is given
+// by the identifiers of its fields in their declarations (which are used to build the artificial
+// selector expressions of the fields in nil checks).
+type OneOfs map[*types.Var][][]*ast.Ident
+
+// CollectOneOfs collects the oneof directives on the struct types declared in the package. For
+// example, the following directive states that at least one of the fields `a`, `b` and `c` is
+// nonnil, such that `v.c` is known to be nonnil after `v.a` and `v.b` are checked to be nil:
+//
+//	//nilaway:oneof(a, b, c)
+//	type sum struct {
+//		a *A
+//		b *B
+//		c *C
+//	}
+//
+// Note that the directives are only read from the current package, i.e., the checks on structs
+// declared in upstream packages are not credited.
+func CollectOneOfs(pass *analysis.Pass) OneOfs {
+	oneOfs := make(OneOfs)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				// The doc comment is attached to the declaration (instead of the spec) if the
+				// declaration is not parenthesized.
+				groups := []*ast.CommentGroup{typeSpec.Doc}
+				if len(genDecl.Specs) == 1 {
+					groups = append(groups, genDecl.Doc)
+				}
+				for _, group := range groups {
+					if group == nil {
+						continue
+					}
+					for _, comment := range group.List {
+						matching := _oneOfRE.FindStringSubmatch(comment.Text)
+						if matching == nil {
+							continue
+						}
+						// matching is a slice of two elements; the first is the whole matched
+						// string and the second is the captured list of field names.
+						oneOf := oneOfFields(pass, structType, strings.Split(matching[1], ","))
+						for _, name := range oneOf {
+							if v, ok := pass.TypesInfo.Defs[name].(*types.Var); ok {
+								oneOfs[v] = append(oneOfs[v], oneOf)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return oneOfs
+}
+
+// oneOfFields returns the identifiers of the struct fields with the given names, or nil if any of
+// the names does not refer to a distinct field of a nilable type (or there are fewer than two).
+func oneOfFields(pass *analysis.Pass, structType *ast.StructType, names []string) []*ast.Ident {
+	fields := make(map[string]*ast.Ident)
+	for _, field := range structType.Fields.List {
+		if util.TypeBarsNilness(pass.TypesInfo.TypeOf(field.Type)) {
+			continue
+		}
+		for _, name := range field.Names {
+			fields[name.Name] = name
+		}
+	}
+
+	var oneOf []*ast.Ident
+	for _, name := range names {
+		ident, ok := fields[strings.TrimSpace(name)]
+		if !ok {
+			return nil
+		}
+		// Remove the field such that the duplicates are rejected.
+		delete(fields, ident.Name)
+		oneOf = append(oneOf, ident)
+	}
+	if len(oneOf) < 2 {
+		return nil
+	}
+	return oneOf
+}
+
+// expandOneOfChecks chains a nil check of the last remaining field of a oneof group after the nil
+// check of a field in the group, if all other fields in the group are checked to be nil on the
+// same path, such that the remaining field is known to be nonnil there. Specifically, it rewrites
+// `if v.b == nil {T} {F}` to `if v.b == nil {if v.c == nil {U} else {T}} {F}` if the block is only
+// reachable from the true branch of `v.a == nil` (directly or through other conditionals) and
+// the struct is annotated with `//nilaway:oneof(a, b, c)`, where U is an (empty) unreachable
+// block. Note that, unlike the other chained nil checks, the nil branch cannot lead to F, since
+// F may rely on the checked field being nonnil.
+//
+// To avoid reasoning about the modifications of the struct, the path between the nil checks must
+// only consist of the conditionals, and the struct must be referenced by the same local variable.
+func (p *Preprocessor) expandOneOfChecks(graph *cfg.CFG) {
+	if len(p.oneOfs) == 0 {
+		return
+	}
+
+	preds := make(map[*cfg.Block][]*cfg.Block)
+	for _, block := range graph.Blocks {
+		if !block.Live {
+			continue
+		}
+		for _, succ := range block.Succs {
+			preds[succ] = append(preds[succ], block)
+		}
+	}
+
+	// Collect the nil checks to chain first, since chaining them modifies the predecessors.
+	chains := make(map[*cfg.Block][]ast.Expr)
+	var blocks []*cfg.Block
+	for _, block := range graph.Blocks {
+		if !block.Live || len(block.Nodes) != 1 || len(block.Succs) != 2 {
+			continue
+		}
+		recv, field := p.oneOfNilCheck(block)
+		if recv == nil {
+			continue
+		}
+		for _, oneOf := range p.oneOfs[field] {
+			// Find the fields in the group that are checked to be nil on the path to the block.
+			checked := map[*types.Var]bool{field: true}
+			visited := map[*cfg.Block]bool{block: true}
+			for cur := block; len(preds[cur]) == 1; {
+				pred := preds[cur][0]
+				if visited[pred] || len(pred.Succs) != 2 || pred.Succs[0] != cur || pred.Succs[1] == cur {
+					break
+				}
+				visited[pred] = true
+				if r, f := p.oneOfNilCheck(pred); r != nil && p.localVar(r) == p.localVar(recv) {
+					checked[f] = true
+				}
+				// The nodes before the conditional of the predecessor are evaluated before its
+				// nil check, so we can only move further up if it has no other nodes.
+				if len(pred.Nodes) != 1 {
+					break
+				}
+				cur = pred
+			}
+
+			var remaining []*ast.Ident
+			for _, ident := range oneOf {
+				if !checked[p.pass.TypesInfo.Defs[ident].(*types.Var)] {
+					remaining = append(remaining, ident)
+				}
+			}
+			if len(remaining) != 1 {
+				continue
+			}
+			if _, ok := chains[block]; !ok {
+				blocks = append(blocks, block)
+			}
+			chains[block] = append(chains[block], &ast.SelectorExpr{X: recv, Sel: remaining[0]})
+		}
+	}
+
+	if len(blocks) == 0 {
+		return
+	}
+	unreachable := &cfg.Block{Index: int32(len(graph.Blocks)), Live: true}
+	graph.Blocks = append(graph.Blocks, unreachable)
+	for _, block := range blocks {
+		for _, expr := range chains[block] {
+			newBlock := &cfg.Block{
+				Nodes: []ast.Node{&ast.BinaryExpr{
+					X:     expr,
+					OpPos: expr.Pos(),
+					Op:    token.EQL,
+					Y:     &ast.Ident{NamePos: expr.Pos(), Name: "nil"},
+				}},
+				Succs: []*cfg.Block{unreachable, block.Succs[0]},
+				Index: int32(len(graph.Blocks)),
+				Live:  true,
+			}
+			graph.Blocks = append(graph.Blocks, newBlock)
+			block.Succs[0] = newBlock
+		}
+	}
+}
+
+// oneOfNilCheck returns the receiver (a local variable) and the field of the nil check
+// `recv.field == nil` (in the canonical form) at the end of the block, if the field belongs to a
+// oneof group. Otherwise, nil values are returned.
+func (p *Preprocessor) oneOfNilCheck(block *cfg.Block) (*ast.Ident, *types.Var) {
+	if len(block.Nodes) == 0 {
+		return nil, nil
+	}
+	cond, ok := block.Nodes[len(block.Nodes)-1].(*ast.BinaryExpr)
+	if !ok || cond.Op != token.EQL || !util.IsLiteral(cond.Y, "nil") {
+		return nil, nil
+	}
+	sel, ok := cond.X.(*ast.SelectorExpr)
+	if !ok {
+		return nil, nil
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	if !ok || p.localVar(recv) == nil {
+		return nil, nil
+	}
+	field, ok := p.pass.TypesInfo.ObjectOf(sel.Sel).(*types.Var)
+	if !ok || !field.IsField() || len(p.oneOfs[field]) == 0 {
+		return nil, nil
+	}
+	return recv, field
+}
@@ -16,14 +16,26 @@
 // amenable to analysis.
 package preprocess
 
-import "golang.org/x/tools/go/analysis"
+import (
+	"go.uber.org/nilaway/assertion/function/functioncontracts"
+	"golang.org/x/tools/go/analysis"
+)
 
 // Preprocessor handles different preprocessing logic for different types of input.
 type Preprocessor struct {
 	pass *analysis.Pass
+	// funcContracts stores the function contracts of all the functions, which are used to expand
+	// calls to predicate functions in conditionals.
+	funcContracts functioncontracts.Map
+	// taggedUnions stores the fields of tagged union structs guarded by their tag fields, which
+	// are used to expand tag checks in conditionals.
+	taggedUnions TaggedUnions
+	// oneOfs stores the oneof groups of struct fields, which are used to expand nil checks of the
+	// fields in conditionals.
+	oneOfs OneOfs
 }
 
 // New returns a new Preprocessor.
-func New(pass *analysis.Pass) *Preprocessor {
-	return &Preprocessor{pass: pass}
+func New(pass *analysis.Pass, funcContracts functioncontracts.Map, taggedUnions TaggedUnions, oneOfs OneOfs) *Preprocessor {
+	return &Preprocessor{pass: pass, funcContracts: funcContracts, taggedUnions: taggedUnions, oneOfs: oneOfs}
 }
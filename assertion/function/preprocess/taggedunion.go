@@ -0,0 +1,142 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preprocess
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"regexp"
+
+	"go.uber.org/nilaway/util"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// _nonnilWhenKeyword is the keyword for annotating a field of a tagged union struct that is
+// nonnil whenever a tag field of the same struct has a certain constant value.
+const _nonnilWhenKeyword = "nilaway:nonnil-when"
+
+// _nonnilWhenRE matches the tagged union directive in its own line, which looks like
+// `nilaway:nonnil-when(TAG == VALUE)`. The RE captures the name of the tag field and the
+// (constant) value expression.
+var _nonnilWhenRE = regexp.MustCompile(
+	fmt.Sprintf("^\\s*//\\s*%s\\s*\\(\\s*([\\p{L}_][\\p{L}\\p{N}_]*)\\s*==\\s*(.+?)\\s*\\)\\s*$", _nonnilWhenKeyword))
+
+// TaggedField is a field of a tagged union struct that is nonnil when the tag field has the value.
+type TaggedField struct {
+	// Field is the identifier of the field in its declaration, which is used to build the
+	// (artificial) selector expressions of the field in nil checks.
+	Field *ast.Ident
+	// Value is the constant value of the tag field under which the field is nonnil.
+	Value constant.Value
+}
+
+// TaggedUnions maps the tag fields of tagged union structs to the fields guarded by them.
+type TaggedUnions map[*types.Var][]TaggedField
+
+// CollectTaggedUnions collects the tagged union directives on the struct fields declared in the
+// package. For example, the following directive states that the field `p` is nonnil whenever
+// the field `kind` is `KindPtr`, such that a check `v.kind == KindPtr` also guards `v.p`:
+//
+//	type value struct {
+//		kind Kind
+//		//nilaway:nonnil-when(kind == KindPtr)
+//		p *int
+//	}
+//
+// Note that the directives are only read from the current package, i.e., the tag checks on
+// structs declared in upstream packages are not credited.
+func CollectTaggedUnions(pass *analysis.Pass) TaggedUnions {
+	unions := make(TaggedUnions)
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			structType, ok := node.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			tags := make(map[string]*types.Var)
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					if v, ok := pass.TypesInfo.Defs[name].(*types.Var); ok {
+						tags[name.Name] = v
+					}
+				}
+			}
+			for _, field := range structType.Fields.List {
+				if len(field.Names) == 0 || util.TypeBarsNilness(pass.TypesInfo.TypeOf(field.Type)) {
+					continue
+				}
+				for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+					if group == nil {
+						continue
+					}
+					for _, comment := range group.List {
+						matching := _nonnilWhenRE.FindStringSubmatch(comment.Text)
+						if matching == nil {
+							continue
+						}
+						// matching is a slice of three elements; the first is the whole matched
+						// string and the next two are the captured tag field and value.
+						tag, ok := tags[matching[1]]
+						if !ok {
+							continue
+						}
+						tv, err := types.Eval(pass.Fset, pass.Pkg, field.Pos(), matching[2])
+						if err != nil || tv.Value == nil || !types.AssignableTo(tv.Type, tag.Type()) {
+							continue
+						}
+						for _, name := range field.Names {
+							unions[tag] = append(unions[tag], TaggedField{Field: name, Value: tv.Value})
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+	return unions
+}
+
+// taggedUnionGuardedFields returns the fields (as selector expressions on the same struct value
+// as the tag field) that are guaranteed to be nonnil if the comparison `x == y` holds, where one
+// side is a tag field of a tagged union struct and the other side is a constant.
+func (p *Preprocessor) taggedUnionGuardedFields(x, y ast.Expr) []ast.Expr {
+	sel, ok := astutil.Unparen(x).(*ast.SelectorExpr)
+	if !ok {
+		if sel, ok = astutil.Unparen(y).(*ast.SelectorExpr); !ok {
+			return nil
+		}
+		y = x
+	}
+	tag, ok := p.pass.TypesInfo.ObjectOf(sel.Sel).(*types.Var)
+	if !ok || !tag.IsField() || len(p.taggedUnions[tag]) == 0 {
+		return nil
+	}
+	val := p.pass.TypesInfo.Types[y].Value
+	if val == nil {
+		return nil
+	}
+
+	var exprs []ast.Expr
+	for _, f := range p.taggedUnions[tag] {
+		if constant.Compare(f.Value, token.EQL, val) {
+			exprs = append(exprs, &ast.SelectorExpr{X: sel.X, Sel: f.Field})
+		}
+	}
+	return exprs
+}
@@ -24,8 +24,10 @@ import (
 	"go/token"
 	"go/types"
 	"regexp"
+	"strings"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/util"
 	"golang.org/x/tools/go/analysis"
 )
@@ -45,10 +47,121 @@ func As(expr ast.Expr, p *analysis.Pass) (any, bool) {
 				}
 			}
 		}
+		if t := panicIfNilCond(call, p); t != nil {
+			return t, true
+		}
+		if t := protobufGetterProducer(call, p); t != nil {
+			return t, true
+		}
 	}
 	return nil, false
 }
 
+// panicIfNilCond checks if the call expression calls one of the user-configured functions that
+// panic if their arguments are nil (see config.Config.PanicIfNilFuncs), and if so, returns
+// `arg != nil` (joined by `&&` if multiple arguments are considered) for the nilable arguments
+// of the call, which gets interpreted as `if arg != nil {...}` by preprocess. Otherwise, it
+// returns nil.
+func panicIfNilCond(call *ast.CallExpr, p *analysis.Pass) ast.Expr {
+	conf, ok := p.ResultOf[config.Analyzer].(*config.Config)
+	if !ok || len(conf.PanicIfNilFuncs) == 0 {
+		return nil
+	}
+	ident := util.FuncIdentFromCallExpr(p.TypesInfo, call)
+	if ident == nil {
+		return nil
+	}
+	funcObj, ok := p.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok {
+		return nil
+	}
+	name, ok := util.FullyQualifiedFuncName(funcObj)
+	if !ok {
+		return nil
+	}
+	indices, ok := conf.PanicIfNilFuncs[name]
+	if !ok {
+		return nil
+	}
+	if indices == nil {
+		for i := range call.Args {
+			indices = append(indices, i)
+		}
+	}
+
+	var cond ast.Expr
+	for _, i := range indices {
+		if i >= len(call.Args) || util.ExprBarsNilness(p, call.Args[i]) {
+			continue
+		}
+		c := newNilBinaryExpr(call.Args[i], token.NEQ)
+		if cond == nil {
+			cond = c
+			continue
+		}
+		cond = &ast.BinaryExpr{X: cond, OpPos: c.Pos(), Op: token.LAND, Y: c}
+	}
+	return cond
+}
+
+// _protoMessageMethods are the methods that identify the types implementing `proto.Message`:
+// `ProtoReflect` for the current API (google.golang.org/protobuf) and `ProtoMessage` for the
+// legacy one (github.com/golang/protobuf), at least one of which is generated for every message.
+var _protoMessageMethods = []string{"ProtoReflect", "ProtoMessage"}
+
+// protobufGetterProducer checks if the call expression calls a generated protobuf getter that
+// returns a message (e.g., `req.GetUser()`), and if so, returns a nilable producer for its result
+// if the user enables the modeling of such getters (see config.Config.ModelProtobufGetters), since
+// the getters return nil for unset fields. Otherwise, it returns nil.
+func protobufGetterProducer(call *ast.CallExpr, p *analysis.Pass) *annotation.ProduceTrigger {
+	conf, ok := p.ResultOf[config.Analyzer].(*config.Config)
+	if !ok || !conf.ModelProtobufGetters {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(sel.Sel.Name) <= len("Get") || !strings.HasPrefix(sel.Sel.Name, "Get") {
+		return nil
+	}
+	funcObj, ok := p.TypesInfo.ObjectOf(sel.Sel).(*types.Func)
+	if !ok {
+		return nil
+	}
+	sig := funcObj.Type().(*types.Signature)
+	if sig.Recv() == nil || sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		return nil
+	}
+	if !isProtoMessage(sig.Recv().Type()) || !isProtoMessage(sig.Results().At(0).Type()) {
+		return nil
+	}
+	return &annotation.ProduceTrigger{
+		Annotation: &annotation.ProtobufGetterResult{
+			ProduceTriggerTautology: &annotation.ProduceTriggerTautology{},
+			Getter:                  sel.Sel.Name,
+		},
+		Expr: call,
+	}
+}
+
+// isProtoMessage returns true if the type is a pointer to a named type implementing
+// `proto.Message`, i.e., a generated protobuf message.
+func isProtoMessage(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	if _, ok := ptr.Elem().(*types.Named); !ok {
+		return false
+	}
+	for _, name := range _protoMessageMethods {
+		if obj, _, _ := types.LookupFieldOrMethod(ptr, false, nil, name); obj != nil {
+			if _, ok := obj.(*types.Func); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // funcKind indicates the kind of the trusted function:
 // (1) _method: it is a method of a struct;
 // (2) _func: it is a top-level function of a package.
@@ -157,6 +270,13 @@ var nonnilProducer action = func(call *ast.CallExpr, _ int, _ *analysis.Pass) an
 	}
 }
 
+var nilableProducer action = func(call *ast.CallExpr, _ int, _ *analysis.Pass) any {
+	return &annotation.ProduceTrigger{
+		Annotation: &annotation.TrustedFuncNilable{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}},
+		Expr:       call,
+	}
+}
+
 func newNilBinaryExpr(arg ast.Expr, op token.Token) *ast.BinaryExpr {
 	return &ast.BinaryExpr{
 		X:     arg,
@@ -453,6 +573,30 @@ var trustedFuncs = map[trustedFuncSig]trustedFuncAction{
 		funcNameRegex:  regexp.MustCompile(`^Errorf$`),
 	}: {action: nonnilProducer, argIndex: -1},
 
+	// `errors.Unwrap` returns nil if the error does not wrap another one.
+	{
+		kind:           _func,
+		enclosingRegex: regexp.MustCompile(`^errors$`),
+		funcNameRegex:  regexp.MustCompile(`^Unwrap$`),
+	}: {action: nilableProducer, argIndex: -1},
+
+	// `text/template` and `html/template`: `New` never returns nil, and `Must` panics instead of
+	// returning a nil template. Note that `Parse` (and the like) return nil templates along with
+	// non-nil errors, which is already handled by the error-return semantics.
+	{
+		kind:           _func,
+		enclosingRegex: regexp.MustCompile(`^(text|html)/template$`),
+		funcNameRegex:  regexp.MustCompile(`^(New|Must)$`),
+	}: {action: nonnilProducer, argIndex: -1},
+
+	// `sync/atomic.Pointer[T]`: `Load` returns nil before the first store, and `Swap` returns
+	// the previous value, which is similarly nil if nothing has been stored yet.
+	{
+		kind:           _method,
+		enclosingRegex: regexp.MustCompile(`^sync/atomic\.Pointer$`),
+		funcNameRegex:  regexp.MustCompile(`^(Load|Swap)$`),
+	}: {action: nilableProducer, argIndex: -1},
+
 	// `github.com/pkg/errors`
 	{
 		kind:           _func,
@@ -57,6 +57,7 @@ func run(pass *analysis.Pass) ([]annotation.FullTrigger, error) {
 			}
 			for _, spec := range genDecl.Specs {
 				fullTriggers = append(fullTriggers, analyzeValueSpec(pass, spec.(*ast.ValueSpec))...)
+				fullTriggers = append(fullTriggers, analyzeInitDerefs(pass, spec.(*ast.ValueSpec))...)
 			}
 		}
 	}
@@ -16,11 +16,13 @@ package global
 
 import (
 	"go/ast"
+	"go/token"
 	"go/types"
 
 	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/util"
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 // analyzeValueSpec returns full triggers corresponding to the declaration
@@ -62,6 +64,94 @@ func analyzeValueSpec(pass *analysis.Pass, spec *ast.ValueSpec) []annotation.Ful
 	return fullTriggers
 }
 
+// analyzeInitDerefs returns full triggers for the dereferences (i.e., pointer loads and field
+// accesses) within the initializer expressions of the declaration, since a nil dereference there
+// panics at the initialization of the package. Only the dereferences of the operands that we can
+// give producers for (i.e., other global variables and function calls) are considered.
+func analyzeInitDerefs(pass *analysis.Pass, spec *ast.ValueSpec) []annotation.FullTrigger {
+	var fullTriggers []annotation.FullTrigger
+	addDeref := func(operand ast.Expr, consumer annotation.ConsumingAnnotationTrigger) {
+		prod := getOperandProducer(pass, operand)
+		if prod == nil {
+			return
+		}
+		fullTriggers = append(fullTriggers, annotation.FullTrigger{
+			Producer: prod,
+			Consumer: &annotation.ConsumeTrigger{
+				Annotation: consumer,
+				Expr:       operand,
+				Guards:     util.NoGuards(),
+			},
+		})
+	}
+
+	var inspect func(node ast.Node) bool
+	inspect = func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			// The bodies of the function literals are not run at the initialization.
+			return false
+		case *ast.BinaryExpr:
+			// The right operands of the logical operators are only conditionally evaluated (e.g.,
+			// guarded by a nil check in the left operand as in `v != nil && *v == 1`), which we do
+			// not reason about here. So we conservatively skip them.
+			if node.Op == token.LAND || node.Op == token.LOR {
+				ast.Inspect(node.X, inspect)
+				return false
+			}
+		case *ast.StarExpr:
+			// Skip the pointer types (e.g., in conversions like `(*T)(nil)`).
+			if tv, ok := pass.TypesInfo.Types[node.X]; ok && tv.IsValue() && util.TypeIsDeeplyPtr(tv.Type) {
+				addDeref(node.X, &annotation.PtrLoad{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}})
+			}
+		case *ast.SelectorExpr:
+			sel, ok := pass.TypesInfo.Selections[node]
+			if ok && sel.Kind() == types.FieldVal && util.TypeIsDeeplyPtr(sel.Recv()) {
+				addDeref(node.X, &annotation.FldAccess{ConsumeTriggerTautology: &annotation.ConsumeTriggerTautology{}, Sel: sel.Obj()})
+			}
+		}
+		return true
+	}
+	for _, value := range spec.Values {
+		ast.Inspect(value, inspect)
+	}
+	return fullTriggers
+}
+
+// getOperandProducer returns a producer for the operand of a dereference in an initializer
+// expression in the cases: 1) literal nil 2) another global var 3) single-result func / method
+// call. In all other cases, it returns nil.
+func getOperandProducer(pass *analysis.Pass, operand ast.Expr) *annotation.ProduceTrigger {
+	switch operand := astutil.Unparen(operand).(type) {
+	case *ast.Ident:
+		if operand.Name == "nil" {
+			return &annotation.ProduceTrigger{
+				Annotation: &annotation.ConstNil{ProduceTriggerTautology: &annotation.ProduceTriggerTautology{}},
+				Expr:       operand,
+			}
+		}
+		return getProducerForVar(pass, operand)
+	case *ast.CallExpr:
+		if _, ok := pass.TypesInfo.TypeOf(operand).(*types.Tuple); ok {
+			return nil
+		}
+		switch fun := operand.Fun.(type) {
+		case *ast.Ident:
+			if _, ok := pass.TypesInfo.ObjectOf(fun).(*types.Builtin); ok {
+				return nil
+			}
+			return getProducerForFuncCall(pass, fun, 0, 0, operand)
+		case *ast.SelectorExpr:
+			if sel, ok := pass.TypesInfo.Selections[fun]; ok && sel.Kind() == types.MethodVal {
+				return getProducerForMethodCall(pass, fun.Sel, 0, 0, operand)
+			}
+			// Package-qualified function call.
+			return getProducerForFuncCall(pass, fun.Sel, 0, 0, operand)
+		}
+	}
+	return nil
+}
+
 // Returns a list of consumers corresponding to a global level variable declaration
 func getGlobalConsumers(pass *analysis.Pass, valspec *ast.ValueSpec) []*annotation.ConsumeTrigger {
 	consumers := make([]*annotation.ConsumeTrigger, len(valspec.Names))
@@ -0,0 +1,146 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// _exitCodeDiagnostics is the exit code of the singlechecker driver if any errors are reported.
+const _exitCodeDiagnostics = 3
+
+// needsChildProcess returns true if the command line arguments enable any mode that post-processes
+// the errors of all packages once the analysis finishes (i.e., the summary footer or the
+// diff-friendly output), unless the driver already runs as the child process of such a mode.
+func needsChildProcess(args []string) bool {
+	if os.Getenv(_summaryFileEnv) != "" || os.Getenv(_diffFriendlyFileEnv) != "" {
+		return false
+	}
+	return boolFlagEnabled(args, "summary") || boolFlagEnabled(args, "diff-friendly")
+}
+
+// boolFlagEnabled returns true if the command line arguments set the boolean flag of the name to
+// true, accepting all the forms that the flag package accepts (e.g., "-name", "--name" and
+// "-name=1"), where the last occurrence takes effect.
+func boolFlagEnabled(args []string, name string) bool {
+	enabled := false
+	for _, arg := range args {
+		arg, ok := strings.CutPrefix(arg, "-")
+		if !ok {
+			continue
+		}
+		arg = strings.TrimPrefix(arg, "-")
+		if arg == name {
+			enabled = true
+			continue
+		}
+		if value, ok := strings.CutPrefix(arg, name+"="); ok {
+			b, err := strconv.ParseBool(value)
+			enabled = err == nil && b
+		}
+	}
+	return enabled
+}
+
+// runInChildProcess runs the driver as a child process, and post-processes the errors it reported
+// once it exits, returning the exit code of the child. The singlechecker driver exits the process
+// right after printing the errors and offers no hook to run afterward, hence the analysis runs in a
+// child process that writes the errors it reports to temporary files instead. Specifically,
+//   - in the summary mode, the summary footer is written to stderr (see writeSummary);
+//   - in the diff-friendly mode, the errors of all packages are written to stdout at once (see
+//     writeDiffFriendly). Since the child does not report them to its driver, the exit code is
+//     set to the one of the driver for reported errors if there are any.
+func runInChildProcess(stdout, stderr io.Writer, args []string) int {
+	env := os.Environ()
+	createFile := func(name, envName string) (string, error) {
+		f, err := os.CreateTemp("", "nilaway-"+name+"-*.jsonl")
+		if err != nil {
+			return "", fmt.Errorf("create %s file: %w", name, err)
+		}
+		f.Close()
+		env = append(env, envName+"="+f.Name())
+		return f.Name(), nil
+	}
+	var summaryFile, diffFriendlyFile string
+	if boolFlagEnabled(args, "summary") {
+		path, err := createFile("summary", _summaryFileEnv)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer os.Remove(path)
+		summaryFile = path
+	}
+	if boolFlagEnabled(args, "diff-friendly") {
+		path, err := createFile("diff-friendly", _diffFriendlyFileEnv)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		defer os.Remove(path)
+		diffFriendlyFile = path
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to find executable: %v\n", err)
+		return 1
+	}
+	cmd := exec.Command(executable, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, stdout, stderr
+	cmd.Env = env
+	code := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			fmt.Fprintf(stderr, "failed to run analysis: %v\n", err)
+			return 1
+		}
+		code = exitErr.ExitCode()
+	}
+
+	if diffFriendlyFile != "" {
+		diagnostics, err := readLines[diffFriendlyDiagnostic](diffFriendlyFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to read diff-friendly file: %v\n", err)
+			return 1
+		}
+		if err := writeDiffFriendly(&jsonlWriter{w: stdout}, diagnostics); err != nil {
+			fmt.Fprintf(stderr, "failed to write diff-friendly output: %v\n", err)
+			return 1
+		}
+		if code == 0 && len(diagnostics) > 0 {
+			code = _exitCodeDiagnostics
+		}
+	}
+	if summaryFile != "" {
+		diagnostics, err := readLines[summaryDiagnostic](summaryFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to read summary file: %v\n", err)
+			return 1
+		}
+		if err := writeSummary(stderr, diagnostics); err != nil {
+			fmt.Fprintf(stderr, "failed to write summary: %v\n", err)
+			return 1
+		}
+	}
+	return code
+}
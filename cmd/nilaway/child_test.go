@@ -0,0 +1,49 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoolFlagEnabled(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"-diff-friendly"},
+		{"--diff-friendly"},
+		{"-diff-friendly=true"},
+		{"-diff-friendly=1"},
+		{"-diff-friendly=t"},
+		{"-diff-friendly=TRUE"},
+		{"-include-pkgs=a", "-diff-friendly", "./..."},
+		{"-diff-friendly=false", "-diff-friendly"},
+	} {
+		require.True(t, boolFlagEnabled(args, "diff-friendly"), args)
+	}
+	for _, args := range [][]string{
+		{"-diff-friendly=false"},
+		{"-diff-friendly=0"},
+		{"-diff-friendly=invalid"},
+		{"-diff-friendly", "-diff-friendly=f"},
+		{"-diff-friendly-other"},
+		{"diff-friendly"},
+		{"-summary", "./..."},
+	} {
+		require.False(t, boolFlagEnabled(args, "diff-friendly"), args)
+	}
+}
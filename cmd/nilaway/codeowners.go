@@ -0,0 +1,129 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// codeownersRule is a single rule in a CODEOWNERS file, mapping a file pattern to its owners.
+type codeownersRule struct {
+	// pattern is the file pattern with leading and trailing slashes trimmed.
+	pattern string
+	// anchored indicates whether the pattern must match from the root of the repository, which
+	// is the case if the pattern contains a slash anywhere but at the end.
+	anchored bool
+	// dirOnly indicates whether the pattern only matches directories (i.e., ends with a slash).
+	dirOnly bool
+	owners  []string
+}
+
+// codeowners stores the rules of a CODEOWNERS file and the root directory the rules are relative to.
+type codeowners struct {
+	root  string
+	rules []codeownersRule
+}
+
+// parseCodeowners reads and parses the CODEOWNERS file at the given path. Following GitHub's
+// conventions, the patterns are relative to the directory containing the file, or its parent if
+// the file is placed under a ".github" or "docs" directory. Comments, blank lines and rules
+// without owners are ignored.
+func parseCodeowners(p string) (*codeowners, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return nil, fmt.Errorf("convert %q to absolute path: %w", p, err)
+	}
+	root := filepath.Dir(abs)
+	if base := filepath.Base(root); base == ".github" || base == "docs" {
+		root = filepath.Dir(root)
+	}
+
+	f, err := os.Open(abs)
+	if err != nil {
+		return nil, fmt.Errorf("open CODEOWNERS file: %w", err)
+	}
+	defer f.Close()
+
+	c := &codeowners{root: root}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		pattern := fields[0]
+		c.rules = append(c.rules, codeownersRule{
+			pattern:  strings.Trim(pattern, "/"),
+			anchored: strings.Contains(strings.TrimSuffix(pattern, "/"), "/"),
+			dirOnly:  strings.HasSuffix(pattern, "/"),
+			owners:   fields[1:],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read CODEOWNERS file: %w", err)
+	}
+	return c, nil
+}
+
+// Owners returns the owners of the given file, or nil if the file is not owned by anyone (or is
+// outside the root of the CODEOWNERS file). As in GitHub, the last matching rule takes precedence.
+func (c *codeowners) Owners(file string) []string {
+	rel, err := filepath.Rel(c.root, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	segments := strings.Split(filepath.ToSlash(rel), "/")
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].matches(segments) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// matches returns true if the rule matches the file with the given path segments. A pattern
+// matching a directory also matches all files under it.
+func (r codeownersRule) matches(segments []string) bool {
+	if r.pattern == "" || r.pattern == "*" {
+		return !r.dirOnly || len(segments) > 1
+	}
+
+	starts := len(segments)
+	if r.anchored {
+		starts = 1
+	}
+	for start := 0; start < starts; start++ {
+		for end := start + 1; end <= len(segments); end++ {
+			// A directory-only pattern must not match the file itself.
+			if r.dirOnly && end == len(segments) {
+				continue
+			}
+			if ok, _ := path.Match(r.pattern, strings.Join(segments[start:end], "/")); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestCodeowners(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	err := os.MkdirAll(filepath.Join(root, ".github"), 0o755)
+	require.NoError(t, err)
+	content := `
+# Default owners.
+*                 @org/everyone
+*.pb.go           @org/proto # Generated files.
+/pkg/             @org/pkg
+internal/         @org/internal
+/pkg/sub/foo.go   @alice @bob
+docs/*.md         @org/docs
+no-owners
+`
+	p := filepath.Join(root, ".github", "CODEOWNERS")
+	err = os.WriteFile(p, []byte(content), 0o644)
+	require.NoError(t, err)
+
+	owners, err := parseCodeowners(p)
+	require.NoError(t, err)
+
+	tests := map[string][]string{
+		"main.go":                  {"@org/everyone"},
+		"a/b/types.pb.go":          {"@org/proto"},
+		"pkg/a.go":                 {"@org/pkg"},
+		"pkg/types.pb.go":          {"@org/pkg"},
+		"pkg/sub/foo.go":           {"@alice", "@bob"},
+		"pkg/sub/bar.go":           {"@org/pkg"},
+		"a/pkg/main.go":            {"@org/everyone"},
+		"internal/a.go":            {"@org/internal"},
+		"a/internal/b/c.go":        {"@org/internal"},
+		"docs/README.md":           {"@org/docs"},
+		"docs/sub/README.md":       {"@org/everyone"},
+		"no-owners":                {"@org/everyone"},
+		"../outside/main.go":       nil,
+		"internal":                 {"@org/everyone"},
+		"a/b/internal/types.pb.go": {"@org/internal"},
+	}
+	for file, expected := range tests {
+		require.Equal(t, expected, owners.Owners(filepath.Join(root, file)), "file: %s", file)
+	}
+}
+
+func TestRun_Codeowners(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// and config flags.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	_codeowners = filepath.Join(testdata, "src", "codeowners", "CODEOWNERS")
+	_includeErrorsInFiles = testdata
+	err = config.Analyzer.Flags.Set(config.PrettyPrintFlag, "false")
+	require.NoError(t, err)
+	defer func() {
+		_codeowners, _includeErrorsInFiles = "", ""
+		err := config.Analyzer.Flags.Set(config.PrettyPrintFlag, "true")
+		require.NoError(t, err)
+	}()
+
+	analysistest.Run(t, testdata, Analyzer, "codeowners/...")
+}
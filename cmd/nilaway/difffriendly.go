@@ -0,0 +1,141 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// _diffFriendlyFileEnv is the environment variable that the driver sets for its child process in
+// the diff-friendly mode (see runInChildProcess), naming the file that the child writes the errors
+// to.
+const _diffFriendlyFileEnv = "NILAWAY_DIFF_FRIENDLY_FILE"
+
+var (
+	// _ansiEscapeRE matches the ANSI escape sequences of the pretty-printed messages.
+	_ansiEscapeRE = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	// _columnRE matches the positions (i.e., "file.go:line:column") in the messages, capturing
+	// the part without the column.
+	_columnRE = regexp.MustCompile(`(\.go:\d+):\d+`)
+	// _similarPlacesRE matches the list of the quoted positions of the similar nil panics at the
+	// end of the grouped messages, capturing the list.
+	_similarPlacesRE = regexp.MustCompile(`other place\(s\): ((?:"[^"]*"(?:, and |, )?)+)\.\)`)
+	// _quotedRE matches a quoted string in the list of the positions of the similar nil panics.
+	_quotedRE = regexp.MustCompile(`"[^"]*"`)
+)
+
+// diffFriendlyDiagnostic is a single line of the diff-friendly output, where the fields that vary
+// between runs (or machines) are normalized away, such that the outputs can be checked in as
+// golden files or diffed in CI.
+type diffFriendlyDiagnostic struct {
+	// Posn is the position of the diagnostic in the form of "file:line", where the file is
+	// relative to the working directory (or absolute if the file is outside of it).
+	Posn string `json:"posn"`
+	// Message is the message of the diagnostic rendered in a single line.
+	Message string `json:"message"`
+}
+
+// String returns the line of the diagnostic in the diff-friendly output.
+func (d diffFriendlyDiagnostic) String() string {
+	return d.Posn + ": " + d.Message
+}
+
+// newDiffFriendlyDiagnostic converts the diagnostic at the position to its diff-friendly form.
+// Specifically, the paths (in the position and the message) are made relative to the base
+// directory, the columns are dropped, the positions of the similar nil panics are sorted, and the
+// message (without the escape sequences of pretty printing) is joined into a single line.
+func newDiffFriendlyDiagnostic(posn token.Position, message, base string) diffFriendlyDiagnostic {
+	message = _ansiEscapeRE.ReplaceAllString(message, "")
+	message = strings.ReplaceAll(message, base+string(filepath.Separator), "")
+	message = _columnRE.ReplaceAllString(message, "$1")
+	message = _similarPlacesRE.ReplaceAllStringFunc(message, func(s string) string {
+		list := _similarPlacesRE.FindStringSubmatch(s)[1]
+		places := _quotedRE.FindAllString(list, -1)
+		slices.SortFunc(places, func(a, b string) int {
+			return comparePosns(strings.Trim(a, `"`), strings.Trim(b, `"`))
+		})
+		sorted := strings.Join(places[:len(places)-1], ", ")
+		if len(places) > 1 {
+			sorted += ", and "
+		}
+		return strings.Replace(s, list, sorted+places[len(places)-1], 1)
+	})
+
+	var lines []string
+	for _, l := range strings.Split(message, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	return diffFriendlyDiagnostic{
+		Posn:    fmt.Sprintf("%s:%d", relativePath(posn.Filename, base), posn.Line),
+		Message: strings.Join(lines, " "),
+	}
+}
+
+// openDiffFriendlyOutput opens the file named by _diffFriendlyFileEnv only once, since the errors
+// of all packages are written to the same file.
+var openDiffFriendlyOutput = sync.OnceValues(func() (*jsonlWriter, error) {
+	f, err := os.OpenFile(os.Getenv(_diffFriendlyFileEnv), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open diff-friendly file: %w", err)
+	}
+	return &jsonlWriter{w: f}, nil
+})
+
+// writeDiffFriendly sorts the diagnostics (of all packages) by their positions and messages, and
+// writes them as consecutive lines to the writer. The duplicates (e.g., the ones reported again by
+// the test variants of the packages) are written once, the same as the driver prints them once.
+func writeDiffFriendly(j *jsonlWriter, diagnostics []diffFriendlyDiagnostic) error {
+	slices.SortFunc(diagnostics, func(a, b diffFriendlyDiagnostic) int {
+		if n := comparePosns(a.Posn, b.Posn); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Message, b.Message)
+	})
+	diagnostics = slices.Compact(diagnostics)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, d := range diagnostics {
+		if _, err := fmt.Fprintln(j.w, d.String()); err != nil {
+			return fmt.Errorf("write diagnostic: %w", err)
+		}
+	}
+	return nil
+}
+
+// comparePosns compares the positions (in the form of "file:line:column" or "file:line") by their
+// files, and then numerically by their lines and columns.
+func comparePosns(a, b string) int {
+	aFile, aLine, aCol := splitPosn(a)
+	bFile, bLine, bCol := splitPosn(b)
+	if n := cmp.Compare(aFile, bFile); n != 0 {
+		return n
+	}
+	if n := cmp.Compare(aLine, bLine); n != 0 {
+		return n
+	}
+	return cmp.Compare(aCol, bCol)
+}
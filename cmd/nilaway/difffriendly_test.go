@@ -0,0 +1,124 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNewDiffFriendlyDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.Join(string(filepath.Separator), "repo")
+	file := filepath.Join(base, "pkg", "a.go")
+	message := "\x1b[31mPotential nil panic detected.\x1b[0m Observed nil flow from source to dereference point: \n" +
+		"\t- " + file + ":3:9: literal `nil` returned from `bar()` in position 0\n" +
+		"\t- " + file + ":7:9: result 0 of `bar()` dereferenced\n" +
+		"\n(Same nil source could also cause potential nil panic(s) at 3 other place(s): \"" +
+		file + ":12:2\", \"" + file + ":8:9\", and \"" + file + ":8:3\".)\n"
+
+	d := newDiffFriendlyDiagnostic(token.Position{Filename: file, Line: 7, Column: 9}, message, base)
+	require.Equal(t, "pkg/a.go:7", d.Posn)
+	require.Equal(t, "Potential nil panic detected. Observed nil flow from source to dereference point: "+
+		"- pkg/a.go:3: literal `nil` returned from `bar()` in position 0 "+
+		"- pkg/a.go:7: result 0 of `bar()` dereferenced "+
+		"(Same nil source could also cause potential nil panic(s) at 3 other place(s): "+
+		"\"pkg/a.go:8\", \"pkg/a.go:8\", and \"pkg/a.go:12\".)", d.Message)
+
+	// Files outside of the base directory keep their absolute paths.
+	outside := filepath.Join(string(filepath.Separator), "other", "b.go")
+	d = newDiffFriendlyDiagnostic(token.Position{Filename: outside, Line: 1, Column: 4}, "message", base)
+	require.Equal(t, outside+":1: message", d.String())
+}
+
+func TestWriteDiffFriendly(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := writeDiffFriendly(&jsonlWriter{w: &buf}, []diffFriendlyDiagnostic{
+		{Posn: "b.go:1", Message: "b1"},
+		{Posn: "a.go:10", Message: "a10"},
+		{Posn: "a.go:9", Message: "a9 second"},
+		{Posn: "a.go:9", Message: "a9 first"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a.go:9: a9 first\na.go:9: a9 second\na.go:10: a10\nb.go:1: b1\n", buf.String())
+}
+
+func TestRun_DiffFriendly(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and the environment.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	originalOpen := openDiffFriendlyOutput
+	_diffFriendly, _includeErrorsInFiles = true, testdata
+	defer func() {
+		_diffFriendly, _includeErrorsInFiles = false, ""
+		openDiffFriendlyOutput = originalOpen
+	}()
+
+	// The outputs of two separate runs over multiple packages (which are analyzed in parallel)
+	// must be byte-identical.
+	var outputs [2]bytes.Buffer
+	for i, pkgs := range [][]string{
+		{"jsonl", "difffriendly/a", "difffriendly/b"},
+		{"difffriendly/b", "difffriendly/a", "jsonl"},
+	} {
+		// The errors are written to the file given by the parent process (see runInChildProcess),
+		// which is opened only once per process.
+		file := filepath.Join(t.TempDir(), "diff-friendly.jsonl")
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+		t.Setenv(_diffFriendlyFileEnv, file)
+		openDiffFriendlyOutput = sync.OnceValues(func() (*jsonlWriter, error) {
+			f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND, 0)
+			return &jsonlWriter{w: f}, err
+		})
+
+		// analysistest checks that no errors are reported to the driver.
+		analysistest.Run(t, testdata, Analyzer, pkgs...)
+
+		diagnostics, err := readLines[diffFriendlyDiagnostic](file)
+		require.NoError(t, err)
+		require.NoError(t, writeDiffFriendly(&jsonlWriter{w: &outputs[i]}, diagnostics))
+	}
+	require.Equal(t, outputs[0].String(), outputs[1].String())
+
+	lines := strings.Split(strings.TrimSuffix(outputs[0].String(), "\n"), "\n")
+	require.Len(t, lines, 6)
+	prefixes := []string{
+		"testdata/src/difffriendly/a/a.go:13: ",
+		"testdata/src/difffriendly/b/b.go:13: ",
+		"testdata/src/difffriendly/b/b.go:18: ",
+		"testdata/src/jsonl/jsonl.go:",
+		"testdata/src/jsonl/jsonl.go:",
+		"testdata/src/jsonl/jsonl.go:",
+	}
+	for i, line := range lines {
+		require.True(t, strings.HasPrefix(line, prefixes[i]), "line: %q", line)
+		require.Contains(t, line, ": Potential nil panic detected.")
+		require.NotContains(t, line, "\x1b[")
+		require.NotRegexp(t, `\.go:\d+:\d+`, line)
+	}
+}
@@ -0,0 +1,77 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"os"
+	"slices"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// _applyMu serializes the edits of the source files, since the same files can be analyzed
+// concurrently as parts of different packages (e.g., a package and its test variant).
+var _applyMu sync.Mutex
+
+// applyEdits applies the text edits (of the suggested fixes of a single package) to the source
+// files on disk. The files that have changed since they were loaded (e.g., already edited for
+// another variant of the same package) are skipped, since the positions of the edits no longer
+// apply to them.
+func applyEdits(fset *token.FileSet, edits []analysis.TextEdit) error {
+	byFile := make(map[string][]analysis.TextEdit)
+	sizes := make(map[string]int)
+	for _, edit := range edits {
+		f := fset.File(edit.Pos)
+		byFile[f.Name()] = append(byFile[f.Name()], edit)
+		sizes[f.Name()] = f.Size()
+	}
+
+	_applyMu.Lock()
+	defer _applyMu.Unlock()
+	for name, edits := range byFile {
+		info, err := os.Stat(name)
+		if err != nil {
+			return fmt.Errorf("stat %q: %w", name, err)
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("read %q: %w", name, err)
+		}
+		if len(content) != sizes[name] {
+			continue
+		}
+
+		// Apply the edits from the end of the file, such that the offsets of the remaining edits
+		// stay valid.
+		f := fset.File(edits[0].Pos)
+		slices.SortStableFunc(edits, func(a, b analysis.TextEdit) int {
+			return cmp.Compare(b.Pos, a.Pos)
+		})
+		for _, edit := range edits {
+			start, end := f.Offset(edit.Pos), f.Offset(edit.End)
+			edited := make([]byte, 0, len(content)-(end-start)+len(edit.NewText))
+			edited = append(append(append(edited, content[:start]...), edit.NewText...), content[end:]...)
+			content = edited
+		}
+		if err := os.WriteFile(name, content, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("write %q: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRun_ApplyInferredAnnotations(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags, and the analysis edits the source files in place.
+	original, err := os.ReadFile(filepath.Join("testdata", "src", "applyannotations", "applyannotations.go"))
+	require.NoError(t, err)
+	golden, err := os.ReadFile(filepath.Join("testdata", "src", "applyannotations", "applyannotations.go.golden"))
+	require.NoError(t, err)
+
+	// Copy the sample to a temporary directory, since the annotations are applied in place.
+	dir := t.TempDir()
+	file := filepath.Join(dir, "src", "applyannotations", "applyannotations.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+	require.NoError(t, os.WriteFile(file, original, 0o644))
+
+	require.NoError(t, config.Analyzer.Flags.Set(config.ApplyInferredAnnotationsFlag, "true"))
+	_includeErrorsInFiles = dir
+	defer func() {
+		require.NoError(t, config.Analyzer.Flags.Set(config.ApplyInferredAnnotationsFlag, "false"))
+		_includeErrorsInFiles = ""
+	}()
+
+	analysistest.Run(t, dir, Analyzer, "applyannotations")
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), string(content))
+
+	// Applying the annotations again must not change the file, since all inferred sites are now
+	// explicitly annotated.
+	analysistest.Run(t, dir, Analyzer, "applyannotations")
+	content, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), string(content))
+}
@@ -0,0 +1,110 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// _outputFormatJSONL is the output format that writes the diagnostics as newline-delimited JSON,
+// i.e., one independently-valid JSON object per line.
+const _outputFormatJSONL = "jsonl"
+
+// jsonlDiagnostic is a single line of the newline-delimited JSON output.
+type jsonlDiagnostic struct {
+	// Package is the path of the package that was analyzed when the diagnostic was reported.
+	Package string `json:"package"`
+	// Posn is the position of the diagnostic in the form of "file:line:column", the same as the
+	// "posn" field of the JSON output of the standard drivers, except that the file is relative to
+	// the working directory (or absolute if the file is outside of it).
+	Posn string `json:"posn"`
+	// Message is the plain text message of the diagnostic, i.e., without the escape sequences of
+	// pretty printing.
+	Message string `json:"message"`
+}
+
+// newJSONLDiagnostic converts the diagnostic at the position (reported when analyzing the package)
+// to its machine-readable form, where the paths (in the position and the message) are made
+// relative to the base directory, and the escape sequences of pretty printing are removed from the
+// message.
+func newJSONLDiagnostic(pkg string, posn token.Position, message, base string) jsonlDiagnostic {
+	message = _ansiEscapeRE.ReplaceAllString(message, "")
+	posn.Filename = relativePath(posn.Filename, base)
+	return jsonlDiagnostic{
+		Package: pkg,
+		Posn:    posn.String(),
+		Message: strings.ReplaceAll(message, base+string(filepath.Separator), ""),
+	}
+}
+
+// jsonlWriter writes the diagnostics as newline-delimited JSON to the underlying writer. It is
+// safe for concurrent use since the packages are analyzed in parallel.
+type jsonlWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes the diagnostics (of a single package) as consecutive lines, such that the lines
+// of different packages are never interleaved.
+func (j *jsonlWriter) Write(diagnostics []jsonlDiagnostic) error {
+	return writeLines(j, diagnostics)
+}
+
+// writeLines writes the values as consecutive lines of JSON objects to the writer. It is a
+// function rather than a method of jsonlWriter since methods cannot have type parameters.
+func writeLines[T any](j *jsonlWriter, values []T) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// Encode appends a newline after each value and never emits newlines within a value.
+	encoder := json.NewEncoder(j.w)
+	for _, v := range values {
+		if err := encoder.Encode(v); err != nil {
+			return fmt.Errorf("write diagnostic: %w", err)
+		}
+	}
+	return nil
+}
+
+// readLines reads the values from the file of newline-delimited JSON objects, e.g., the one
+// written by the child process (see runInChildProcess).
+func readLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	var values []T
+	decoder := json.NewDecoder(f)
+	for {
+		var v T
+		if err := decoder.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return values, nil
+			}
+			return nil, fmt.Errorf("read %q: %w", path, err)
+		}
+		values = append(values, v)
+	}
+}
@@ -0,0 +1,118 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestJSONLWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := &jsonlWriter{w: &buf}
+
+	// Write from multiple goroutines to simulate concurrent analyses of packages.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pkg := fmt.Sprintf("pkg%d", i)
+			err := w.Write([]jsonlDiagnostic{
+				{Package: pkg, Posn: "a.go:1:1", Message: "first\nmultiline \"message\""},
+				{Package: pkg, Posn: "a.go:2:1", Message: "second"},
+			})
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 20)
+	for i, line := range lines {
+		var d jsonlDiagnostic
+		require.NoError(t, json.Unmarshal([]byte(line), &d), "line %d: %q", i, line)
+		// The lines of the same package must be consecutive.
+		if i%2 == 1 {
+			var prev jsonlDiagnostic
+			require.NoError(t, json.Unmarshal([]byte(lines[i-1]), &prev))
+			require.Equal(t, prev.Package, d.Package)
+			require.Equal(t, "second", d.Message)
+		}
+	}
+}
+
+func TestRun_JSONL(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and output.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_outputFormat, _includeErrorsInFiles = _outputFormatJSONL, testdata
+	_jsonlOutput = &jsonlWriter{w: &buf}
+	defer func() {
+		_outputFormat, _includeErrorsInFiles = "", ""
+		_jsonlOutput = &jsonlWriter{w: os.Stdout}
+	}()
+
+	// analysistest checks that no errors are reported to the driver.
+	analysistest.Run(t, testdata, Analyzer, "jsonl")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		var d jsonlDiagnostic
+		require.NoError(t, json.Unmarshal([]byte(line), &d), "line: %q", line)
+		require.Equal(t, "jsonl", d.Package)
+		// The positions are relative to the working directory, and the messages are plain text
+		// even though they are pretty-printed by default.
+		require.True(t, strings.HasPrefix(d.Posn, "testdata/src/jsonl/jsonl.go:"), "posn: %q", d.Posn)
+		require.Contains(t, d.Message, "Potential nil panic detected")
+		require.NotContains(t, d.Message, "\x1b")
+	}
+}
+
+func TestNewJSONLDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.Join(string(filepath.Separator), "repo")
+	file := filepath.Join(base, "pkg", "a.go")
+	message := "\x1b[31merror: \x1b[0mPotential nil panic detected. (Same nil source could also cause potential nil panic(s) at 1 other place(s): \"" + file + ":8:9\".)"
+
+	d := newJSONLDiagnostic("pkg", token.Position{Filename: file, Line: 7, Column: 9}, message, base)
+	require.Equal(t, jsonlDiagnostic{
+		Package: "pkg",
+		Posn:    "pkg/a.go:7:9",
+		Message: "error: Potential nil panic detected. (Same nil source could also cause potential nil panic(s) at 1 other place(s): \"pkg/a.go:8:9\".)",
+	}, d)
+
+	// Files outside of the base directory keep their absolute paths.
+	outside := filepath.Join(string(filepath.Separator), "other", "b.go")
+	d = newJSONLDiagnostic("pkg", token.Position{Filename: outside, Line: 1, Column: 2}, "message", base)
+	require.Equal(t, outside+":1:2", d.Posn)
+}
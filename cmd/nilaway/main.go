@@ -23,11 +23,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"go.uber.org/nilaway"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
 )
 
 // Analyzer is identical to the one in nilaway.go, except that it overrides the run function for
@@ -47,9 +50,47 @@ var (
 	_includeErrorsInFiles string
 	// _excludeErrorsInFiles is a driver flag for specifying the list of file prefixes to not report errors.
 	_excludeErrorsInFiles string
+	// _codeowners is a driver flag for specifying the path to a CODEOWNERS file, which is used to
+	// label the errors with the owners of the files they are reported in.
+	_codeowners string
+	// _outputFormat is a driver flag for specifying an alternative output format for the errors.
+	_outputFormat string
+	// _reportURL is a driver flag for specifying an endpoint that the errors are posted to as JSON.
+	_reportURL string
+	// _diffFriendly is a driver flag for writing the errors in a normalized plain text form that
+	// is stable across runs and machines (see diffFriendlyDiagnostic).
+	_diffFriendly bool
+	// _serve is a driver flag for running NilAway as a long-lived server that keeps the packages
+	// loaded and re-analyzes them on requests (see server).
+	_serve bool
+	// _metrics is a driver flag for specifying a file that the per-package metrics of the errors
+	// are written to as newline-delimited JSON (see packageMetrics).
+	_metrics string
+	// _summary is a driver flag for writing a footer with the counts of the errors by severity and
+	// category to stderr once the analysis finishes (see writeSummary).
+	_summary bool
 )
 
+// _jsonlOutput is where the errors are streamed to in the newline-delimited JSON output format.
+var _jsonlOutput = &jsonlWriter{w: os.Stdout}
+
+// loadCodeowners parses the CODEOWNERS file specified by the driver flag (if any) only once, since
+// it is shared across the analyses of all packages.
+var loadCodeowners = sync.OnceValues(func() (*codeowners, error) {
+	if _codeowners == "" {
+		return nil, nil
+	}
+	return parseCodeowners(_codeowners)
+})
+
 func run(pass *analysis.Pass) (interface{}, error) {
+	// The package is skipped since the total timeout is exceeded, where we return an error (for
+	// the first skipped package only) to note that the analysis is incomplete, which also makes
+	// the driver exit with a distinct exit code (1, as opposed to 3 for reported errors).
+	if pass.ResultOf[config.Analyzer].(*config.Config) == _skippedConfig {
+		return nil, _budget.incompleteError(pass)
+	}
+
 	// NilAway by default analyzes all packages, including dependencies. Even if specified to
 	// exclude packages from analysis via configurations, NilAway can still report errors on
 	// packages that are not analyzed if the nilness flow happens within the analyzed package, but
@@ -66,9 +107,79 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parse file prefixes for error exclusion: %w", err)
 	}
+	owners, err := loadCodeowners()
+	if err != nil {
+		return nil, fmt.Errorf("parse CODEOWNERS file: %w", err)
+	}
+	if _outputFormat != "" && _outputFormat != _outputFormatJSONL && _outputFormat != _outputFormatReviewComments {
+		return nil, fmt.Errorf("unsupported output format %q, expect %q or %q", _outputFormat, _outputFormatJSONL, _outputFormatReviewComments)
+	}
+	if _diffFriendly && _outputFormat != "" {
+		return nil, fmt.Errorf("-diff-friendly cannot be combined with output format %q", _outputFormat)
+	}
+	var reporter *urlReporter
+	if _reportURL != "" {
+		if reporter, err = newURLReporter(_reportURL); err != nil {
+			return nil, err
+		}
+	}
+	var wd string
+	if _outputFormat != "" || _diffFriendly || reporter != nil {
+		if wd, err = os.Getwd(); err != nil {
+			return nil, fmt.Errorf("get working directory: %w", err)
+		}
+	}
+	var metricsOutput *jsonlWriter
+	metrics := packageMetrics{Package: pass.Pkg.Path(), Categories: make(map[string]int)}
+	if _metrics != "" {
+		if metricsOutput, err = openMetricsOutput(); err != nil {
+			return nil, err
+		}
+	}
+	// In the summary mode and the diff-friendly mode, the driver runs in a child process (see
+	// runInChildProcess) that writes the errors to the files given by its parent.
+	var summaryOutput *jsonlWriter
+	var summarized []summaryDiagnostic
+	if _summary && os.Getenv(_summaryFileEnv) != "" {
+		if summaryOutput, err = openSummaryOutput(); err != nil {
+			return nil, err
+		}
+	}
+	var diffFriendlyOutput *jsonlWriter
+	if _diffFriendly {
+		if diffFriendlyOutput, err = openDiffFriendlyOutput(); err != nil {
+			return nil, err
+		}
+	}
 
-	// Override the report function to add error filtering logic.
 	report := pass.Report
+	// In the newline-delimited JSON output formats and the diff-friendly output (or if a report
+	// URL is given), the errors are collected and written out (or posted) as soon as the analysis
+	// of the current package finishes, instead of being reported to the driver (which buffers them
+	// until all packages are analyzed). The diff-friendly errors are written to the file given by
+	// the parent process, which sorts the errors of all packages once the analysis finishes.
+	var collected []jsonlDiagnostic
+	var comments []reviewComment
+	var normalized []diffFriendlyDiagnostic
+	if _outputFormat != "" || _diffFriendly || reporter != nil {
+		report = func(d analysis.Diagnostic) {
+			posn := pass.Fset.Position(d.Pos)
+			collected = append(collected, newJSONLDiagnostic(pass.Pkg.Path(), posn, d.Message, wd))
+			if _outputFormat == _outputFormatReviewComments {
+				comments = append(comments, newReviewComment(posn, d.Message, wd))
+			}
+			if _diffFriendly {
+				normalized = append(normalized, newDiffFriendlyDiagnostic(posn, d.Message, wd))
+			}
+		}
+	}
+
+	// If the inferred annotations are to be applied, their edits are collected (instead of being
+	// reported) and applied to the source files once the analysis of the current package finishes.
+	apply := pass.ResultOf[config.Analyzer].(*config.Config).ApplyInferredAnnotations
+	var edits []analysis.TextEdit
+
+	// Override the report function to add error filtering and labeling logic.
 	pass.Report = func(d analysis.Diagnostic) {
 		p := pass.Fset.File(d.Pos).Name()
 		for _, e := range excludes {
@@ -77,8 +188,28 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			}
 		}
 
+		if owners != nil {
+			if o := owners.Owners(p); len(o) > 0 {
+				d.Message = fmt.Sprintf("[owners: %s] %s", strings.Join(o, " "), d.Message)
+			}
+		}
+
 		for _, i := range includes {
 			if strings.HasPrefix(p, i) {
+				if apply && d.Category == diagnostic.InferredAnnotationCategory {
+					for _, fix := range d.SuggestedFixes {
+						edits = append(edits, fix.TextEdits...)
+					}
+					return
+				}
+				metrics.add(d)
+				if summaryOutput != nil {
+					summarized = append(summarized, summaryDiagnostic{
+						Posn:     pass.Fset.Position(d.Pos).String(),
+						Message:  d.Message,
+						Category: d.Category,
+					})
+				}
 				report(d)
 				return
 			}
@@ -86,7 +217,46 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 
 	// Delegate the real analysis run to the original nilaway analyzer.
-	return nilaway.Analyzer.Run(pass)
+	result, err := nilaway.Analyzer.Run(pass)
+	if err != nil {
+		return nil, err
+	}
+	if len(edits) > 0 {
+		if err := applyEdits(pass.Fset, edits); err != nil {
+			return nil, fmt.Errorf("apply inferred annotations: %w", err)
+		}
+	}
+	if len(collected) > 0 && _outputFormat == _outputFormatJSONL {
+		if err := _jsonlOutput.Write(collected); err != nil {
+			return nil, err
+		}
+	}
+	if len(comments) > 0 {
+		if err := writeLines(_jsonlOutput, comments); err != nil {
+			return nil, err
+		}
+	}
+	if len(normalized) > 0 {
+		if err := writeLines(diffFriendlyOutput, normalized); err != nil {
+			return nil, err
+		}
+	}
+	if len(collected) > 0 && reporter != nil {
+		if err := reporter.Post(collected); err != nil {
+			return nil, err
+		}
+	}
+	if metrics.PanicsPrevented > 0 && metricsOutput != nil {
+		if err := writeLines(metricsOutput, []packageMetrics{metrics}); err != nil {
+			return nil, err
+		}
+	}
+	if len(summarized) > 0 {
+		if err := writeLines(summaryOutput, summarized); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
 // parseFilePrefixes parses the comma-separated list of file prefixes, converts them to absolute
@@ -109,6 +279,15 @@ func parseFilePrefixes(s string) ([]string, error) {
 }
 
 func main() {
+	// The merge subcommand does not run the analysis, but merges the outputs of previous runs.
+	if len(os.Args) > 1 && os.Args[1] == _mergeCommand {
+		if err := merge(os.Stdout, os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to merge: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// For better UX, we lift the flags from config.Analyzer to the top level so that users can
 	// specify them without having to specify the analyzer name ("nilaway_config").
 	// For example, without lifting the flags, we will have to use `multichecker` to run the
@@ -133,5 +312,56 @@ func main() {
 	flag.StringVar(&_includeErrorsInFiles, "include-errors-in-files", wd, "A comma-separated list of file prefixes to report errors, default is current working directory.")
 	flag.StringVar(&_excludeErrorsInFiles, "exclude-errors-in-files", "", "A comma-separated list of file prefixes to exclude from error reporting. This takes precedence over include-errors-in-files.")
 
+	flag.StringVar(&_codeowners, "codeowners", "", "The path to a CODEOWNERS file, if specified, errors will be labeled with the owners of the files they are reported in.")
+	flag.StringVar(&_outputFormat, "output-format", "", "The output format of the errors, if set to \"jsonl\", the errors are streamed to stdout as newline-delimited JSON (one error per line, with the positions relative to the working directory and the messages without colors) as the analysis of each package finishes, and the outputs of multiple runs (e.g., of sharded CI jobs) can be merged into one deduplicated and sorted output with \"nilaway merge <file>...\". If set to \"review-comments\", the errors are streamed the same way, but as objects with \"path\", \"line\" and \"body\" fields that can be posted as inline code review comments (e.g., on GitHub or GitLab). Note that the errors are then not reported to the driver, hence they do not affect the exit code.")
+
+	flag.StringVar(&_reportURL, "report-url", "", "The URL of an endpoint (e.g., of an editor plugin) to post the errors to, either \"http(s)://...\" or \"unix://<socket path>\" for an HTTP server listening on a Unix domain socket. The errors of each package are posted as a JSON array (of the same objects as in the \"jsonl\" output format) as soon as its analysis finishes. Note that the errors are then not reported to the driver, hence they do not affect the exit code.")
+
+	flag.BoolVar(&_diffFriendly, "diff-friendly", false, "Write the errors to stdout in a normalized plain text form for golden-file testing and diffing in CI, one error per line as \"<relative file>:<line>: <message>\", where the paths are relative to the working directory, the columns are omitted, and the messages (including the nil flows) are rendered in a single line without colors. The errors of all packages are buffered, deduplicated and sorted, and written once the analysis finishes, so the output is byte-stable across runs. Cannot be combined with -output-format. Note that the analysis then runs in a child process, and the driver exits with code 3 if any errors are found, the same as without this flag.")
+
+	flag.DurationVar(&_budget.timeout, "total-timeout", 0, "The total timeout of the analysis (excluding package loading), e.g., \"30m\". If exceeded, the remaining packages are not analyzed, the errors found so far are reported along with a note that the analysis is incomplete, and the driver exits with code 1. Default is no timeout.")
+
+	flag.IntVar(&_packageLimit.max, "max-packages", 0, "The maximum number of in-scope packages to analyze, e.g., \"10\". If set, only the first N in-scope packages (sorted by their paths) are analyzed and the rest are skipped, which helps to bisect the package that makes the analysis crash or misbehave. The selected packages are printed to stderr. Default is no limit.")
+
+	flag.BoolVar(&_serve, "serve", false, "Run as a long-lived server (e.g., for editor integration) that loads the packages once and keeps them warm, instead of analyzing them once and exiting. The server reads the requests from stdin as newline-delimited JSON objects of the form {\"changed\": [<file>...]} listing the files changed since the last request (empty for the first one), re-analyzes the affected packages, and writes one line of JSON of the form {\"diagnostics\": [...], \"errors\": [...]} with the updated errors of all packages to stdout per request. Cannot be combined with -output-format, -diff-friendly, -report-url or -summary.")
+
+	flag.StringVar(&_metrics, "metrics", "", "The path to a file that the metrics of the errors are written to as newline-delimited JSON for dashboards, one object per package of the form {\"package\": <path>, \"categories\": {<category>: <count>...}, \"panics_prevented\": <count>}, where the uncategorized errors are counted under \"nilaway\", and \"panics_prevented\" is a rough tally of the potential nil panics flagged (including the similar ones grouped into the errors). The packages without errors are omitted. The metrics can be combined with any output format.")
+
+	flag.BoolVar(&_summary, "summary", false, "Write a footer to stderr once the analysis finishes, with the total number of the errors followed by their counts by severity and category, one per line sorted by severity (\"error\" for the potential nil panics, \"warning\" for the internal errors of NilAway, and \"info\" for the inferred annotations and the nilability reports) and then by category, where the uncategorized errors are counted under \"nilaway\". The duplicate errors (e.g., of the test variants of the packages) are counted once. The footer can be combined with any output format. Note that the analysis then runs in a child process, whose exit code is passed through.")
+
+	flag.Var(buildEnvFlag("GOOS"), "goos", "The target operating system of the build context that the packages are loaded with (e.g., \"windows\"), which selects the files to analyze by their build constraints. Default is the GOOS environment variable (or the host operating system if unset). Run NilAway multiple times with different values to check the platform-specific files of all platforms.")
+
+	flag.Var(buildEnvFlag("GOARCH"), "goarch", "The target architecture of the build context that the packages are loaded with (e.g., \"arm64\"), which selects the files to analyze by their build constraints. Default is the GOARCH environment variable (or the host architecture if unset).")
+
+	// Skip the analyses of the packages beyond the limit, and of the remaining packages once the
+	// total timeout is exceeded.
+	config.Analyzer.Run = _budget.skipIfExhausted(_packageLimit.skipIfBeyondLimit(config.Analyzer.Run))
+
+	// The server mode replaces the singlechecker driver, which analyzes the packages only once.
+	// Note that we can only parse the flags ourselves in the server mode, since singlechecker
+	// registers more flags (e.g., "-json") right before parsing them.
+	if boolFlagEnabled(os.Args[1:], "serve") {
+		// The flag is registered by singlechecker otherwise, which includes the test variants of
+		// the packages by default.
+		tests := flag.Bool("test", true, "indicates whether test files should be analyzed, too")
+		flag.Parse()
+		if _outputFormat != "" || _diffFriendly || _reportURL != "" || _summary {
+			fmt.Fprintln(os.Stderr, "-serve cannot be combined with -output-format, -diff-friendly, -report-url or -summary")
+			os.Exit(1)
+		}
+		if err := serve(os.Stdin, os.Stdout, newServer(&packages.Config{Tests: *tests}, Analyzer, flag.Args())); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to serve: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// The summary footer and the diff-friendly output are written by the parent process once the
+	// analysis in the child process finishes, since singlechecker exits right after printing the
+	// errors.
+	if needsChildProcess(os.Args[1:]) {
+		os.Exit(runInChildProcess(os.Stdout, os.Stderr, os.Args[1:]))
+	}
+
 	singlechecker.Main(Analyzer)
 }
@@ -0,0 +1,134 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// packageLimit limits the analysis to the first N in-scope packages (sorted by their paths), which
+// is a debugging aid for bisecting the package that makes NilAway crash or misbehave on a large
+// code base. Since the driver analyzes the packages in parallel, the order the analyses start in
+// is not deterministic, hence the packages to analyze are instead selected from the full list of
+// packages, which is loaded separately on first use.
+type packageLimit struct {
+	// max is the maximum number of in-scope packages to analyze, where zero means unbounded.
+	max int
+	// load returns the paths of all packages (including dependencies) that the driver analyzes.
+	load func() ([]string, error)
+
+	once    sync.Once
+	allowed map[string]bool
+	err     error
+}
+
+// _packageLimit is the package limit of the driver, whose maximum is set by the driver flag.
+var _packageLimit = &packageLimit{load: loadPackagePaths}
+
+// _limitedConfig is the config given to the sub-analyzers of the packages beyond the limit. Unlike
+// _skippedConfig, the packages are skipped silently since the limit is requested explicitly.
+var _limitedConfig = &config.Config{}
+
+// loadPackagePaths loads the packages matching the patterns given on the command line (and their
+// dependencies, including the test variants if the driver analyzes them) and returns their paths.
+func loadPackagePaths() ([]string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedImports | packages.NeedDeps,
+		Tests: includeTests(),
+	}, flag.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		paths = append(paths, pkg.PkgPath)
+	})
+	return paths, nil
+}
+
+// includeTests returns true if the driver analyzes the test variants of the packages, as set by
+// the "-test" flag that singlechecker registers (true by default).
+func includeTests() bool {
+	f := flag.Lookup("test")
+	if f == nil {
+		return true
+	}
+	tests, ok := f.Value.(flag.Getter).Get().(bool)
+	return !ok || tests
+}
+
+// selectPackages computes the set of the first N in-scope packages, only once since the packages
+// are the same for all analyses.
+func (l *packageLimit) selectPackages(conf *config.Config) (map[string]bool, error) {
+	l.once.Do(func() {
+		paths, err := l.load()
+		if err != nil {
+			l.err = fmt.Errorf("load packages for -max-packages: %w", err)
+			return
+		}
+		var inScope []string
+		for _, path := range paths {
+			if conf.IsPkgInScope(types.NewPackage(path, "")) {
+				inScope = append(inScope, path)
+			}
+		}
+		slices.Sort(inScope)
+		inScope = slices.Compact(inScope)
+		if len(inScope) > l.max {
+			inScope = inScope[:l.max]
+		}
+
+		// Print the selected packages to help with the bisecting.
+		fmt.Fprintf(os.Stderr, "analysis limited to the first %d in-scope package(s): %s\n", l.max, strings.Join(inScope, ", "))
+		l.allowed = make(map[string]bool, len(inScope))
+		for _, path := range inScope {
+			l.allowed[path] = true
+		}
+	})
+	return l.allowed, l.err
+}
+
+// skipIfBeyondLimit wraps the run function of the config analyzer (which all sub-analyzers depend
+// on) such that only the first N in-scope packages are analyzed.
+func (l *packageLimit) skipIfBeyondLimit(run func(*analysis.Pass) (any, error)) func(*analysis.Pass) (any, error) {
+	return func(pass *analysis.Pass) (any, error) {
+		result, err := run(pass)
+		if err != nil || l.max <= 0 {
+			return result, err
+		}
+		conf, ok := result.(*config.Config)
+		if !ok || !conf.IsPkgInScope(pass.Pkg) {
+			return result, nil
+		}
+		allowed, err := l.selectPackages(conf)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed[pass.Pkg.Path()] {
+			return _limitedConfig, nil
+		}
+		return conf, nil
+	}
+}
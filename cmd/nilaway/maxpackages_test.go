@@ -0,0 +1,76 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRun_MaxPackages(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and the config analyzer.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	originalRun := config.Analyzer.Run
+	_includeErrorsInFiles = testdata
+	_packageLimit = &packageLimit{
+		max: 1,
+		// The packages are listed out of order to check that the selection is deterministic.
+		load: func() ([]string, error) { return []string{"maxpackages/b", "maxpackages/a"}, nil },
+	}
+	config.Analyzer.Run = _packageLimit.skipIfBeyondLimit(originalRun)
+	defer func() {
+		_includeErrorsInFiles = ""
+		_packageLimit = &packageLimit{load: loadPackagePaths}
+		config.Analyzer.Run = originalRun
+	}()
+
+	r := &errorRecorder{}
+	results := analysistest.Run(r, testdata, Analyzer, "maxpackages/a", "maxpackages/b")
+	require.Len(t, results, 2)
+
+	// Exactly one package (the first one in sorted order) is analyzed, and the other one is
+	// skipped silently, i.e., without an error.
+	var analyzed []string
+	for _, result := range results {
+		require.NoError(t, result.Err)
+		if len(result.Diagnostics) > 0 {
+			analyzed = append(analyzed, result.Pass.Pkg.Path())
+		}
+	}
+	require.Equal(t, []string{"maxpackages/a"}, analyzed)
+	require.Len(t, r.errors, 1)
+	require.Contains(t, r.errors[0], "no diagnostic was reported")
+}
+
+func TestIncludeTests(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it registers the global flag.
+
+	// The test variants are included by default, even if the flag is not registered.
+	require.True(t, includeTests())
+
+	tests := flag.Bool("test", true, "")
+	require.True(t, includeTests())
+	require.NoError(t, flag.Set("test", "false"))
+	defer func() { *tests = true }()
+	require.False(t, includeTests())
+}
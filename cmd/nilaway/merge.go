@@ -0,0 +1,98 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// _mergeCommand is the subcommand that merges the newline-delimited JSON outputs (see
+// _outputFormatJSONL) of multiple invocations (e.g., of the shards of a CI job, each analyzing a
+// subset of the packages) into a single output, i.e., `nilaway merge <file>...`.
+const _mergeCommand = "merge"
+
+// merge reads the diagnostics from the newline-delimited JSON files and writes them to w as a
+// single newline-delimited JSON output, where the duplicates (i.e., the diagnostics with the same
+// position and message, which can be reported by overlapping shards) are removed and the rest are
+// sorted by their positions. The diagnostics are normalized the same way as they are written
+// (i.e., with the paths relative to the working directory and without the escape sequences of
+// pretty printing) before they are compared, such that the outputs of older versions (or of runs
+// in the same directory with absolute paths) are deduplicated as well.
+func merge(w io.Writer, paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("no files to merge")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+
+	var merged []jsonlDiagnostic
+	seen := make(map[[2]string]bool)
+	for _, path := range paths {
+		diagnostics, err := readLines[jsonlDiagnostic](path)
+		if err != nil {
+			return err
+		}
+		for _, d := range diagnostics {
+			file, line, col := splitPosn(d.Posn)
+			d = newJSONLDiagnostic(d.Package, token.Position{Filename: file, Line: line, Column: col}, d.Message, wd)
+			key := [2]string{d.Posn, d.Message}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, d)
+		}
+	}
+
+	slices.SortStableFunc(merged, func(a, b jsonlDiagnostic) int {
+		if n := comparePosns(a.Posn, b.Posn); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Message, b.Message)
+	})
+	return writeLines(&jsonlWriter{w: w}, merged)
+}
+
+// splitPosn splits the position in the form of "file:line:column" into its parts, such that the
+// lines and columns can be compared numerically. Missing or malformed lines and columns are 0.
+func splitPosn(posn string) (file string, line, col int) {
+	file = posn
+	for _, part := range []*int{&col, &line} {
+		i := strings.LastIndexByte(file, ':')
+		if i < 0 {
+			break
+		}
+		n, err := strconv.Atoi(file[i+1:])
+		if err != nil {
+			break
+		}
+		*part, file = n, file[:i]
+	}
+	// The position only has a line (i.e., "file:line").
+	if line == 0 && col != 0 {
+		line, col = col, 0
+	}
+	return file, line, col
+}
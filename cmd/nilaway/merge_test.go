@@ -0,0 +1,110 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeShard := func(name string, diagnostics []jsonlDiagnostic) string {
+		var buf bytes.Buffer
+		require.NoError(t, (&jsonlWriter{w: &buf}).Write(diagnostics))
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+		return path
+	}
+
+	// The two shards overlap on package "b", where the diagnostics are reported by both.
+	shard1 := writeShard("shard1.jsonl", []jsonlDiagnostic{
+		{Package: "b", Posn: "b/b.go:10:2", Message: "b10"},
+		{Package: "a", Posn: "a/a.go:9:1", Message: "a9"},
+		{Package: "b", Posn: "b/b.go:2:5", Message: "b2"},
+	})
+	shard2 := writeShard("shard2.jsonl", []jsonlDiagnostic{
+		{Package: "c", Posn: "c/c.go:1:1", Message: "c1"},
+		{Package: "b", Posn: "b/b.go:2:5", Message: "b2"},
+		{Package: "b", Posn: "b/b.go:10:2", Message: "b10"},
+		{Package: "a", Posn: "a/a.go:10:1", Message: "a10"},
+		{Package: "b", Posn: "b/b.go:2:5", Message: "b2 other"},
+	})
+	// The third shard has the absolute positions and the pretty-printed messages of the
+	// diagnostics in the other shards, which are duplicates after the normalization.
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	shard3 := writeShard("shard3.jsonl", []jsonlDiagnostic{
+		{Package: "a", Posn: filepath.Join(wd, "a", "a.go") + ":9:1", Message: "\x1b[31ma9\x1b[0m"},
+		{Package: "c", Posn: filepath.Join(wd, "c", "c.go") + ":1:1", Message: "c1"},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, merge(&buf, []string{shard1, shard2, shard3}))
+
+	var posns, messages []string
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		var d jsonlDiagnostic
+		require.NoError(t, json.Unmarshal([]byte(line), &d), "line: %q", line)
+		posns = append(posns, d.Posn)
+		messages = append(messages, d.Message)
+	}
+	// The duplicates are removed, and the lines and columns are compared numerically.
+	require.Equal(t, []string{"a/a.go:9:1", "a/a.go:10:1", "b/b.go:2:5", "b/b.go:2:5", "b/b.go:10:2", "c/c.go:1:1"}, posns)
+	require.Equal(t, []string{"a9", "a10", "b2", "b2 other", "b10", "c1"}, messages)
+}
+
+func TestMerge_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed.jsonl")
+	require.NoError(t, os.WriteFile(malformed, []byte("{\"posn\": \"a.go:1:1\"}\nnot json\n"), 0o600))
+
+	var buf bytes.Buffer
+	require.ErrorContains(t, merge(&buf, nil), "no files to merge")
+	require.ErrorContains(t, merge(&buf, []string{filepath.Join(dir, "missing.jsonl")}), "missing.jsonl")
+	require.ErrorContains(t, merge(&buf, []string{malformed}), "malformed.jsonl")
+}
+
+func TestSplitPosn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		posn string
+		file string
+		line int
+		col  int
+	}{
+		{posn: "a/b.go:12:3", file: "a/b.go", line: 12, col: 3},
+		{posn: "a/b.go:12", file: "a/b.go", line: 12},
+		{posn: "a/b.go", file: "a/b.go"},
+		{posn: "-", file: "-"},
+	}
+	for _, tt := range tests {
+		file, line, col := splitPosn(tt.posn)
+		require.Equal(t, tt.file, file, tt.posn)
+		require.Equal(t, tt.line, line, tt.posn)
+		require.Equal(t, tt.col, col, tt.posn)
+	}
+}
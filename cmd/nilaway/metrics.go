@@ -0,0 +1,70 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// _defaultMetricsCategory is the category that the uncategorized diagnostics are counted under.
+const _defaultMetricsCategory = "nilaway"
+
+// _similarCountRE matches the number of the similar nil panics grouped into a diagnostic.
+var _similarCountRE = regexp.MustCompile(`at (\d+) other place\(s\)`)
+
+// packageMetrics is a single line of the metrics output, which aggregates the diagnostics reported
+// in a package for dashboards. Unlike the diagnostics themselves, the metrics are meant to be
+// tracked over time, e.g., to demonstrate the value of NilAway.
+type packageMetrics struct {
+	Package string `json:"package"`
+	// Categories maps the categories of the diagnostics (see analysis.Diagnostic.Category) to
+	// their counts, where the uncategorized diagnostics are counted under "nilaway".
+	Categories map[string]int `json:"categories"`
+	// PanicsPrevented is a rough tally of the potential nil panics that are flagged, i.e., the
+	// number of the diagnostics plus the numbers of the similar nil panics grouped into them.
+	PanicsPrevented int `json:"panics_prevented"`
+}
+
+// add counts the diagnostic in the metrics.
+func (m *packageMetrics) add(d analysis.Diagnostic) {
+	category := d.Category
+	if category == "" {
+		category = _defaultMetricsCategory
+	}
+	m.Categories[category]++
+
+	m.PanicsPrevented++
+	if match := _similarCountRE.FindStringSubmatch(d.Message); match != nil {
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			m.PanicsPrevented += n
+		}
+	}
+}
+
+// openMetricsOutput creates the metrics file specified by the driver flag only once, since the
+// metrics of all packages are written to the same file.
+var openMetricsOutput = sync.OnceValues(func() (*jsonlWriter, error) {
+	f, err := os.Create(_metrics)
+	if err != nil {
+		return nil, fmt.Errorf("create metrics file: %w", err)
+	}
+	return &jsonlWriter{w: f}, nil
+})
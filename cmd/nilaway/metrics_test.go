@@ -0,0 +1,74 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestPackageMetrics(t *testing.T) {
+	t.Parallel()
+
+	m := packageMetrics{Package: "pkg", Categories: make(map[string]int)}
+	m.add(analysis.Diagnostic{Message: "Potential nil panic detected."})
+	m.add(analysis.Diagnostic{Message: "Potential nil panic detected.\n\n(Same nil source could also " +
+		"cause potential nil panic(s) at 2 other place(s): \"a.go:1:1\", and \"a.go:2:1\".)"})
+	m.add(analysis.Diagnostic{Category: "nilaway/defer-deref", Message: "Potential nil panic detected."})
+
+	require.Equal(t, map[string]int{"nilaway": 2, "nilaway/defer-deref": 1}, m.Categories)
+	require.Equal(t, 5, m.PanicsPrevented)
+}
+
+func TestRun_Metrics(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and output.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	// The errors are written out as newline-delimited JSON such that the testdata needs no "want"
+	// comments, which does not affect the metrics.
+	var buf bytes.Buffer
+	_metrics = filepath.Join(t.TempDir(), "metrics.jsonl")
+	_outputFormat, _includeErrorsInFiles = _outputFormatJSONL, testdata
+	_jsonlOutput = &jsonlWriter{w: &buf}
+	defer func() {
+		_metrics, _outputFormat, _includeErrorsInFiles = "", "", ""
+		_jsonlOutput = &jsonlWriter{w: os.Stdout}
+	}()
+
+	analysistest.Run(t, testdata, Analyzer, "jsonl")
+
+	content, err := os.ReadFile(_metrics)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	require.Len(t, lines, 1)
+
+	var m packageMetrics
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &m))
+	require.Equal(t, packageMetrics{
+		Package:         "jsonl",
+		Categories:      map[string]int{"nilaway": 3},
+		PanicsPrevented: 3,
+	}, m)
+}
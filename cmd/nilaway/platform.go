@@ -0,0 +1,37 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "os"
+
+// buildEnvFlag is a driver flag that overrides an environment variable of the build context (e.g.,
+// "GOOS" or "GOARCH") that the packages are loaded with. Since the packages are loaded via the go
+// command, which inherits the environment of the driver, the variable is set as soon as the flag
+// is parsed (i.e., before the packages are loaded). This allows analyzing the files selected by
+// the build constraints of another platform without cross-compiling NilAway.
+type buildEnvFlag string
+
+// String returns the current value of the environment variable.
+func (f buildEnvFlag) String() string {
+	if f == "" {
+		return ""
+	}
+	return os.Getenv(string(f))
+}
+
+// Set sets the environment variable to the value.
+func (f buildEnvFlag) Set(value string) error {
+	return os.Setenv(string(f), value)
+}
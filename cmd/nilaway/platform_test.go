@@ -0,0 +1,63 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestBuildEnvFlag(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the environment.
+	t.Setenv("GOOS", "linux")
+
+	f := buildEnvFlag("GOOS")
+	require.Equal(t, "linux", f.String())
+	require.NoError(t, f.Set("windows"))
+	require.Equal(t, "windows", f.String())
+
+	// The zero value is used by the flag package to check for default values.
+	require.Empty(t, buildEnvFlag("").String())
+}
+
+func TestRun_GOOS(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and the environment.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	_includeErrorsInFiles = testdata
+	defer func() { _includeErrorsInFiles = "" }()
+
+	// Each platform selects a different file of the package, and only the errors in the
+	// selected file are expected to be reported.
+	for _, goos := range []string{"linux", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			t.Setenv("GOOS", "")
+			require.NoError(t, buildEnvFlag("GOOS").Set(goos))
+
+			results := analysistest.Run(t, testdata, Analyzer, "platform")
+			require.Len(t, results, 1)
+			var files []string
+			for _, f := range results[0].Pass.Files {
+				files = append(files, filepath.Base(results[0].Pass.Fset.File(f.Pos()).Name()))
+			}
+			require.ElementsMatch(t, []string{"platform.go", "platform_" + goos + ".go"}, files)
+		})
+	}
+}
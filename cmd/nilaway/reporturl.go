@@ -0,0 +1,89 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// _reportTimeout is the timeout for posting the diagnostics of a single package to the endpoint.
+const _reportTimeout = 10 * time.Second
+
+// urlReporter posts the diagnostics as JSON to an HTTP endpoint, e.g., a local server of an editor
+// plugin that renders the diagnostics live. It is safe for concurrent use since http.Client is.
+type urlReporter struct {
+	url    string
+	client *http.Client
+}
+
+// newURLReporter returns a reporter for the given URL, which is either an HTTP(S) URL, or a
+// "unix://<socket path>" URL for posting to an HTTP server listening on a Unix domain socket.
+func newURLReporter(rawURL string) (*urlReporter, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse report URL %q: %w", rawURL, err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return &urlReporter{url: rawURL, client: &http.Client{Timeout: _reportTimeout}}, nil
+	case "unix":
+		socket := u.Path
+		if u.Host != "" {
+			// Relative socket paths, e.g., "unix://nilaway.sock".
+			socket = u.Host + u.Path
+		}
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		// The host is irrelevant since the connections are always made to the socket.
+		return &urlReporter{url: "http://unix/", client: &http.Client{Transport: transport, Timeout: _reportTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q in report URL %q, expect \"http\", \"https\", or \"unix\"", u.Scheme, rawURL)
+	}
+}
+
+// Post posts the diagnostics (of a single package) as a JSON array in a single request. The
+// diagnostics are in the same plain form as the ones of the jsonl output (see newJSONLDiagnostic).
+func (r *urlReporter) Post(diagnostics []jsonlDiagnostic) error {
+	body, err := json.Marshal(diagnostics)
+	if err != nil {
+		return fmt.Errorf("marshal diagnostics: %w", err)
+	}
+
+	resp, err := r.client.Post(r.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("post diagnostics: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain the body such that the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post diagnostics: unexpected status %q", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// diagnosticsServer is an HTTP handler that records the diagnostics posted to it.
+type diagnosticsServer struct {
+	mu          sync.Mutex
+	diagnostics []jsonlDiagnostic
+}
+
+func (s *diagnosticsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var diagnostics []jsonlDiagnostic
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&diagnostics); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.diagnostics = append(s.diagnostics, diagnostics...)
+}
+
+func TestNewURLReporter(t *testing.T) {
+	t.Parallel()
+
+	for _, u := range []string{"http://localhost:8080/diagnostics", "https://example.com", "unix:///tmp/nilaway.sock", "unix://nilaway.sock"} {
+		r, err := newURLReporter(u)
+		require.NoError(t, err, "url: %s", u)
+		require.NotNil(t, r)
+	}
+	for _, u := range []string{"ftp://example.com", "localhost:8080", "://"} {
+		_, err := newURLReporter(u)
+		require.Error(t, err, "url: %s", u)
+	}
+}
+
+func TestURLReporter_UnixSocket(t *testing.T) {
+	t.Parallel()
+
+	socket := filepath.Join(t.TempDir(), "nilaway.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	handler := &diagnosticsServer{}
+	server := &http.Server{Handler: handler}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	r, err := newURLReporter("unix://" + socket)
+	require.NoError(t, err)
+	diagnostics := []jsonlDiagnostic{{Package: "pkg", Posn: "a.go:1:1", Message: "message"}}
+	require.NoError(t, r.Post(diagnostics))
+	require.Equal(t, diagnostics, handler.diagnostics)
+}
+
+func TestURLReporter_ErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r, err := newURLReporter(server.URL)
+	require.NoError(t, err)
+	require.ErrorContains(t, r.Post([]jsonlDiagnostic{{Package: "pkg"}}), "unexpected status")
+}
+
+func TestRun_ReportURL(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	handler := &diagnosticsServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	_reportURL, _includeErrorsInFiles = server.URL, testdata
+	defer func() {
+		_reportURL, _includeErrorsInFiles = "", ""
+	}()
+
+	// analysistest checks that no errors are reported to the driver.
+	analysistest.Run(t, testdata, Analyzer, "jsonl")
+
+	require.Len(t, handler.diagnostics, 3)
+	for _, d := range handler.diagnostics {
+		require.Equal(t, "jsonl", d.Package)
+		// The posted errors are in the same plain form as the jsonl output (see newJSONLDiagnostic),
+		// such that the editor plugins do not have to strip the escape sequences of the colors.
+		require.True(t, strings.HasPrefix(d.Posn, "testdata/src/jsonl/jsonl.go:"), "posn: %q", d.Posn)
+		require.Contains(t, d.Message, "Potential nil panic detected")
+		require.NotContains(t, d.Message, "\x1b")
+	}
+}
@@ -0,0 +1,72 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// _outputFormatReviewComments is the output format that writes the diagnostics as newline-delimited
+// JSON objects in the shape of the inline (line) comments of the code review APIs of GitHub and
+// GitLab, such that they can be posted by a bot as-is.
+const _outputFormatReviewComments = "review-comments"
+
+// reviewComment is a single line of the review comments output.
+type reviewComment struct {
+	// Path is the path of the file relative to the working directory (i.e., usually the root of
+	// the repository), or the absolute path if the file is outside of it.
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	// Body is the Markdown body of the comment, with the primary message followed by a summary of
+	// the nil flow.
+	Body string `json:"body"`
+}
+
+// newReviewComment converts the diagnostic at the position to a review comment, where the paths
+// (in the position and the message) are made relative to the base directory, and the escape
+// sequences of pretty printing are removed from the message.
+func newReviewComment(posn token.Position, message, base string) reviewComment {
+	message = _ansiEscapeRE.ReplaceAllString(message, "")
+	// The message consists of a headline followed by the nil flow, one step (already in the form
+	// of "- <position>: <reason>") per line. We render the headline in bold and the steps as a
+	// Markdown list.
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	var body strings.Builder
+	body.WriteString("**" + strings.TrimSpace(lines[0]) + "**\n")
+	for _, l := range lines[1:] {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		if !strings.HasPrefix(l, "- ") {
+			// Separate the trailing notes (e.g., of similar nil panics) from the list.
+			body.WriteString("\n")
+		}
+		body.WriteString("\n" + strings.ReplaceAll(l, base+string(filepath.Separator), ""))
+	}
+
+	return reviewComment{Path: relativePath(posn.Filename, base), Line: posn.Line, Body: body.String()}
+}
+
+// relativePath returns the path relative to the base directory (with forward slashes), or the path
+// itself if it is outside of the base directory.
+func relativePath(path, base string) string {
+	if rel, err := filepath.Rel(base, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,98 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNewReviewComment(t *testing.T) {
+	t.Parallel()
+
+	base := filepath.Join(string(filepath.Separator), "repo")
+	file := filepath.Join(base, "pkg", "a.go")
+	message := "Potential nil panic detected. Observed nil flow from source to dereference point: \n" +
+		"\t- " + file + ":3:9: literal `nil` returned from `bar()` in position 0\n" +
+		"\t- " + file + ":7:9: result 0 of `bar()` dereferenced\n" +
+		"\n(Same nil source could also cause potential nil panic(s) at 1 other place(s): \"" + file + ":8:9\".)\n"
+
+	c := newReviewComment(token.Position{Filename: file, Line: 7, Column: 9}, message, base)
+	require.Equal(t, "pkg/a.go", c.Path)
+	require.Equal(t, 7, c.Line)
+	require.Equal(t, "**Potential nil panic detected. Observed nil flow from source to dereference point:**\n"+
+		"\n- pkg/a.go:3:9: literal `nil` returned from `bar()` in position 0"+
+		"\n- pkg/a.go:7:9: result 0 of `bar()` dereferenced"+
+		"\n\n(Same nil source could also cause potential nil panic(s) at 1 other place(s): \"pkg/a.go:8:9\".)", c.Body)
+
+	// Files outside of the base directory keep their absolute paths.
+	outside := filepath.Join(string(filepath.Separator), "other", "b.go")
+	c = newReviewComment(token.Position{Filename: outside, Line: 1}, "message", base)
+	require.Equal(t, outside, c.Path)
+	require.Equal(t, "**message**\n", c.Body)
+
+	// The escape sequences of pretty printing are removed.
+	c = newReviewComment(token.Position{Filename: file, Line: 1}, "\x1b[31merror: \x1b[0mmessage", base)
+	require.Equal(t, "**error: message**\n", c.Body)
+}
+
+func TestRun_ReviewComments(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and output.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_outputFormat, _includeErrorsInFiles = _outputFormatReviewComments, testdata
+	_jsonlOutput = &jsonlWriter{w: &buf}
+	defer func() {
+		_outputFormat, _includeErrorsInFiles = "", ""
+		_jsonlOutput = &jsonlWriter{w: os.Stdout}
+	}()
+
+	// analysistest checks that no errors are reported to the driver.
+	analysistest.Run(t, testdata, Analyzer, "jsonl")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		// Check the presence of exactly the fields expected by the code review APIs.
+		var fields map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal([]byte(line), &fields), "line: %q", line)
+		require.Len(t, fields, 3)
+		require.Contains(t, fields, "path")
+		require.Contains(t, fields, "line")
+		require.Contains(t, fields, "body")
+
+		var c reviewComment
+		require.NoError(t, json.Unmarshal([]byte(line), &c))
+		require.Equal(t, "testdata/src/jsonl/jsonl.go", c.Path)
+		require.Positive(t, c.Line)
+		// The messages are pretty-printed by default, but the bodies must not contain the escape
+		// sequences of the colors.
+		require.NotContains(t, c.Body, "\x1b")
+		require.True(t, strings.HasPrefix(c.Body, "**error: Potential nil panic detected."), "body: %q", c.Body)
+		// The positions in the nil flow are truncated (see config.DirLevelsToPrintForTriggers).
+		require.Contains(t, c.Body, "\n- jsonl/jsonl.go:")
+	}
+}
@@ -0,0 +1,460 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+// _serveLoadMode is the mode for loading the packages in the server, where the dependencies are
+// also loaded from source since the analysis relies on the facts of the dependencies.
+const _serveLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedTypesSizes |
+	packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedModule
+
+// serveRequest is a single line of the requests read by the server, asking it to re-analyze the
+// packages and respond with the updated diagnostics.
+type serveRequest struct {
+	// Changed is the list of the files that have changed since the last request. The packages
+	// containing them (and the packages depending on those) are re-analyzed, while the results of
+	// the other packages are reused. The packages are reloaded entirely if any of the files does
+	// not belong to the loaded packages (e.g., a new file).
+	Changed []string `json:"changed"`
+}
+
+// serveResponse is a single line of the responses written by the server, one per request.
+type serveResponse struct {
+	// Diagnostics is the complete list of the diagnostics of the requested packages, sorted by
+	// their positions.
+	Diagnostics []jsonlDiagnostic `json:"diagnostics"`
+	// Errors is the list of the errors (e.g., of loading or type checking) that prevented (some
+	// of) the packages from being analyzed.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// server keeps the packages loaded (and their analysis results cached) across requests, such that
+// repeated analyses (e.g., from an editor on every save) do not pay the cost of cold starts.
+type server struct {
+	cfg      *packages.Config
+	patterns []string
+	analyzer *analysis.Analyzer
+	// factAnalyzers is the list of the analyzers (required by the top-level analyzer) that
+	// export facts, which are the only ones run on the dependencies.
+	factAnalyzers []*analysis.Analyzer
+	// packages is the list of all loaded packages (including the dependencies) in dependency
+	// order, i.e., every package comes after the packages it imports.
+	packages []*servePackage
+	// files maps the absolute file names to the packages they belong to, where a file may belong
+	// to multiple packages (e.g., a package and its test variant).
+	files map[string][]*servePackage
+}
+
+// servePackage is a loaded package along with the cached results of analyzing it.
+type servePackage struct {
+	*packages.Package
+	// isRoot indicates whether the package matches the requested patterns, i.e., its diagnostics
+	// are reported (as opposed to a dependency, which is only analyzed for the facts).
+	isRoot bool
+	// deps is the list of the packages that this package directly imports.
+	deps []*servePackage
+	// changed indicates whether the files of the package have changed since it was type checked.
+	changed bool
+	// analyzed indicates whether the cached results below are up-to-date.
+	analyzed     bool
+	diagnostics  []jsonlDiagnostic
+	err          error
+	objectFacts  map[objectFactKey]analysis.Fact
+	packageFacts map[packageFactKey]analysis.Fact
+}
+
+type objectFactKey struct {
+	obj types.Object
+	typ reflect.Type
+}
+
+type packageFactKey struct {
+	pkg *types.Package
+	typ reflect.Type
+}
+
+// newServer returns a server that analyzes the packages matching the patterns with the analyzer,
+// where the packages are loaded with the given base configuration (e.g., whether to include the
+// test variants of the packages).
+func newServer(cfg *packages.Config, analyzer *analysis.Analyzer, patterns []string) *server {
+	cfg.Mode = _serveLoadMode
+
+	var factAnalyzers []*analysis.Analyzer
+	seen := make(map[*analysis.Analyzer]bool)
+	var visit func(a *analysis.Analyzer)
+	visit = func(a *analysis.Analyzer) {
+		if seen[a] {
+			return
+		}
+		seen[a] = true
+		for _, req := range a.Requires {
+			visit(req)
+		}
+		if len(a.FactTypes) > 0 {
+			factAnalyzers = append(factAnalyzers, a)
+		}
+	}
+	visit(analyzer)
+
+	return &server{cfg: cfg, patterns: patterns, analyzer: analyzer, factAnalyzers: factAnalyzers}
+}
+
+// serve reads the requests (one JSON object per line) from the reader until EOF, and writes the
+// responses (one JSON object per line) to the writer.
+func serve(r io.Reader, w io.Writer, s *server) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req serveRequest
+		resp := serveResponse{Diagnostics: []jsonlDiagnostic{}}
+		if err := json.Unmarshal(line, &req); err != nil {
+			resp.Errors = []string{fmt.Sprintf("decode request: %v", err)}
+		} else {
+			resp = s.handle(req)
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return fmt.Errorf("write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+// handle brings the packages and their analysis results up to date with the changed files of the
+// request, and returns the diagnostics of the requested packages.
+func (s *server) handle(req serveRequest) serveResponse {
+	resp := serveResponse{Diagnostics: []jsonlDiagnostic{}}
+
+	reload := s.packages == nil
+	for _, name := range req.Changed {
+		abs, err := filepath.Abs(name)
+		if err != nil {
+			resp.Errors = append(resp.Errors, fmt.Sprintf("resolve changed file %q: %v", name, err))
+			continue
+		}
+		pkgs, ok := s.files[abs]
+		if !ok {
+			reload = true
+			break
+		}
+		for _, p := range pkgs {
+			p.changed = true
+		}
+	}
+	if reload {
+		if err := s.load(); err != nil {
+			resp.Errors = append(resp.Errors, err.Error())
+			return resp
+		}
+	}
+
+	checked := make(map[*servePackage]bool)
+	for _, p := range s.packages {
+		// The packages depending on a changed package must be type checked again as well, since
+		// the types they refer to are replaced.
+		if p.changed || slices.ContainsFunc(p.deps, func(d *servePackage) bool { return checked[d] }) {
+			s.check(p)
+			checked[p] = true
+			p.changed, p.analyzed = false, false
+		}
+		if !p.analyzed {
+			s.analyze(p)
+			p.analyzed = true
+		}
+		if p.err != nil {
+			resp.Errors = append(resp.Errors, p.err.Error())
+		}
+		if p.isRoot {
+			resp.Diagnostics = append(resp.Diagnostics, p.diagnostics...)
+		}
+	}
+
+	// The diagnostics of the files shared by a package and its test variant are reported once,
+	// as the singlechecker driver does.
+	slices.SortFunc(resp.Diagnostics, func(a, b jsonlDiagnostic) int {
+		if n := comparePosns(a.Posn, b.Posn); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Message, b.Message)
+	})
+	resp.Diagnostics = slices.CompactFunc(resp.Diagnostics, func(a, b jsonlDiagnostic) bool {
+		return a.Posn == b.Posn && a.Message == b.Message
+	})
+	return resp
+}
+
+// load (re)loads all packages matching the patterns and their dependencies, and discards all
+// cached results. The packages are loaded into a fresh file set, such that the files parsed for
+// the discarded packages are released.
+func (s *server) load() error {
+	s.packages, s.files = nil, nil
+	s.cfg.Fset = token.NewFileSet()
+	roots, err := packages.Load(s.cfg, s.patterns...)
+	if err != nil {
+		return fmt.Errorf("load packages: %w", err)
+	}
+
+	loaded := make(map[*packages.Package]*servePackage)
+	var ordered []*servePackage
+	packages.Visit(roots, nil, func(pkg *packages.Package) {
+		p := &servePackage{Package: pkg}
+		for _, imported := range pkg.Imports {
+			p.deps = append(p.deps, loaded[imported])
+		}
+		loaded[pkg] = p
+		ordered = append(ordered, p)
+	})
+	for _, root := range roots {
+		loaded[root].isRoot = true
+	}
+
+	files := make(map[string][]*servePackage)
+	for _, p := range ordered {
+		for _, name := range p.CompiledGoFiles {
+			files[name] = append(files[name], p)
+		}
+	}
+	s.packages, s.files = ordered, files
+	return nil
+}
+
+// check parses the files of the package again and type checks them against the (possibly also
+// type checked again) imported packages. Note that the files are added to the file set of the
+// last load, which hence keeps growing with every change until the packages are reloaded (e.g.,
+// when a file is added).
+func (s *server) check(p *servePackage) {
+	// Only the errors of listing the package (e.g., of missing imports) are kept, while the ones
+	// of parsing and type checking are found again.
+	p.Errors = slices.DeleteFunc(p.Errors, func(e packages.Error) bool { return e.Kind != packages.ListError })
+	p.Syntax, p.TypeErrors = nil, nil
+	for _, name := range p.CompiledGoFiles {
+		f, err := parser.ParseFile(s.cfg.Fset, name, nil, parser.AllErrors|parser.ParseComments)
+		if err != nil {
+			p.Errors = append(p.Errors, packages.Error{Msg: err.Error(), Kind: packages.ParseError})
+		}
+		if f != nil {
+			p.Syntax = append(p.Syntax, f)
+		}
+	}
+
+	p.TypesInfo = &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Instances:  make(map[*ast.Ident]types.Instance),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path == "unsafe" {
+				return types.Unsafe, nil
+			}
+			imported, ok := p.Imports[path]
+			if !ok {
+				return nil, fmt.Errorf("no metadata for %q", path)
+			}
+			return imported.Types, nil
+		}),
+		Sizes: p.TypesSizes,
+		Error: func(err error) {
+			if terr, ok := err.(types.Error); ok {
+				p.TypeErrors = append(p.TypeErrors, terr)
+				p.Errors = append(p.Errors, packages.Error{
+					Pos:  terr.Fset.Position(terr.Pos).String(),
+					Msg:  terr.Msg,
+					Kind: packages.TypeError,
+				})
+			}
+		},
+	}
+	if p.Module != nil && p.Module.GoVersion != "" {
+		conf.GoVersion = "go" + p.Module.GoVersion
+	}
+	// The errors are collected by the error handler above.
+	p.Types, _ = conf.Check(p.PkgPath, s.cfg.Fset, p.Syntax, p.TypesInfo)
+}
+
+// analyze runs the analyzer on the package (or only the analyzers exporting facts if the package
+// is a dependency), and caches the diagnostics and the facts for the packages depending on it.
+func (s *server) analyze(p *servePackage) {
+	p.diagnostics, p.err = nil, nil
+
+	// The facts of all (transitive) dependencies are visible to the analyses of the package.
+	p.objectFacts = make(map[objectFactKey]analysis.Fact)
+	p.packageFacts = make(map[packageFactKey]analysis.Fact)
+	for _, d := range p.deps {
+		for k, v := range d.objectFacts {
+			p.objectFacts[k] = v
+		}
+		for k, v := range d.packageFacts {
+			p.packageFacts[k] = v
+		}
+	}
+
+	if len(p.Errors) > 0 {
+		errs := make([]error, len(p.Errors))
+		for i, e := range p.Errors {
+			errs[i] = e
+		}
+		err := errors.Join(errs...)
+		p.err = fmt.Errorf("analyze %s: %w", p.PkgPath, err)
+		return
+	}
+
+	analyzers := s.factAnalyzers
+	if p.isRoot {
+		analyzers = []*analysis.Analyzer{s.analyzer}
+	}
+	results := make(map[*analysis.Analyzer]any)
+	var exec func(a *analysis.Analyzer) error
+	exec = func(a *analysis.Analyzer) (err error) {
+		if _, ok := results[a]; ok {
+			return nil
+		}
+		inputs := make(map[*analysis.Analyzer]any, len(a.Requires))
+		for _, req := range a.Requires {
+			if err := exec(req); err != nil {
+				return err
+			}
+			inputs[req] = results[req]
+		}
+
+		// A long-lived server must survive the crashes of single analyses, unless the internal
+		// errors are strict, where the panic is propagated with its stack trace for bug reports.
+		defer func() {
+			if r := recover(); r != nil {
+				if strictInternalErrors() {
+					panic(r)
+				}
+				err = fmt.Errorf("%s panicked: %v", a.Name, r)
+			}
+		}()
+		result, err := a.Run(s.newPass(p, a, inputs))
+		if err != nil {
+			return fmt.Errorf("%s: %w", a.Name, err)
+		}
+		results[a] = result
+		return nil
+	}
+	for _, a := range analyzers {
+		if err := exec(a); err != nil {
+			p.err = fmt.Errorf("analyze %s: %w", p.PkgPath, err)
+			return
+		}
+	}
+}
+
+// newPass returns the pass for running the analyzer on the package, where the diagnostics
+// reported by the top-level analyzer are collected into the package.
+func (s *server) newPass(p *servePackage, a *analysis.Analyzer, inputs map[*analysis.Analyzer]any) *analysis.Pass {
+	return &analysis.Pass{
+		Analyzer:     a,
+		Fset:         s.cfg.Fset,
+		Files:        p.Syntax,
+		OtherFiles:   p.OtherFiles,
+		IgnoredFiles: p.IgnoredFiles,
+		Pkg:          p.Types,
+		TypesInfo:    p.TypesInfo,
+		TypesSizes:   p.TypesSizes,
+		TypeErrors:   p.TypeErrors,
+		ResultOf:     inputs,
+		ReadFile:     os.ReadFile,
+		Report: func(d analysis.Diagnostic) {
+			if a != s.analyzer {
+				return
+			}
+			p.diagnostics = append(p.diagnostics, jsonlDiagnostic{
+				Package: p.PkgPath,
+				Posn:    s.cfg.Fset.Position(d.Pos).String(),
+				Message: d.Message,
+			})
+		},
+		ImportObjectFact: func(obj types.Object, fact analysis.Fact) bool {
+			f, ok := p.objectFacts[objectFactKey{obj, reflect.TypeOf(fact)}]
+			if ok {
+				reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(f).Elem())
+			}
+			return ok
+		},
+		ImportPackageFact: func(pkg *types.Package, fact analysis.Fact) bool {
+			f, ok := p.packageFacts[packageFactKey{pkg, reflect.TypeOf(fact)}]
+			if ok {
+				reflect.ValueOf(fact).Elem().Set(reflect.ValueOf(f).Elem())
+			}
+			return ok
+		},
+		ExportObjectFact: func(obj types.Object, fact analysis.Fact) {
+			p.objectFacts[objectFactKey{obj, reflect.TypeOf(fact)}] = fact
+		},
+		ExportPackageFact: func(fact analysis.Fact) {
+			p.packageFacts[packageFactKey{p.Types, reflect.TypeOf(fact)}] = fact
+		},
+		AllObjectFacts: func() []analysis.ObjectFact {
+			facts := make([]analysis.ObjectFact, 0, len(p.objectFacts))
+			for k, f := range p.objectFacts {
+				facts = append(facts, analysis.ObjectFact{Object: k.obj, Fact: f})
+			}
+			return facts
+		},
+		AllPackageFacts: func() []analysis.PackageFact {
+			facts := make([]analysis.PackageFact, 0, len(p.packageFacts))
+			for k, f := range p.packageFacts {
+				facts = append(facts, analysis.PackageFact{Package: k.pkg, Fact: f})
+			}
+			return facts
+		},
+	}
+}
+
+// strictInternalErrors returns true if the internal errors of NilAway are strict as set by the
+// flag of the config analyzer (which the driver lifts to the top level).
+func strictInternalErrors() bool {
+	strict, _ := config.Analyzer.Flags.Lookup(config.StrictInternalErrorsFlag).Value.(flag.Getter).Get().(bool)
+	return strict
+}
+
+// importerFunc implements types.Importer with a function.
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
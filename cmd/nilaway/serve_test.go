@@ -0,0 +1,167 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestServe(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// and config flags.
+
+	gopath := copyServePackages(t)
+	depFile := filepath.Join(gopath, "src", "serve", "dep", "dep.go")
+	original := readFile(t, depFile)
+
+	_includeErrorsInFiles = gopath
+	defer func() { _includeErrorsInFiles = "" }()
+	// The errors are not grouped, such that the test variant of the package reports the same
+	// error in the shared file as the package.
+	require.NoError(t, config.Analyzer.Flags.Set(config.GroupErrorMessagesFlag, "false"))
+	defer func() { require.NoError(t, config.Analyzer.Flags.Set(config.GroupErrorMessagesFlag, "true")) }()
+
+	s := newServer(newServeConfig(gopath, true), Analyzer, []string{"serve"})
+
+	// The first two requests must give the same results, where the second one is answered by the
+	// warm server without re-analyzing any package.
+	var out bytes.Buffer
+	require.NoError(t, serve(strings.NewReader("{}\n{\"changed\": []}\n"), &out, s))
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, lines[0], lines[1])
+
+	// The dereference in the test file is reported along with the test variant of the package,
+	// while the one shared by the package and its test variant is reported once.
+	resp := decodeServeResponse(t, lines[0])
+	require.Empty(t, resp.Errors)
+	require.Len(t, resp.Diagnostics, 2)
+	for i, name := range []string{"serve.go", "serve_test.go"} {
+		require.True(t, strings.HasPrefix(resp.Diagnostics[i].Posn, filepath.Join(gopath, "src", "serve", name)+":"))
+		require.Contains(t, resp.Diagnostics[i].Message, "Potential nil panic detected.")
+	}
+
+	// After the dependency is fixed, the depending packages are re-analyzed with the updated facts.
+	fixed := strings.Replace(original, "return nil", "return new(int)", 1)
+	require.NoError(t, os.WriteFile(depFile, []byte(fixed), 0o644))
+	out.Reset()
+	require.NoError(t, serve(strings.NewReader(`{"changed": ["`+depFile+`"]}`), &out, s))
+	require.JSONEq(t, `{"diagnostics": []}`, out.String())
+
+	// After the dependency is broken again, the stale facts of the fixed dependency must not be
+	// reused, i.e., the same errors as in the first request are reported.
+	require.NoError(t, os.WriteFile(depFile, []byte(original), 0o644))
+	out.Reset()
+	require.NoError(t, serve(strings.NewReader(`{"changed": ["`+depFile+`"]}`), &out, s))
+	require.JSONEq(t, lines[0], out.String())
+
+	// Malformed requests are answered with errors without stopping the server.
+	out.Reset()
+	require.NoError(t, serve(strings.NewReader("not json\n{}\n"), &out, s))
+	lines = strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], "decode request")
+	require.Len(t, decodeServeResponse(t, lines[1]).Diagnostics, 2)
+}
+
+func TestServe_NoTests(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags.
+
+	gopath := copyServePackages(t)
+
+	_includeErrorsInFiles = gopath
+	defer func() { _includeErrorsInFiles = "" }()
+
+	// Without the test variants, the test file is not analyzed.
+	s := newServer(newServeConfig(gopath, false), Analyzer, []string{"serve"})
+	var out bytes.Buffer
+	require.NoError(t, serve(strings.NewReader("{}\n"), &out, s))
+	resp := decodeServeResponse(t, out.String())
+	require.Empty(t, resp.Errors)
+	require.Len(t, resp.Diagnostics, 1)
+	require.True(t, strings.HasPrefix(resp.Diagnostics[0].Posn, filepath.Join(gopath, "src", "serve", "serve.go")+":"))
+}
+
+func TestServe_Panic(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global config
+	// flags.
+
+	gopath := copyServePackages(t)
+	panicking := &analysis.Analyzer{
+		Name: "panicking",
+		Doc:  "panics on every package",
+		Run:  func(*analysis.Pass) (any, error) { panic("boom") },
+	}
+
+	// The panics are returned as errors by default.
+	s := newServer(newServeConfig(gopath, false), panicking, []string{"serve"})
+	var out bytes.Buffer
+	require.NoError(t, serve(strings.NewReader("{}\n"), &out, s))
+	resp := decodeServeResponse(t, out.String())
+	require.Len(t, resp.Errors, 1)
+	require.Contains(t, resp.Errors[0], "panicking panicked: boom")
+
+	// The panics are propagated if the internal errors are strict.
+	require.NoError(t, config.Analyzer.Flags.Set(config.StrictInternalErrorsFlag, "true"))
+	defer func() { require.NoError(t, config.Analyzer.Flags.Set(config.StrictInternalErrorsFlag, "false")) }()
+	s = newServer(newServeConfig(gopath, false), panicking, []string{"serve"})
+	require.PanicsWithValue(t, "boom", func() { _ = serve(strings.NewReader("{}\n"), &out, s) })
+}
+
+// copyServePackages copies the test packages to a temporary GOPATH (since the tests modify them)
+// and returns the GOPATH.
+func copyServePackages(t *testing.T) string {
+	gopath := t.TempDir()
+	for _, name := range []string{"serve.go", "serve_test.go", filepath.Join("dep", "dep.go")} {
+		content, err := os.ReadFile(filepath.Join("testdata", "src", "serve", name))
+		require.NoError(t, err)
+		path := filepath.Join(gopath, "src", "serve", name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+	}
+	return gopath
+}
+
+// newServeConfig returns the base configuration for loading the test packages from the GOPATH.
+func newServeConfig(gopath string, tests bool) *packages.Config {
+	return &packages.Config{
+		Dir:   filepath.Join(gopath, "src"),
+		Env:   append(os.Environ(), "GOPATH="+gopath, "GO111MODULE=off", "GOPROXY=off"),
+		Tests: tests,
+	}
+}
+
+func decodeServeResponse(t *testing.T, line string) serveResponse {
+	var resp serveResponse
+	require.NoError(t, json.Unmarshal([]byte(line), &resp))
+	return resp
+}
+
+func readFile(t *testing.T, name string) string {
+	content, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return string(content)
+}
@@ -0,0 +1,118 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"text/tabwriter"
+
+	"go.uber.org/nilaway"
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/diagnostic"
+)
+
+// _summaryFileEnv is the environment variable that the driver sets for its child process in the
+// summary mode (see runInChildProcess), naming the file that the child writes the errors to.
+const _summaryFileEnv = "NILAWAY_SUMMARY_FILE"
+
+// _severityOrder lists the severities in the order they are listed in the summary footer.
+var _severityOrder = []string{"error", "warning", "info"}
+
+// summaryDiagnostic is a single line of the file that the child process writes the errors to in
+// the summary mode, which carries just enough to deduplicate and count the errors.
+type summaryDiagnostic struct {
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+	Category string `json:"category,omitempty"`
+}
+
+// summaryKey is a single row of the summary footer.
+type summaryKey struct {
+	Severity string
+	Category string
+}
+
+// severity returns the severity of the errors of the category. The potential nil panics (in any
+// category) are errors, while the internal errors of NilAway and the notes on incomplete inference
+// are warnings since they concern the analysis rather than the code, and the inferred annotations
+// and the nilability reports are merely informational.
+func severity(category string) string {
+	switch category {
+	case accumulation.InternalErrorCategory, accumulation.IncompleteInferenceCategory:
+		return "warning"
+	case diagnostic.InferredAnnotationCategory, diagnostic.NilabilityReportCategory:
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// openSummaryOutput opens the file named by _summaryFileEnv only once, since the errors of all
+// packages are written to the same file.
+var openSummaryOutput = sync.OnceValues(func() (*jsonlWriter, error) {
+	f, err := os.OpenFile(os.Getenv(_summaryFileEnv), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open summary file: %w", err)
+	}
+	return &jsonlWriter{w: f}, nil
+})
+
+// writeSummary writes the summary footer of the errors to the writer, i.e., the total number of
+// the errors followed by their counts by severity and category. The duplicates (i.e., the errors
+// with the same position and message, which are reported again by the test variants of the
+// packages) are counted once, the same as the driver prints them once. The rows are sorted by
+// their severities and categories such that the footer is stable across runs.
+func writeSummary(w io.Writer, diagnostics []summaryDiagnostic) error {
+	counts := make(map[summaryKey]int)
+	seen := make(map[[2]string]bool)
+	for _, d := range diagnostics {
+		key := [2]string{d.Posn, d.Message}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		category := d.Category
+		if category == "" {
+			category = _defaultMetricsCategory
+		}
+		counts[summaryKey{Severity: severity(category), Category: category}]++
+	}
+
+	keys := make([]summaryKey, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b summaryKey) int {
+		if n := cmp.Compare(slices.Index(_severityOrder, a.Severity), slices.Index(_severityOrder, b.Severity)); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Category, b.Category)
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s: %d error(s) in total\n", nilaway.Analyzer.Name, len(seen))
+	for _, k := range keys {
+		fmt.Fprintf(tw, "  %s\t%s\t%d\n", k.Severity, k.Category, counts[k])
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write summary: %w", err)
+	}
+	return nil
+}
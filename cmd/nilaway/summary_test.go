@@ -0,0 +1,97 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSummaryFlagEnabled(t *testing.T) {
+	t.Parallel()
+
+	for _, arg := range []string{"-summary", "--summary", "-summary=true", "-summary=1", "-summary=t", "-summary=TRUE"} {
+		require.True(t, boolFlagEnabled([]string{arg, "./..."}, "summary"), arg)
+	}
+	for _, arg := range []string{"-summary=false", "-summary=0", "-summary=bogus", "-serve", "./..."} {
+		require.False(t, boolFlagEnabled([]string{arg, "./..."}, "summary"), arg)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := writeSummary(&buf, []summaryDiagnostic{
+		{Posn: "a.go:1:1", Message: "m1", Category: "nilaway/inferred-annotation"},
+		{Posn: "a.go:2:1", Message: "m2", Category: "nilaway/internal-error"},
+		{Posn: "a.go:3:1", Message: "m3", Category: "nilaway/defer-deref"},
+		{Posn: "a.go:4:1", Message: "m4"},
+		{Posn: "b.go:1:1", Message: "m5"},
+		// The duplicate of the test variant of the package is counted once.
+		{Posn: "b.go:1:1", Message: "m5"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nilaway: 5 error(s) in total\n"+
+		"  error    nilaway                      2\n"+
+		"  error    nilaway/defer-deref          1\n"+
+		"  warning  nilaway/internal-error       1\n"+
+		"  info     nilaway/inferred-annotation  1\n", buf.String())
+
+	// The footer is written even without errors.
+	buf.Reset()
+	require.NoError(t, writeSummary(&buf, nil))
+	require.Equal(t, "nilaway: 0 error(s) in total\n", buf.String())
+}
+
+func TestRun_Summary(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and output, and the environment.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	summaryFile := filepath.Join(t.TempDir(), "summary.jsonl")
+	require.NoError(t, os.WriteFile(summaryFile, nil, 0o600))
+	t.Setenv(_summaryFileEnv, summaryFile)
+
+	// The errors are written out as newline-delimited JSON such that the testdata needs no "want"
+	// comments, and such that the footer can be checked against the emitted errors.
+	var buf bytes.Buffer
+	_summary = true
+	_outputFormat, _includeErrorsInFiles = _outputFormatJSONL, testdata
+	_jsonlOutput = &jsonlWriter{w: &buf}
+	defer func() {
+		_summary = false
+		_outputFormat, _includeErrorsInFiles = "", ""
+		_jsonlOutput = &jsonlWriter{w: os.Stdout}
+	}()
+
+	analysistest.Run(t, testdata, Analyzer, "jsonl")
+
+	diagnostics, err := readLines[summaryDiagnostic](summaryFile)
+	require.NoError(t, err)
+	var footer bytes.Buffer
+	require.NoError(t, writeSummary(&footer, diagnostics))
+
+	emitted := strings.Count(buf.String(), "\n")
+	require.Equal(t, 3, emitted)
+	require.Equal(t, "nilaway: 3 error(s) in total\n  error  nilaway  3\n", footer.String())
+}
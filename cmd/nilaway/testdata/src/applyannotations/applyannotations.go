@@ -0,0 +1,61 @@
+// Package applyannotations is copied to a temporary directory for applying the inferred
+// annotations in place, and the result is compared against applyannotations.go.golden. Note that
+// the inferred annotations are applied instead of being reported, hence there are no "want"
+// comments.
+package applyannotations
+
+type T struct {
+	name string
+}
+
+// Find returns the element with the given name, or nil if there is none.
+func Find(ts []*T, name string) *T {
+	for _, t := range ts {
+		if t != nil && t.name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func Name(t *T) string {
+	return t.name
+}
+
+// Explicitly annotated sites are kept as is.
+// nilable(t)
+func Describe(t *T) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.name
+}
+
+// Only the sites that are not explicitly annotated are annotated.
+// nonnil(a)
+func Concat(a, b *T) string {
+	return a.name + b.name
+}
+
+func (t *T) Rename(other *T) *T {
+	t.name = other.name
+	return nil
+}
+
+func Names(_ *T, ts ...*T) []string {
+	names := make([]string, 0, len(ts))
+	for _, t := range ts {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+// Unexported functions are not part of the exported API.
+func name(t *T) string {
+	return t.name
+}
+
+// The sites that the inference left undetermined are skipped.
+func Identity(t *T) *T {
+	return t
+}
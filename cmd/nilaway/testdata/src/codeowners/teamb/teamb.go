@@ -0,0 +1,7 @@
+// <nilaway no inference>
+package teamb
+
+// nilable(a)
+func foo(a *int) {
+	print(*a) //want "^\\[owners: @org/team-b @alice\\] "
+}
@@ -0,0 +1,7 @@
+// <nilaway no inference>
+package unowned
+
+// nilable(a)
+func foo(a *int) {
+	print(*a) //want "^Potential nil panic"
+}
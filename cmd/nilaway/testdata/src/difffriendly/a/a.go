@@ -0,0 +1,14 @@
+// <nilaway no inference>
+package a
+
+// The errors in this package are written out in the diff-friendly form instead of being reported
+// to the driver, hence there are no "want" comments.
+
+// nilable(result 0)
+func bar() *int {
+	return nil
+}
+
+func baz() int {
+	return *bar()
+}
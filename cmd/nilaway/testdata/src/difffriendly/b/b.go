@@ -0,0 +1,19 @@
+// <nilaway no inference>
+package b
+
+// The errors in this package are written out in the diff-friendly form instead of being reported
+// to the driver, hence there are no "want" comments.
+
+// nilable(result 0)
+func bar() *int {
+	return nil
+}
+
+func baz() int {
+	return *bar()
+}
+
+// nilable(a)
+func foo(a *int) int {
+	return *a
+}
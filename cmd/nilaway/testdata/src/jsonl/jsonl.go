@@ -0,0 +1,19 @@
+// <nilaway no inference>
+package jsonl
+
+// The errors in this package are written out as newline-delimited JSON instead of being reported
+// to the driver, hence there are no "want" comments.
+
+// nilable(a, b)
+func foo(a, b *int) int {
+	return *a + *b
+}
+
+// nilable(result 0)
+func bar() *int {
+	return nil
+}
+
+func baz() int {
+	return *bar()
+}
@@ -0,0 +1,14 @@
+// <nilaway no inference>
+package b
+
+// This package has an error, which is reported only if the package is among the packages selected
+// by the package limit.
+
+// nilable(result 0)
+func bar() *int {
+	return nil
+}
+
+func baz() int {
+	return *bar() //want "dereferenced"
+}
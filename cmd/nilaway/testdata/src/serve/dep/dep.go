@@ -0,0 +1,7 @@
+// Package dep is the dependency of the serve package, whose result is changed to nonnil between
+// the requests to the server in the test.
+package dep
+
+func Get() *int {
+	return nil
+}
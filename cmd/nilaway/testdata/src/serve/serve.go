@@ -0,0 +1,9 @@
+// Package serve is for testing the server mode, where the nilable result of the dependency is
+// dereferenced (also in the test file, which is only analyzed with the test variants).
+package serve
+
+import "serve/dep"
+
+func foo() int {
+	return *dep.Get()
+}
@@ -0,0 +1,8 @@
+package serve
+
+import "serve/dep"
+
+// bar is only analyzed along with the test variant of the package.
+func bar() int {
+	return *dep.Get()
+}
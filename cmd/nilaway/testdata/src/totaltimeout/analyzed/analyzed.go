@@ -0,0 +1,13 @@
+// <nilaway no inference>
+package analyzed
+
+// This package is analyzed before the total timeout is exceeded, hence its errors are reported.
+
+// nilable(result 0)
+func bar() *int {
+	return nil
+}
+
+func baz() int {
+	return *bar() //want "dereferenced"
+}
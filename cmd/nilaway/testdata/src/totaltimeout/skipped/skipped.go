@@ -0,0 +1,14 @@
+// <nilaway no inference>
+package skipped
+
+// This package is analyzed after the total timeout is exceeded, hence it is skipped and its
+// errors are not reported.
+
+// nilable(result 0)
+func bar() *int {
+	return nil
+}
+
+func baz() int {
+	return *bar()
+}
@@ -0,0 +1,77 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// analysisBudget bounds the total wall-clock time of the analysis. Once the budget is exhausted,
+// the analyses of the packages that have not started yet are skipped, while the ones in progress
+// run to completion such that their errors are still reported.
+type analysisBudget struct {
+	// timeout is the total timeout of the analysis, where zero means unbounded. The clock starts
+	// when the analysis of the first package starts, i.e., package loading is not included.
+	timeout time.Duration
+
+	start   sync.Once
+	expired atomic.Bool
+	note    sync.Once
+}
+
+// _budget is the analysis budget of the driver, whose timeout is set by the driver flag.
+var _budget = &analysisBudget{}
+
+// _skippedConfig is the config given to the sub-analyzers of the skipped packages. Note that a
+// zero config has no packages in scope, so all sub-analyzers return immediately.
+var _skippedConfig = &config.Config{}
+
+// exhausted returns true if the budget is exhausted, starting the clock on the first call.
+func (b *analysisBudget) exhausted() bool {
+	if b.timeout <= 0 {
+		return false
+	}
+	b.start.Do(func() {
+		time.AfterFunc(b.timeout, func() { b.expired.Store(true) })
+	})
+	return b.expired.Load()
+}
+
+// incompleteError returns the error noting that the analysis is incomplete for the first skipped
+// package, and nil for the other ones to avoid flooding the output with the same note.
+func (b *analysisBudget) incompleteError(pass *analysis.Pass) error {
+	var err error
+	b.note.Do(func() {
+		err = fmt.Errorf("analysis incomplete: total timeout of %s exceeded, skipping the analysis of %q and all remaining packages", b.timeout, pass.Pkg.Path())
+	})
+	return err
+}
+
+// skipIfExhausted wraps the run function of the config analyzer (which all sub-analyzers depend
+// on) such that no further packages are analyzed once the budget is exhausted.
+func (b *analysisBudget) skipIfExhausted(run func(*analysis.Pass) (any, error)) func(*analysis.Pass) (any, error) {
+	return func(pass *analysis.Pass) (any, error) {
+		if b.exhausted() {
+			return _skippedConfig, nil
+		}
+		return run(pass)
+	}
+}
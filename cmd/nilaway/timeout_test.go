@@ -0,0 +1,83 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/config"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// errorRecorder implements analysistest.Testing to record the test failures instead of failing
+// the test, such that we can check the errors returned by the analyzer.
+type errorRecorder struct {
+	errors []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAnalysisBudget_Exhausted(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, (&analysisBudget{}).exhausted())
+
+	b := &analysisBudget{timeout: time.Nanosecond}
+	require.Eventually(t, b.exhausted, time.Second, time.Millisecond)
+}
+
+func TestRun_TotalTimeout(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since it modifies the global driver
+	// flags and the config analyzer.
+	testdata, err := filepath.Abs("testdata")
+	require.NoError(t, err)
+
+	originalRun := config.Analyzer.Run
+	_includeErrorsInFiles = testdata
+	_budget = &analysisBudget{timeout: time.Hour}
+	config.Analyzer.Run = _budget.skipIfExhausted(originalRun)
+	defer func() {
+		_includeErrorsInFiles = ""
+		_budget = &analysisBudget{}
+		config.Analyzer.Run = originalRun
+	}()
+
+	// Before the timeout is exceeded, the errors are reported as usual.
+	analysistest.Run(t, testdata, Analyzer, "totaltimeout/analyzed")
+
+	// Once the timeout is exceeded, the remaining packages are skipped with a note (only once).
+	_budget.expired.Store(true)
+	r := &errorRecorder{}
+	results := analysistest.Run(r, testdata, Analyzer, "totaltimeout/skipped")
+	require.Len(t, results, 1)
+	require.ErrorContains(t, results[0].Err, "analysis incomplete: total timeout of 1h0m0s exceeded")
+	require.Len(t, r.errors, 1)
+	require.Contains(t, r.errors[0], "analysis incomplete")
+
+	// The expected errors of an otherwise-analyzed package are no longer reported either.
+	r = &errorRecorder{}
+	results = analysistest.Run(r, testdata, Analyzer, "totaltimeout/analyzed")
+	require.Len(t, results, 1)
+	require.Len(t, r.errors, 1)
+	require.Contains(t, r.errors[0], "no diagnostic was reported")
+	require.NoError(t, results[0].Err)
+	require.Empty(t, results[0].Diagnostics)
+}
@@ -17,9 +17,14 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"go/ast"
+	"go/build/constraint"
 	"go/types"
 	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"go.uber.org/nilaway/util/asthelper"
@@ -32,16 +37,147 @@ type Config struct {
 	PrettyPrint bool
 	// GroupErrorMessages indicates whether similar error messages should be grouped.
 	GroupErrorMessages bool
-	// ExperimentalStructInitEnable indicates whether experimental struct initialization is enabled.
+	// ExperimentalStructInitEnable indicates whether experimental struct initialization is enabled
+	// for all packages.
+	//
+	// Deprecated: use IsExperimentalStructInitEnabled instead, which also respects the package
+	// patterns the support is enabled for.
 	ExperimentalStructInitEnable bool
-	// ExperimentalAnonymousFuncEnable indicates whether experimental anonymous function support is enabled.
+	// ExperimentalAnonymousFuncEnable indicates whether experimental anonymous function support is
+	// enabled for all packages.
+	//
+	// Deprecated: use IsExperimentalAnonymousFuncEnabled instead, which also respects the package
+	// patterns the support is enabled for.
 	ExperimentalAnonymousFuncEnable bool
+	// KeepExplanationsInGroups indicates whether the grouped error messages should also include
+	// the explanations (i.e., the nil flows from the conflict points to the dereference points) of
+	// the other places sharing the same nil source, instead of only listing their positions.
+	KeepExplanationsInGroups bool
+	// AnnotationAliases maps user-defined alias keywords (e.g., "opt") to the annotation keywords
+	// (i.e., "nilable" or "nonnil") they should be expanded to before the annotations are parsed.
+	AnnotationAliases map[string]string
+	// WarnRedundantAnnotations indicates whether annotations that have no effect on the analysis
+	// should be reported.
+	WarnRedundantAnnotations bool
+	// SuggestRelaxAnnotations indicates whether `nonnil` annotations on the parameters of unexported
+	// functions that all call sites already satisfy should be reported as suggestions for relaxing
+	// them.
+	SuggestRelaxAnnotations bool
+	// PanicIfNilFuncs maps the fully-qualified names of the functions that panic if (some of)
+	// their arguments are nil (e.g., "example.com/pkg.MustNotBeNil" or
+	// "example.com/pkg.Checker.NotNil" for methods) to the indices of those arguments. A nil
+	// slice of indices means all arguments. After a call to such a function, the arguments are
+	// treated as nonnil.
+	PanicIfNilFuncs map[string][]int
+	// ExcludeSymbols is the set of the fully-qualified names of the functions (e.g.,
+	// "example.com/pkg.MustGet" or "example.com/pkg.Store.MustGet" for methods) whose results are
+	// known to be nonnil by external guarantees, such that the diagnostics rooted in their
+	// results are suppressed everywhere.
+	ExcludeSymbols map[string]bool
+	// NoInference indicates whether the inference engine should be disabled entirely, such that
+	// only the syntactically-certain nil panics (e.g., dereferences of literal nils, or of values
+	// inside the branches where they are checked to be nil) are reported.
+	NoInference bool
+	// OptionalAnnotations indicates whether the `// +optional` comment convention (e.g., from
+	// Kubernetes) on struct fields should be recognized as a `nilable` annotation.
+	OptionalAnnotations bool
+	// ConservativeUnknownCalls indicates whether the results of calls whose callees cannot be
+	// resolved statically (e.g., calls through function values) should be treated as nilable
+	// instead of nonnil.
+	ConservativeUnknownCalls bool
+	// StrictMapReads indicates whether every single-value read from a map whose values can be nil
+	// (e.g., `m[k]` for a `map[string]*T`) should require the comma-ok form (i.e., `v, ok := m[k]`),
+	// regardless of the writes to or the nil checks on the same index within the function.
+	StrictMapReads bool
+	// NonnilUnsafeConversions indicates whether the results of conversions from `unsafe.Pointer`
+	// (e.g., `(*T)(unsafe.Pointer(p))`) should be treated as nonnil instead of nilable.
+	NonnilUnsafeConversions bool
+	// ConcreteInterfaceReceivers indicates whether the method calls on local interface variables
+	// that are only assigned pointers of a single concrete type (e.g., `var i I = t` for `t *T`)
+	// should be resolved to the methods of the concrete type, such that a nilable pointer assigned
+	// to the interface is reported only if the called method dereferences its receiver.
+	ConcreteInterfaceReceivers bool
+	// ModelProtobufGetters indicates whether the message-typed results of the generated protobuf
+	// getters (e.g., `req.GetUser()` on a message type implementing `proto.Message`) should be
+	// treated as nilable, since the getters return nil for unset fields (and nil receivers).
+	ModelProtobufGetters bool
+	// StripVendor indicates whether the `vendor/` segments (e.g., in "example.com/app/vendor/
+	// github.com/foo") should be stripped from the package paths before they are matched against
+	// the include / exclude package lists, such that the vendored packages are matched by the
+	// paths of the packages they are copied from.
+	StripVendor bool
+	// SkipIgnoreBuildFiles indicates whether the files constrained by the `ignore` build tag
+	// (i.e., `//go:build ignore`, the convention for standalone tools that are not part of the
+	// package) should be skipped, in case they are loaded (e.g., with `-tags ignore`).
+	SkipIgnoreBuildFiles bool
+	// APILint indicates whether the analysis should only report the violations of the nilability
+	// contracts (i.e., annotations) of the exported API instead of potential nil panics.
+	APILint bool
+	// DeferDerefs controls how the diagnostics occurring within deferred function literals (i.e.,
+	// only during cleanup) are reported, and it is one of the DeferDerefs* constants.
+	DeferDerefs string
+	// DiscardedErrors controls how the potential nil panics rooted in the value results whose
+	// error results are explicitly discarded with the blank identifier (e.g., `v, _ := f()`) are
+	// reported, and it is one of the DiscardedErrors* constants.
+	DiscardedErrors string
+	// MinConfidence is the minimum confidence level (one of the Confidence* constants) of the
+	// potential nil panics to be reported, where the reported ones are labeled with their levels.
+	// Empty means that all potential nil panics are reported without the levels.
+	MinConfidence string
+	// RootInScopeOnly indicates whether only the potential nil panics whose nil sources (i.e., the
+	// roots of the nil flows) are in the packages in scope (see IsPkgInScope) should be reported,
+	// such that the ones rooted in the nilable values from the out-of-scope dependencies (which
+	// are usually not actionable) are suppressed.
+	RootInScopeOnly bool
+	// ReportOncePerSite indicates whether each unique site (i.e., position and category) should be
+	// reported at most once, even if multiple nil flows (e.g., from different nil sources or
+	// through different paths) reach it.
+	ReportOncePerSite bool
+	// DefaultNilability overrides the default nilability (i.e., the nilability of the unannotated
+	// sites in the packages without inference) by the category of the types (one of the
+	// TypeCategory* constants), where true means nilable. The categories not in the map keep the
+	// built-in defaults, i.e., slices, maps and channels are nilable and the rest are nonnil.
+	DefaultNilability map[string]bool
+	// WithHints indicates whether a one-line hint for fixing the issue (e.g., "check the error
+	// returned by `f()` before using its other results") should be appended to each diagnostic.
+	WithHints bool
+	// StrictInternalErrors indicates whether the internal errors of NilAway (e.g., panics on
+	// unexpected AST shapes) should fail the analysis with the stack traces and the offending
+	// positions, instead of being reported as diagnostics or silently degrading the analysis.
+	StrictInternalErrors bool
+	// ApplyInferredAnnotations indicates whether the nilability of the exported API inferred by
+	// NilAway (but not explicitly annotated) should be reported as suggested fixes that insert
+	// the corresponding annotations into the source (see cmd/nilaway for applying them).
+	ApplyInferredAnnotations bool
+	// NilabilityReport indicates whether the concluded nilability of the parameters and results of
+	// each analyzed function should be reported as JSON, along with the callees whose parameters
+	// and results contributed to it, forming a nilability-annotated call graph.
+	NilabilityReport bool
+	// MaxInferenceIterations is the maximum number of the propagation steps of the inference over
+	// the constraints of a package, after which the inference stops with the nilabilities
+	// concluded so far. Zero means no bound.
+	MaxInferenceIterations int
 
 	// includePkgs is the list of packages to analyze.
 	includePkgs []string
+	// packages is the list of the exact import paths of the packages to analyze, or empty if the
+	// packages are only matched against the include list by prefixes (see PackagesFlag).
+	packages []string
 	// excludePkgs is the list of packages to exclude from analysis. Exclude list takes
 	// precedence over the include list.
 	excludePkgs []string
+	// excludeModules is the list of module paths whose packages are excluded from analysis,
+	// regardless of the package paths (see ExcludeModulesFlag).
+	excludeModules []string
+	// modules maps the paths of the package and its dependencies to the paths of the modules they
+	// belong to. It is only populated if any module is excluded.
+	modules map[string]string
+	// experimentalStructInitPkgs is the list of package patterns (see pkgScopeFlag) for which the
+	// experimental struct initialization support is enabled.
+	experimentalStructInitPkgs []string
+	// experimentalAnonymousFuncPkgs is the list of package patterns (see pkgScopeFlag) for which
+	// the experimental anonymous function support is enabled.
+	experimentalAnonymousFuncPkgs []string
 	// excludeFileDocStrings is the list of doc strings that, if they appear in the file doc
 	// string, will cause the file to be excluded from analysis. Examples include "@generated" and
 	// "Code generated by".
@@ -49,19 +185,31 @@ type Config struct {
 }
 
 // IsPkgInScope returns true iff the passed package is in scope for analysis, i.e., it is in the
-// configured include list but not in the exclude list.
+// configured include list (and exactly in the list of packages, if given) but not in the exclude
+// list.
 func (c *Config) IsPkgInScope(pkg *types.Package) bool {
 	if pkg == nil {
 		return false
 	}
 
+	path := pkg.Path()
+	if c.isModuleExcluded(path) {
+		return false
+	}
+	if c.StripVendor {
+		path = stripVendor(path)
+	}
+	if len(c.packages) > 0 && !slices.Contains(c.packages, path) {
+		return false
+	}
+
 	for _, include := range c.includePkgs {
-		if !strings.HasPrefix(pkg.Path(), include) {
+		if !strings.HasPrefix(path, include) {
 			continue
 		}
 
 		for _, exclude := range c.excludePkgs {
-			if strings.HasPrefix(pkg.Path(), exclude) {
+			if strings.HasPrefix(path, exclude) {
 				return false
 			}
 		}
@@ -71,9 +219,45 @@ func (c *Config) IsPkgInScope(pkg *types.Package) bool {
 	return false
 }
 
+// stripVendor returns the package path with everything up to (and including) the last `vendor/`
+// segment stripped, i.e., the path of the package the vendored package is copied from. Note that
+// for nested vendor directories (e.g., "a/vendor/b/vendor/c"), the innermost one takes effect.
+func stripVendor(path string) string {
+	if strings.HasPrefix(path, "vendor/") {
+		path = "/" + path
+	}
+	if i := strings.LastIndex(path, "/vendor/"); i >= 0 {
+		return path[i+len("/vendor/"):]
+	}
+	return path
+}
+
+// IsExperimentalStructInitEnabled returns true iff the experimental struct initialization support
+// is enabled for the passed package.
+func (c *Config) IsExperimentalStructInitEnabled(pkg *types.Package) bool {
+	if c.ExperimentalStructInitEnable {
+		return true
+	}
+	return pkg != nil && matchesAnyPkgPattern(c.experimentalStructInitPkgs, pkg.Path())
+}
+
+// IsExperimentalAnonymousFuncEnabled returns true iff the experimental anonymous function support
+// is enabled for the passed package.
+func (c *Config) IsExperimentalAnonymousFuncEnabled(pkg *types.Package) bool {
+	if c.ExperimentalAnonymousFuncEnable {
+		return true
+	}
+	return pkg != nil && matchesAnyPkgPattern(c.experimentalAnonymousFuncPkgs, pkg.Path())
+}
+
 // IsFileInScope returns true iff we should analyze the file. It checks the docstring of the file
-// and returns false if any of the strings in ExcludeFileDocStrings appear in the file docstring.
+// and returns false if any of the strings in ExcludeFileDocStrings appear in the file docstring,
+// or if the file is constrained by the `ignore` build tag (unless configured otherwise).
 func (c *Config) IsFileInScope(file *ast.File) bool {
+	if c.SkipIgnoreBuildFiles && hasIgnoreBuildTag(file) {
+		return false
+	}
+
 	// Fast return if there is no exclude list.
 	if len(c.excludeFileDocStrings) == 0 {
 		return true
@@ -95,6 +279,47 @@ func (c *Config) IsFileInScope(file *ast.File) bool {
 	return true
 }
 
+// hasIgnoreBuildTag returns true iff the file has a `//go:build` constraint that requires the
+// `ignore` build tag, e.g., `//go:build ignore` or `//go:build ignore && linux`.
+func hasIgnoreBuildTag(file *ast.File) bool {
+	for _, group := range file.Comments {
+		// Build constraints must appear before the package clause.
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				continue
+			}
+			if requiresTag(expr, "ignore") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiresTag returns true iff the build constraint expression can only be satisfied if the tag
+// is set.
+func requiresTag(expr constraint.Expr, tag string) bool {
+	switch expr := expr.(type) {
+	case *constraint.TagExpr:
+		return expr.Tag == tag
+	case *constraint.AndExpr:
+		return requiresTag(expr.X, tag) || requiresTag(expr.Y, tag)
+	case *constraint.OrExpr:
+		return requiresTag(expr.X, tag) && requiresTag(expr.Y, tag)
+	}
+	return false
+}
+
+// _identRegex matches a valid alias for the annotation keywords.
+var _identRegex = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9]*$")
+
 const _doc = `nilaway_config analyzer is responsible to take configurations (flags) for NilAway execution.
 It does not run any analysis and is only meant to be used as a dependency for the sub-analyzers of 
 NilAway to share the same configurations. 
@@ -118,6 +343,7 @@ var Analyzer = &analysis.Analyzer{
 	Run:        run,
 	Flags:      newFlagSet(),
 	ResultType: reflect.TypeOf((*Config)(nil)),
+	FactTypes:  []analysis.Fact{new(moduleFact)},
 }
 
 const (
@@ -125,16 +351,133 @@ const (
 	PrettyPrintFlag = "pretty-print"
 	// GroupErrorMessagesFlag is the flag for grouping similar error messages.
 	GroupErrorMessagesFlag = "group-error-messages"
+	// KeepExplanationsInGroupsFlag is the flag for including the explanations of all places in the grouped error messages.
+	KeepExplanationsInGroupsFlag = "keep-explanations-in-groups"
 	// IncludePkgsFlag is the flag name for include package prefixes.
 	IncludePkgsFlag = "include-pkgs"
 	// ExcludePkgsFlag is the flag name for exclude package prefixes.
 	ExcludePkgsFlag = "exclude-pkgs"
+	// PackagesFlag is the flag name for the exact import paths of the packages to analyze.
+	PackagesFlag = "packages"
+	// ExcludeModulesFlag is the flag name for excluded module paths.
+	ExcludeModulesFlag = "exclude-modules"
 	// ExcludeFileDocStringsFlag is the flag name for the docstrings that exclude files from analysis.
 	ExcludeFileDocStringsFlag = "exclude-file-docstrings"
 	// ExperimentalStructInitEnableFlag is the flag name for the experimental struct init support.
 	ExperimentalStructInitEnableFlag = "experimental-struct-init"
 	// ExperimentalAnonymousFunctionFlag is the flag name for the experimental anonymous function support.
 	ExperimentalAnonymousFunctionFlag = "experimental-anonymous-function"
+	// AnnotationAliasesFlag is the flag name for the aliases of the annotation keywords.
+	AnnotationAliasesFlag = "annotation-aliases"
+	// WarnRedundantAnnotationsFlag is the flag name for reporting redundant annotations.
+	WarnRedundantAnnotationsFlag = "warn-redundant-annotations"
+	// SuggestRelaxAnnotationsFlag is the flag name for suggesting relaxable parameter annotations.
+	SuggestRelaxAnnotationsFlag = "suggest-relax-annotations"
+	// PanicIfNilFuncsFlag is the flag name for the functions that panic if their arguments are nil.
+	PanicIfNilFuncsFlag = "panic-if-nil-funcs"
+	// ExcludeSymbolsFlag is the flag name for the functions whose results are excluded as nil sources.
+	ExcludeSymbolsFlag = "exclude-symbols"
+	// NoInferenceFlag is the flag name for disabling the inference engine entirely.
+	NoInferenceFlag = "no-inference"
+	// OptionalAnnotationsFlag is the flag name for recognizing `// +optional` comments as annotations.
+	OptionalAnnotationsFlag = "optional-annotations"
+	// ConservativeUnknownCallsFlag is the flag name for treating the results of unresolved calls as nilable.
+	ConservativeUnknownCallsFlag = "conservative-unknown-calls"
+	// StrictMapReadsFlag is the flag name for requiring the comma-ok form for all map reads.
+	StrictMapReadsFlag = "strict-map-reads"
+	// NonnilUnsafeConversionsFlag is the flag name for treating the results of `unsafe.Pointer` conversions as nonnil.
+	NonnilUnsafeConversionsFlag = "nonnil-unsafe-conversions"
+	// ConcreteInterfaceReceiversFlag is the flag name for resolving method calls on interfaces with known concrete types.
+	ConcreteInterfaceReceiversFlag = "concrete-interface-receivers"
+	// ModelProtobufGettersFlag is the flag name for treating the message results of protobuf getters as nilable.
+	ModelProtobufGettersFlag = "model-protobuf-getters"
+	// StripVendorFlag is the flag name for stripping the `vendor/` segments from the package paths.
+	StripVendorFlag = "strip-vendor"
+	// SkipIgnoreBuildFilesFlag is the flag name for skipping the files with the `ignore` build tag.
+	SkipIgnoreBuildFilesFlag = "skip-ignore-build-files"
+	// APILintFlag is the flag name for only reporting the violations of the exported API contracts.
+	APILintFlag = "api-lint"
+	// DeferDerefsFlag is the flag name for controlling the reporting of diagnostics within deferred functions.
+	DeferDerefsFlag = "defer-derefs"
+	// DiscardedErrorsFlag is the flag name for controlling the reporting of diagnostics rooted in discarded errors.
+	DiscardedErrorsFlag = "discarded-errors"
+	// MinConfidenceFlag is the flag name for the minimum confidence level of the reported diagnostics.
+	MinConfidenceFlag = "min-confidence"
+	// WithHintsFlag is the flag name for appending fix hints to the diagnostics.
+	WithHintsFlag = "with-hints"
+	// StrictInternalErrorsFlag is the flag name for failing the analysis on internal errors.
+	StrictInternalErrorsFlag = "strict-internal-errors"
+	// ApplyInferredAnnotationsFlag is the flag name for suggesting the inferred annotations of the exported API.
+	ApplyInferredAnnotationsFlag = "apply-inferred-annotations"
+	// NilabilityReportFlag is the flag name for reporting the nilability-annotated call graph.
+	NilabilityReportFlag = "nilability-report"
+	// MaxInferenceIterationsFlag is the flag name for bounding the propagation steps of the inference.
+	MaxInferenceIterationsFlag = "max-inference-iterations"
+	// RootInScopeOnlyFlag is the flag name for only reporting the errors rooted in the packages in scope.
+	RootInScopeOnlyFlag = "root-in-scope-only"
+	// ReportOncePerSiteFlag is the flag name for reporting each unique site at most once.
+	ReportOncePerSiteFlag = "report-once-per-site"
+	// DefaultNilabilityFlag is the flag name for overriding the default nilability by type category.
+	DefaultNilabilityFlag = "default-nilability"
+)
+
+const (
+	// TypeCategoryPointer is the category of the pointer types.
+	TypeCategoryPointer = "pointer"
+	// TypeCategoryMap is the category of the map types.
+	TypeCategoryMap = "map"
+	// TypeCategorySlice is the category of the slice types.
+	TypeCategorySlice = "slice"
+	// TypeCategoryChan is the category of the channel types.
+	TypeCategoryChan = "chan"
+	// TypeCategoryInterface is the category of the interface types (except `error`, which is
+	// always nilable by default).
+	TypeCategoryInterface = "interface"
+	// TypeCategoryFunc is the category of the function types. Note that making it nilable only
+	// checks the calls of the function-typed parameters, since the function values are otherwise
+	// considered not inhabited by nil.
+	TypeCategoryFunc = "func"
+)
+
+// _typeCategories is the list of the type categories whose default nilability can be overridden.
+var _typeCategories = []string{
+	TypeCategoryPointer, TypeCategoryMap, TypeCategorySlice, TypeCategoryChan, TypeCategoryInterface,
+	TypeCategoryFunc,
+}
+
+const (
+	// DeferDerefsReport reports the diagnostics within deferred functions like any other ones.
+	DeferDerefsReport = "report"
+	// DeferDerefsCategorize reports the diagnostics within deferred functions under a separate
+	// category, such that they can be suppressed or down-prioritized independently.
+	DeferDerefsCategorize = "categorize"
+	// DeferDerefsIgnore does not report the diagnostics within deferred functions at all.
+	DeferDerefsIgnore = "ignore"
+)
+
+const (
+	// DiscardedErrorsReport reports the diagnostics rooted in discarded errors like any other ones.
+	DiscardedErrorsReport = "report"
+	// DiscardedErrorsCategorize reports the diagnostics rooted in discarded errors under a separate
+	// category, such that they can be treated as warnings (e.g., to encourage proper error
+	// handling without failing the build).
+	DiscardedErrorsCategorize = "categorize"
+	// DiscardedErrorsIgnore does not report the diagnostics rooted in discarded errors at all.
+	DiscardedErrorsIgnore = "ignore"
+)
+
+const (
+	// ConfidenceLow is the confidence level of the potential nil panics whose nil flows go
+	// through many sites (e.g., across several functions), relying the most on the inference.
+	ConfidenceLow = "low"
+	// ConfidenceMedium is the confidence level of the potential nil panics whose nil flows go
+	// through a few sites (e.g., a nil returned from a function and dereferenced by its caller).
+	ConfidenceMedium = "medium"
+	// ConfidenceHigh is the confidence level of the definite nil panics, i.e., the ones whose nil
+	// sources always produce nil and whose dereference points always require nonnil values
+	// regardless of any annotation or inference (e.g., a dereference of a literal nil). These are
+	// the ones reported with the `no-inference` flag.
+	ConfidenceHigh = "high"
 )
 
 // newFlagSet returns a flag set to be used in the nilaway config analyzer.
@@ -145,11 +488,40 @@ func newFlagSet() flag.FlagSet {
 	// Instead, we will use the flags through the analyzer's Flags field later.
 	_ = fs.Bool(PrettyPrintFlag, true, "Pretty print the error messages")
 	_ = fs.Bool(GroupErrorMessagesFlag, true, "Group similar error messages")
+	_ = fs.Bool(KeepExplanationsInGroupsFlag, false, "Whether to also include the explanations (i.e., the nil flows to the dereference points) of the other places sharing the same nil source in the grouped error messages, instead of only listing their positions (only effective with grouping)")
 	_ = fs.String(IncludePkgsFlag, "", "Comma-separated list of packages to analyze")
 	_ = fs.String(ExcludePkgsFlag, "", "Comma-separated list of packages to exclude from analysis")
+	_ = fs.String(PackagesFlag, "", "Comma-separated list of the exact import paths of the packages to analyze, e.g., \"github.com/foo/bar\" analyzes that package, but not \"github.com/foo/barutil\" or \"github.com/foo/bar/baz\" (unlike the prefixes of the include list). The dependencies are still loaded for their facts, and the include / exclude lists still apply")
+	_ = fs.String(ExcludeModulesFlag, "", "Comma-separated list of module paths (as declared in their go.mod files) whose packages are excluded from analysis, regardless of whether their package paths share prefixes with the packages of other modules, e.g., \"github.com/foo/bar\" excludes the packages of that module, but not \"github.com/foo/barutil\" or the packages of a nested module \"github.com/foo/bar/v2\"")
 	_ = fs.String(ExcludeFileDocStringsFlag, "", "Comma-separated list of docstrings to exclude from analysis")
-	_ = fs.Bool(ExperimentalStructInitEnableFlag, false, "Whether to enable experimental struct initialization support")
-	_ = fs.Bool(ExperimentalAnonymousFunctionFlag, false, "Whether to enable experimental anonymous function support")
+	fs.Var(&pkgScopeFlag{}, ExperimentalStructInitEnableFlag, "Whether to enable experimental struct initialization support, either for all packages (true) or for a comma-separated list of package prefixes, e.g., \"github.com/foo/...,github.com/bar\". Note that the list must be attached with \"=\" (e.g., -experimental-struct-init=github.com/foo/...), since a separate argument is not consumed by this flag and would be treated as a package to analyze instead")
+	fs.Var(&pkgScopeFlag{}, ExperimentalAnonymousFunctionFlag, "Whether to enable experimental anonymous function support, either for all packages (true) or for a comma-separated list of package prefixes, e.g., \"github.com/foo/...,github.com/bar\". Note that the list must be attached with \"=\" (e.g., -experimental-anonymous-function=github.com/foo/...), since a separate argument is not consumed by this flag and would be treated as a package to analyze instead")
+	_ = fs.String(AnnotationAliasesFlag, "", "Comma-separated list of <alias>=<keyword> pairs, where the keyword is either \"nilable\" or \"nonnil\", e.g., \"opt=nilable,req=nonnil\"")
+	_ = fs.Bool(WarnRedundantAnnotationsFlag, false, "Whether to report annotations that have no effect on the analysis (full inference mode only)")
+	_ = fs.Bool(SuggestRelaxAnnotationsFlag, false, "Whether to report \"nonnil\" annotations on the parameters of unexported functions that all call sites in the package already satisfy (full inference mode only)")
+	_ = fs.Bool(NoInferenceFlag, false, "Whether to disable the inference engine and only report syntactically-certain nil panics (e.g., dereferences of literal nils, or of values inside the branches where they are checked to be nil) for a fast, low-false-positive analysis")
+	_ = fs.Bool(OptionalAnnotationsFlag, false, "Whether to treat struct fields with a \"// +optional\" doc or line comment as nilable")
+	_ = fs.Bool(ConservativeUnknownCallsFlag, false, "Whether to treat the results of calls that cannot be resolved statically (e.g., calls through function values) as nilable instead of nonnil")
+	_ = fs.Bool(StrictMapReadsFlag, false, "Whether to require the comma-ok form (i.e., \"v, ok := m[k]\") for every read from a map whose values can be nil, treating the single-value reads as nilable even if the same index is written to or nil-checked before")
+	_ = fs.Bool(NonnilUnsafeConversionsFlag, false, "Whether to treat the results of conversions from \"unsafe.Pointer\" (e.g., \"(*T)(unsafe.Pointer(p))\") as nonnil instead of nilable, for code that is known to only convert nonnil pointers")
+	_ = fs.Bool(ConcreteInterfaceReceiversFlag, false, "Whether to resolve the method calls on local interface variables that are only assigned pointers of a single concrete type (e.g., \"var i I = t\" for \"t *T\") to the methods of the concrete type, such that a nilable pointer assigned to the interface is reported only if the called method dereferences its receiver")
+	_ = fs.Bool(ModelProtobufGettersFlag, false, "Whether to treat the results of the generated protobuf getters that return messages (i.e., \"Get*()\" methods of the types implementing \"proto.Message\", e.g., \"req.GetUser()\") as nilable, since they return nil for unset fields")
+	_ = fs.Bool(StripVendorFlag, false, "Whether to strip the \"vendor/\" segments from the package paths before matching them against the include / exclude package lists, such that, e.g., \"github.com/foo\" also matches \"example.com/app/vendor/github.com/foo\"")
+	_ = fs.Bool(SkipIgnoreBuildFilesFlag, true, "Whether to skip the files constrained by the \"ignore\" build tag (i.e., \"//go:build ignore\"), which are conventionally standalone tools that are not part of the package")
+	_ = fs.Bool(APILintFlag, false, "Whether to report only the violations of the nilability annotations on the exported API (e.g., an exported function annotated to return nonnil that returns nil) instead of potential nil panics (full inference mode only)")
+	_ = fs.String(DeferDerefsFlag, DeferDerefsReport, "How to report the potential nil panics within deferred function literals (i.e., only during cleanup): \"report\" them as usual, \"categorize\" them under the separate \"nilaway/defer-deref\" category, or \"ignore\" them")
+	_ = fs.String(DiscardedErrorsFlag, DiscardedErrorsReport, "How to report the potential nil panics rooted in the value results whose error results are explicitly discarded with the blank identifier (e.g., \"v, _ := f()\"): \"report\" them as usual, \"categorize\" them under the separate \"nilaway/discarded-error\" category, or \"ignore\" them. The discards marked with a \"//nilaway:intentional-discard\" comment are never reported")
+	_ = fs.String(MinConfidenceFlag, "", "The minimum confidence level of the potential nil panics to report, which is one of \"low\" (all of them, including the ones whose nil flows go through many annotation sites), \"medium\" (nil flows through at most a few sites), and \"high\" (definite nil panics, e.g., dereferences of literal nils), where each reported error message is labeled with its level. This is useful for rolling out NilAway gradually. Empty (default) reports all potential nil panics without the levels")
+	_ = fs.Bool(WithHintsFlag, false, "Whether to append a one-line hint for fixing the issue to each error message, e.g., \"add a nil check (e.g., 'if x != nil { ... }') before this dereference\"")
+	_ = fs.Bool(StrictInternalErrorsFlag, false, "Whether to fail the analysis on the internal errors of NilAway (e.g., panics on unexpected AST shapes) with the stack traces and the offending positions for bug reports, instead of reporting them as diagnostics or silently skipping the affected code")
+	_ = fs.Bool(ApplyInferredAnnotationsFlag, false, "Whether to insert the \"nilable\" / \"nonnil\" annotations inferred for the parameters and results of the exported API (i.e., exported functions and exported methods of exported types) into the source files as doc comments, such that the current contracts are frozen. The sites that are already annotated or that the inference left undetermined are skipped, hence applying the annotations is idempotent. Other drivers receive the annotations as suggested fixes under the \"nilaway/inferred-annotation\" category (full inference mode only)")
+	_ = fs.Bool(NilabilityReportFlag, false, "Whether to report the concluded nilability (\"nilable\", \"nonnil\" or \"undetermined\") of the parameters and results of each function declared in the analyzed packages, along with the callees whose parameters or results forced the same nilability (e.g., a callee dereferencing its parameter forces the argument passed from a parameter of the caller to be nonnil), forming a nilability-annotated call graph for architecture reviews. One diagnostic per function is reported at its declaration under the \"nilaway/nilability-report\" category, whose message is a JSON object of the form {\"function\": <name>, \"params\": [<site>...], \"results\": [<site>...]}, where each site is of the form {\"name\": <name>, \"nilability\": <nilability>, \"callees\": [<name>...]} (full inference mode only)")
+	_ = fs.Int(MaxInferenceIterationsFlag, 0, "The maximum number of the propagation steps of the inference over the constraints of each analyzed package, which is a safety bound for the packages with pathological constraint graphs (e.g., large recursive call chains). Once the bound is exceeded, the inference of the package stops with the nilabilities concluded so far, hence some errors may be missed, and a note is reported for the package under the \"nilaway/incomplete-inference\" category. Zero (default) means no bound (full inference mode only)")
+	_ = fs.Bool(RootInScopeOnlyFlag, false, "Whether to only report the potential nil panics whose nil sources (i.e., the roots of the nil flows) are in the packages in scope (included by \"include-pkgs\" but not excluded by \"exclude-pkgs\"), suppressing the ones rooted in the nilable values from the out-of-scope dependencies")
+	_ = fs.Bool(ReportOncePerSiteFlag, false, "Whether to report each unique site (i.e., position and category) at most once, even if it is reached by multiple nil flows (e.g., from different nil sources or through different paths), where only one of the nil flows is explained. This is stronger than the grouping of the error messages, which only collapses the places sharing the same nil source")
+	_ = fs.String(DefaultNilabilityFlag, "", "Comma-separated list of <category>=<keyword> pairs overriding the default nilability of the unannotated sites (in the packages without inference) by the category of their types, where the category is one of \"pointer\", \"map\", \"slice\", \"chan\", \"interface\" and \"func\", and the keyword is either \"nilable\" or \"nonnil\", e.g., \"pointer=nonnil,interface=nilable\"")
+	_ = fs.String(PanicIfNilFuncsFlag, "", "Comma-separated list of fully-qualified functions (or methods) that panic if their arguments are nil, optionally suffixed with \":<arg index>\" to only consider one argument, e.g., \"example.com/pkg.MustNotBeNil,example.com/pkg.Checker.NotNil:1\"")
+	_ = fs.String(ExcludeSymbolsFlag, "", "Comma-separated list of fully-qualified functions (or methods) whose results are known to be nonnil by external guarantees, such that the errors rooted in their results are suppressed everywhere, e.g., \"example.com/pkg.MustGet,example.com/pkg.Store.MustGet\". This is finer-grained than excluding the packages")
 
 	return *fs
 }
@@ -159,6 +531,10 @@ func run(pass *analysis.Pass) (any, error) {
 	conf := &Config{
 		PrettyPrint:        true,
 		GroupErrorMessages: true,
+		DeferDerefs:        DeferDerefsReport,
+		DiscardedErrors:    DiscardedErrorsReport,
+		// Files with the `ignore` build tag are skipped by default.
+		SkipIgnoreBuildFiles: true,
 		// If the user does not provide an include list, we give an empty package prefix to catch
 		// all packages.
 		includePkgs: []string{""},
@@ -171,11 +547,97 @@ func run(pass *analysis.Pass) (any, error) {
 	if groupErrorMessages, ok := pass.Analyzer.Flags.Lookup(GroupErrorMessagesFlag).Value.(flag.Getter).Get().(bool); ok {
 		conf.GroupErrorMessages = groupErrorMessages
 	}
-	if enableStructInit, ok := pass.Analyzer.Flags.Lookup(ExperimentalStructInitEnableFlag).Value.(flag.Getter).Get().(bool); ok {
-		conf.ExperimentalStructInitEnable = enableStructInit
+	if keepExplanations, ok := pass.Analyzer.Flags.Lookup(KeepExplanationsInGroupsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.KeepExplanationsInGroups = keepExplanations
+	}
+	if pkgs, ok := pass.Analyzer.Flags.Lookup(ExperimentalStructInitEnableFlag).Value.(flag.Getter).Get().([]string); ok {
+		conf.experimentalStructInitPkgs = pkgs
+		conf.ExperimentalStructInitEnable = slices.Contains(pkgs, "")
+	}
+	if pkgs, ok := pass.Analyzer.Flags.Lookup(ExperimentalAnonymousFunctionFlag).Value.(flag.Getter).Get().([]string); ok {
+		conf.experimentalAnonymousFuncPkgs = pkgs
+		conf.ExperimentalAnonymousFuncEnable = slices.Contains(pkgs, "")
+	}
+	if warnRedundant, ok := pass.Analyzer.Flags.Lookup(WarnRedundantAnnotationsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.WarnRedundantAnnotations = warnRedundant
+	}
+	if suggestRelax, ok := pass.Analyzer.Flags.Lookup(SuggestRelaxAnnotationsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.SuggestRelaxAnnotations = suggestRelax
+	}
+	if noInference, ok := pass.Analyzer.Flags.Lookup(NoInferenceFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.NoInference = noInference
+	}
+	if optional, ok := pass.Analyzer.Flags.Lookup(OptionalAnnotationsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.OptionalAnnotations = optional
+	}
+	if conservative, ok := pass.Analyzer.Flags.Lookup(ConservativeUnknownCallsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.ConservativeUnknownCalls = conservative
 	}
-	if enableAnonymousFunc, ok := pass.Analyzer.Flags.Lookup(ExperimentalAnonymousFunctionFlag).Value.(flag.Getter).Get().(bool); ok {
-		conf.ExperimentalAnonymousFuncEnable = enableAnonymousFunc
+	if strictMapReads, ok := pass.Analyzer.Flags.Lookup(StrictMapReadsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.StrictMapReads = strictMapReads
+	}
+	if nonnilUnsafe, ok := pass.Analyzer.Flags.Lookup(NonnilUnsafeConversionsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.NonnilUnsafeConversions = nonnilUnsafe
+	}
+	if concreteRecvs, ok := pass.Analyzer.Flags.Lookup(ConcreteInterfaceReceiversFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.ConcreteInterfaceReceivers = concreteRecvs
+	}
+	if protoGetters, ok := pass.Analyzer.Flags.Lookup(ModelProtobufGettersFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.ModelProtobufGetters = protoGetters
+	}
+	if stripVendor, ok := pass.Analyzer.Flags.Lookup(StripVendorFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.StripVendor = stripVendor
+	}
+	if skipIgnore, ok := pass.Analyzer.Flags.Lookup(SkipIgnoreBuildFilesFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.SkipIgnoreBuildFiles = skipIgnore
+	}
+	if apiLint, ok := pass.Analyzer.Flags.Lookup(APILintFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.APILint = apiLint
+	}
+	if mode, ok := pass.Analyzer.Flags.Lookup(DeferDerefsFlag).Value.(flag.Getter).Get().(string); ok && mode != "" {
+		switch mode {
+		case DeferDerefsReport, DeferDerefsCategorize, DeferDerefsIgnore:
+			conf.DeferDerefs = mode
+		default:
+			return nil, fmt.Errorf("invalid value %q for flag %q: expect %q, %q, or %q",
+				mode, DeferDerefsFlag, DeferDerefsReport, DeferDerefsCategorize, DeferDerefsIgnore)
+		}
+	}
+	if mode, ok := pass.Analyzer.Flags.Lookup(DiscardedErrorsFlag).Value.(flag.Getter).Get().(string); ok && mode != "" {
+		switch mode {
+		case DiscardedErrorsReport, DiscardedErrorsCategorize, DiscardedErrorsIgnore:
+			conf.DiscardedErrors = mode
+		default:
+			return nil, fmt.Errorf("invalid value %q for flag %q: expect %q, %q, or %q",
+				mode, DiscardedErrorsFlag, DiscardedErrorsReport, DiscardedErrorsCategorize, DiscardedErrorsIgnore)
+		}
+	}
+	if level, ok := pass.Analyzer.Flags.Lookup(MinConfidenceFlag).Value.(flag.Getter).Get().(string); ok && level != "" {
+		switch level {
+		case ConfidenceLow, ConfidenceMedium, ConfidenceHigh:
+			conf.MinConfidence = level
+		default:
+			return nil, fmt.Errorf("invalid value %q for flag %q: expect %q, %q, or %q",
+				level, MinConfidenceFlag, ConfidenceLow, ConfidenceMedium, ConfidenceHigh)
+		}
+	}
+	if withHints, ok := pass.Analyzer.Flags.Lookup(WithHintsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.WithHints = withHints
+	}
+	if strict, ok := pass.Analyzer.Flags.Lookup(StrictInternalErrorsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.StrictInternalErrors = strict
+	}
+	if apply, ok := pass.Analyzer.Flags.Lookup(ApplyInferredAnnotationsFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.ApplyInferredAnnotations = apply
+	}
+	if report, ok := pass.Analyzer.Flags.Lookup(NilabilityReportFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.NilabilityReport = report
+	}
+	if maxIterations, ok := pass.Analyzer.Flags.Lookup(MaxInferenceIterationsFlag).Value.(flag.Getter).Get().(int); ok {
+		if maxIterations < 0 {
+			return nil, fmt.Errorf("invalid value %d for flag %q: expect a non-negative integer", maxIterations, MaxInferenceIterationsFlag)
+		}
+		conf.MaxInferenceIterations = maxIterations
 	}
 	if include, ok := pass.Analyzer.Flags.Lookup(IncludePkgsFlag).Value.(flag.Getter).Get().(string); ok && include != "" {
 		conf.includePkgs = strings.Split(include, ",")
@@ -183,9 +645,142 @@ func run(pass *analysis.Pass) (any, error) {
 	if exclude, ok := pass.Analyzer.Flags.Lookup(ExcludePkgsFlag).Value.(flag.Getter).Get().(string); ok && exclude != "" {
 		conf.excludePkgs = strings.Split(exclude, ",")
 	}
+	if packages, ok := pass.Analyzer.Flags.Lookup(PackagesFlag).Value.(flag.Getter).Get().(string); ok && packages != "" {
+		conf.packages = strings.Split(packages, ",")
+	}
+	if modules, ok := pass.Analyzer.Flags.Lookup(ExcludeModulesFlag).Value.(flag.Getter).Get().(string); ok && modules != "" {
+		conf.excludeModules = strings.Split(modules, ",")
+		conf.modules = collectModules(pass)
+	}
 	if docstrings, ok := pass.Analyzer.Flags.Lookup(ExcludeFileDocStringsFlag).Value.(flag.Getter).Get().(string); ok && docstrings != "" {
 		conf.excludeFileDocStrings = strings.Split(docstrings, ",")
 	}
+	if aliases, ok := pass.Analyzer.Flags.Lookup(AnnotationAliasesFlag).Value.(flag.Getter).Get().(string); ok && aliases != "" {
+		m, err := parseAnnotationAliases(aliases)
+		if err != nil {
+			return nil, fmt.Errorf("parse annotation aliases: %w", err)
+		}
+		conf.AnnotationAliases = m
+	}
+	if rootInScopeOnly, ok := pass.Analyzer.Flags.Lookup(RootInScopeOnlyFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.RootInScopeOnly = rootInScopeOnly
+	}
+	if oncePerSite, ok := pass.Analyzer.Flags.Lookup(ReportOncePerSiteFlag).Value.(flag.Getter).Get().(bool); ok {
+		conf.ReportOncePerSite = oncePerSite
+	}
+	if defaults, ok := pass.Analyzer.Flags.Lookup(DefaultNilabilityFlag).Value.(flag.Getter).Get().(string); ok && defaults != "" {
+		m, err := parseDefaultNilability(defaults)
+		if err != nil {
+			return nil, fmt.Errorf("parse default nilability: %w", err)
+		}
+		conf.DefaultNilability = m
+	}
+	if funcs, ok := pass.Analyzer.Flags.Lookup(PanicIfNilFuncsFlag).Value.(flag.Getter).Get().(string); ok && funcs != "" {
+		m, err := parsePanicIfNilFuncs(funcs)
+		if err != nil {
+			return nil, fmt.Errorf("parse panic-if-nil functions: %w", err)
+		}
+		conf.PanicIfNilFuncs = m
+	}
+	if symbols, ok := pass.Analyzer.Flags.Lookup(ExcludeSymbolsFlag).Value.(flag.Getter).Get().(string); ok && symbols != "" {
+		m, err := parseExcludeSymbols(symbols)
+		if err != nil {
+			return nil, fmt.Errorf("parse excluded symbols: %w", err)
+		}
+		conf.ExcludeSymbols = m
+	}
 
 	return conf, nil
 }
+
+// parseAnnotationAliases parses the comma-separated list of <alias>=<keyword> pairs and returns
+// the mapping from the aliases to the keywords. It returns an error if a pair is malformed, if the
+// keyword is not an annotation keyword, or if the alias collides with an annotation keyword.
+func parseAnnotationAliases(s string) (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		alias, keyword, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		alias, keyword = strings.TrimSpace(alias), strings.TrimSpace(keyword)
+		if !ok || !_identRegex.MatchString(alias) {
+			return nil, fmt.Errorf("invalid alias %q: expect the form of <alias>=<keyword>", pair)
+		}
+		if keyword != NilableKeyword && keyword != NonNilKeyword {
+			return nil, fmt.Errorf("invalid keyword %q for alias %q: expect %q or %q", keyword, alias, NilableKeyword, NonNilKeyword)
+		}
+		if alias == NilableKeyword || alias == NonNilKeyword || alias == NonEmptyKeyword {
+			return nil, fmt.Errorf("alias %q collides with an annotation keyword", alias)
+		}
+		if existing, ok := aliases[alias]; ok && existing != keyword {
+			return nil, fmt.Errorf("alias %q is mapped to both %q and %q", alias, existing, keyword)
+		}
+		aliases[alias] = keyword
+	}
+	return aliases, nil
+}
+
+// parseDefaultNilability parses the comma-separated list of <category>=<keyword> pairs and returns
+// the mapping from the type categories to their default nilability (true for nilable). It returns
+// an error if a pair is malformed, if the category is unknown, if the keyword is not an annotation
+// keyword, or if a category is given conflicting keywords.
+func parseDefaultNilability(s string) (map[string]bool, error) {
+	defaults := make(map[string]bool)
+	for _, pair := range strings.Split(s, ",") {
+		category, keyword, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		category, keyword = strings.TrimSpace(category), strings.TrimSpace(keyword)
+		if !ok || !slices.Contains(_typeCategories, category) {
+			return nil, fmt.Errorf("invalid category in %q: expect the form of <category>=<keyword> with one of the categories %q", pair, _typeCategories)
+		}
+		if keyword != NilableKeyword && keyword != NonNilKeyword {
+			return nil, fmt.Errorf("invalid keyword %q for category %q: expect %q or %q", keyword, category, NilableKeyword, NonNilKeyword)
+		}
+		nilable := keyword == NilableKeyword
+		if existing, ok := defaults[category]; ok && existing != nilable {
+			return nil, fmt.Errorf("category %q is given both %q and %q", category, NilableKeyword, NonNilKeyword)
+		}
+		defaults[category] = nilable
+	}
+	return defaults, nil
+}
+
+// parsePanicIfNilFuncs parses the comma-separated list of <func>[:<arg index>] entries and returns
+// the mapping from the functions to the indices of the arguments that must be nonnil, where a nil
+// slice means all arguments.
+func parsePanicIfNilFuncs(s string) (map[string][]int, error) {
+	funcs := make(map[string][]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		name, index, hasIndex := strings.Cut(entry, ":")
+		if name == "" || !strings.Contains(name, ".") {
+			return nil, fmt.Errorf("invalid function %q: expect the form of <pkg path>.<func>[:<arg index>]", entry)
+		}
+		if !hasIndex {
+			funcs[name] = nil
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 {
+			return nil, fmt.Errorf("invalid argument index %q for function %q", index, name)
+		}
+		// If all arguments are already considered for the function, there is no need to record
+		// the individual index.
+		if indices, ok := funcs[name]; ok && indices == nil {
+			continue
+		}
+		funcs[name] = append(funcs[name], i)
+	}
+	return funcs, nil
+}
+
+// parseExcludeSymbols parses the comma-separated list of the fully-qualified names of the
+// functions and returns them as a set.
+func parseExcludeSymbols(s string) (map[string]bool, error) {
+	symbols := make(map[string]bool)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || !strings.Contains(name, ".") {
+			return nil, fmt.Errorf("invalid symbol %q: expect the form of <pkg path>.<func> or <pkg path>.<type>.<method>", name)
+		}
+		symbols[name] = true
+	}
+	return symbols, nil
+}
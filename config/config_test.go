@@ -0,0 +1,246 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"flag"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnnotationAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases, err := parseAnnotationAliases("opt=nilable, req = nonnil")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"opt": NilableKeyword, "req": NonNilKeyword}, aliases)
+}
+
+func TestParseAnnotationAliases_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		aliases string
+		errMsg  string
+	}{
+		{name: "CollidingAlias", aliases: "opt=nilable,nonnil=nilable", errMsg: "collides"},
+		{name: "CollidingNonEmptyAlias", aliases: "nonempty=nonnil", errMsg: "collides"},
+		{name: "UnknownKeyword", aliases: "opt=optional", errMsg: "invalid keyword"},
+		{name: "MissingKeyword", aliases: "opt", errMsg: "invalid alias"},
+		{name: "InvalidAlias", aliases: "o-pt=nilable", errMsg: "invalid alias"},
+		{name: "ConflictingAlias", aliases: "opt=nilable,opt=nonnil", errMsg: "mapped to both"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			aliases, err := parseAnnotationAliases(tt.aliases)
+			require.ErrorContains(t, err, tt.errMsg)
+			require.Nil(t, aliases)
+		})
+	}
+}
+
+func TestParseDefaultNilability(t *testing.T) {
+	t.Parallel()
+
+	defaults, err := parseDefaultNilability("pointer=nonnil, interface = nilable,map=nonnil,slice=nilable,chan=nonnil,func=nilable,pointer=nonnil")
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{
+		TypeCategoryPointer:   false,
+		TypeCategoryInterface: true,
+		TypeCategoryMap:       false,
+		TypeCategorySlice:     true,
+		TypeCategoryChan:      false,
+		TypeCategoryFunc:      true,
+	}, defaults)
+}
+
+func TestParseDefaultNilability_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		defaults string
+		errMsg   string
+	}{
+		{name: "UnknownCategory", defaults: "struct=nilable", errMsg: "invalid category"},
+		{name: "MissingKeyword", defaults: "pointer", errMsg: "invalid category"},
+		{name: "UnknownKeyword", defaults: "pointer=optional", errMsg: "invalid keyword"},
+		{name: "ConflictingKeywords", defaults: "pointer=nilable,pointer=nonnil", errMsg: "given both"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			defaults, err := parseDefaultNilability(tt.defaults)
+			require.ErrorContains(t, err, tt.errMsg)
+			require.Nil(t, defaults)
+		})
+	}
+}
+
+func TestParsePanicIfNilFuncs(t *testing.T) {
+	t.Parallel()
+
+	funcs, err := parsePanicIfNilFuncs("example.com/pkg.MustNotBeNil, example.com/pkg.Checker.NotNil:1,example.com/pkg.Checker.NotNil:2,example.com/pkg.MustNotBeNil:0")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]int{
+		"example.com/pkg.MustNotBeNil":   nil,
+		"example.com/pkg.Checker.NotNil": {1, 2},
+	}, funcs)
+}
+
+func TestParsePanicIfNilFuncs_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		funcs  string
+		errMsg string
+	}{
+		{name: "MissingPackage", funcs: "MustNotBeNil", errMsg: "invalid function"},
+		{name: "EmptyEntry", funcs: "example.com/pkg.MustNotBeNil,", errMsg: "invalid function"},
+		{name: "InvalidIndex", funcs: "example.com/pkg.MustNotBeNil:x", errMsg: "invalid argument index"},
+		{name: "NegativeIndex", funcs: "example.com/pkg.MustNotBeNil:-1", errMsg: "invalid argument index"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			funcs, err := parsePanicIfNilFuncs(tt.funcs)
+			require.ErrorContains(t, err, tt.errMsg)
+			require.Nil(t, funcs)
+		})
+	}
+}
+
+func TestParseExcludeSymbols(t *testing.T) {
+	t.Parallel()
+
+	symbols, err := parseExcludeSymbols("example.com/pkg.MustGet, example.com/pkg.Store.MustGet")
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{
+		"example.com/pkg.MustGet":       true,
+		"example.com/pkg.Store.MustGet": true,
+	}, symbols)
+
+	for _, invalid := range []string{"MustGet", "example.com/pkg.MustGet,"} {
+		symbols, err := parseExcludeSymbols(invalid)
+		require.ErrorContains(t, err, "invalid symbol")
+		require.Nil(t, symbols)
+	}
+}
+
+func TestIsPkgInScope_StripVendor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path            string
+		inScope         bool
+		inScopeStripped bool
+		description     string
+	}{
+		{path: "github.com/foo/bar", inScope: true, inScopeStripped: true, description: "non-vendored"},
+		{path: "example.com/app/vendor/github.com/foo/bar", inScope: false, inScopeStripped: true, description: "vendored"},
+		{path: "vendor/github.com/foo/bar", inScope: false, inScopeStripped: true, description: "root vendor"},
+		{path: "example.com/app/vendor/github.com/lib/vendor/github.com/foo", inScope: false, inScopeStripped: true, description: "nested vendor"},
+		{path: "example.com/app/vendor/github.com/foo/vendor/github.com/baz", inScope: false, inScopeStripped: false, description: "nested vendor of included package"},
+		{path: "example.com/app/vendor/github.com/foo/internal", inScope: false, inScopeStripped: false, description: "excluded"},
+		{path: "example.com/vendorlib/github.com/foo", inScope: false, inScopeStripped: false, description: "not a vendor segment"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := types.NewPackage(tt.path, "main")
+			conf := &Config{includePkgs: []string{"github.com/foo"}, excludePkgs: []string{"github.com/foo/internal"}}
+			require.Equal(t, tt.inScope, conf.IsPkgInScope(pkg))
+			conf.StripVendor = true
+			require.Equal(t, tt.inScopeStripped, conf.IsPkgInScope(pkg))
+		})
+	}
+}
+
+func TestIsPkgInScope_Packages(t *testing.T) {
+	t.Parallel()
+
+	conf := &Config{
+		includePkgs: []string{""},
+		excludePkgs: []string{"github.com/foo/bar/internal"},
+		packages:    []string{"github.com/foo/bar", "github.com/foo/bar/internal"},
+	}
+	require.True(t, conf.IsPkgInScope(types.NewPackage("github.com/foo/bar", "bar")))
+	// The packages sharing the prefixes of the listed ones are not in scope.
+	require.False(t, conf.IsPkgInScope(types.NewPackage("github.com/foo/barutil", "barutil")))
+	require.False(t, conf.IsPkgInScope(types.NewPackage("github.com/foo/bar/baz", "baz")))
+	require.False(t, conf.IsPkgInScope(types.NewPackage("github.com/foo", "foo")))
+	// The exclude list still applies to the listed packages.
+	require.False(t, conf.IsPkgInScope(types.NewPackage("github.com/foo/bar/internal", "internal")))
+}
+
+func TestIsFileInScope_IgnoreBuildTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		src         string
+		ignored     bool
+		description string
+	}{
+		{src: "package main", ignored: false, description: "no constraint"},
+		{src: "//go:build ignore\n\npackage main", ignored: true, description: "ignore"},
+		{src: "//go:build ignore && linux\n\npackage main", ignored: true, description: "ignore and other tag"},
+		{src: "//go:build ignore || linux\n\npackage main", ignored: false, description: "ignore or other tag"},
+		{src: "//go:build !ignore\n\npackage main", ignored: false, description: "not ignore"},
+		{src: "//go:build linux\n\npackage main", ignored: false, description: "other tag"},
+		{src: "// Copyright notice.\n\n//go:build ignore\n\n// Package main is a tool.\npackage main", ignored: true, description: "after other comments"},
+		{src: "package main\n\n//go:build ignore", ignored: false, description: "after package clause"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := parser.ParseFile(token.NewFileSet(), "main.go", tt.src, parser.ParseComments)
+			require.NoError(t, err)
+
+			conf := &Config{SkipIgnoreBuildFiles: true}
+			require.Equal(t, !tt.ignored, conf.IsFileInScope(file))
+			// The files are always in scope if the skipping is disabled.
+			conf.SkipIgnoreBuildFiles = false
+			require.True(t, conf.IsFileInScope(file))
+		})
+	}
+}
+
+func TestFlagUsages(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	fs.VisitAll(func(f *flag.Flag) {
+		// Backquoted names in the usage strings are treated as the argument placeholders by
+		// flag.PrintDefaults, which would garble the help output.
+		require.NotContains(t, f.Usage, "`", "flag %q", f.Name)
+	})
+}
@@ -29,6 +29,21 @@ const StableRoundLimit = 5
 // NilAway from inferring the annotations for that package - this is useful for unit tests
 const NilAwayNoInferString = "<nilaway no inference>"
 
+// DefaultNonnilReturnsDirective is the directive that may be inserted into the doc comment of a
+// package to annotate the results of all exported functions in the package as nonnil by default,
+// such that only the exceptions need explicit (e.g., `nilable(result 0)`) annotations.
+const DefaultNonnilReturnsDirective = "//nilaway:package default-nonnil-returns"
+
+// NilableKeyword is the keyword for annotating a site as nilable, e.g., `// nilable(x)`.
+const NilableKeyword = "nilable"
+
+// NonNilKeyword is the keyword for annotating a site as nonnil, e.g., `// nonnil(x)`.
+const NonNilKeyword = "nonnil"
+
+// NonEmptyKeyword is the keyword for annotating a slice as always non-empty, e.g., `// nonempty(s)`.
+// Since a nil slice is empty, the slice is treated as nonnil (and can hence be indexed safely).
+const NonEmptyKeyword = "nonempty"
+
 const uberPkgPathPrefix = "go.uber.org"
 
 // NilAwayPkgPathPrefix is the package prefix for NilAway.
@@ -0,0 +1,90 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/analysis"
+)
+
+// moduleFact is the package fact that records the path of the module the package belongs to, such
+// that the packages of the excluded modules (see ExcludeModulesFlag) can be identified even when
+// they are referenced from the downstream packages. It is only exported if any module is excluded.
+type moduleFact struct {
+	// Path is the module path, e.g., "github.com/foo/bar".
+	Path string
+}
+
+// AFact implements the analysis.Fact interface.
+func (*moduleFact) AFact() {}
+
+func (f *moduleFact) String() string {
+	return "module " + f.Path
+}
+
+// isModuleExcluded returns true iff the package with the given path belongs to one of the
+// excluded modules.
+func (c *Config) isModuleExcluded(pkgPath string) bool {
+	if len(c.excludeModules) == 0 {
+		return false
+	}
+	module, ok := c.modules[pkgPath]
+	return ok && slices.Contains(c.excludeModules, module)
+}
+
+// collectModules exports the module of the package of the pass as a fact, and returns the modules
+// of the package and all its dependencies, keyed by the package paths.
+func collectModules(pass *analysis.Pass) map[string]string {
+	modules := make(map[string]string)
+	for _, f := range pass.AllPackageFacts() {
+		if fact, ok := f.Fact.(*moduleFact); ok {
+			modules[f.Package.Path()] = fact.Path
+		}
+	}
+	if len(pass.Files) == 0 {
+		return modules
+	}
+	if module := findModule(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name())); module != "" {
+		pass.ExportPackageFact(&moduleFact{Path: module})
+		modules[pass.Pkg.Path()] = module
+	}
+	return modules
+}
+
+// findModule returns the path of the module that the directory belongs to, i.e., the module
+// declared in the go.mod file of the closest ancestor directory (including itself). It returns an
+// empty string if no go.mod file is found, or if the directory is a vendored package (i.e., under
+// a "vendor" directory of the module), whose module cannot be determined this way.
+func findModule(dir string) string {
+	for d := dir; ; {
+		if data, err := os.ReadFile(filepath.Join(d, "go.mod")); err == nil {
+			rel, err := filepath.Rel(d, dir)
+			if err != nil || slices.Contains(strings.Split(filepath.ToSlash(rel), "/"), "vendor") {
+				return ""
+			}
+			return modfile.ModulePath(data)
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return ""
+		}
+		d = parent
+	}
+}
@@ -0,0 +1,59 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindModule(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for dir, module := range map[string]string{"": "example.com/app", "lib": "example.com/app/lib"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "go.mod"), []byte("module "+module+"\n"), 0o644))
+	}
+
+	require.Equal(t, "example.com/app", findModule(root))
+	require.Equal(t, "example.com/app", findModule(filepath.Join(root, "libutil", "internal")))
+	require.Equal(t, "example.com/app/lib", findModule(filepath.Join(root, "lib", "sub")))
+	// The modules of the vendored packages are unknown.
+	require.Empty(t, findModule(filepath.Join(root, "vendor", "github.com", "foo")))
+}
+
+func TestIsPkgInScope_ExcludeModules(t *testing.T) {
+	t.Parallel()
+
+	conf := &Config{
+		includePkgs:    []string{""},
+		excludeModules: []string{"example.com/app/lib"},
+		modules: map[string]string{
+			"example.com/app/lib":     "example.com/app/lib",
+			"example.com/app/lib/sub": "example.com/app/lib",
+			"example.com/app/libutil": "example.com/app",
+		},
+	}
+	require.False(t, conf.IsPkgInScope(types.NewPackage("example.com/app/lib", "lib")))
+	require.False(t, conf.IsPkgInScope(types.NewPackage("example.com/app/lib/sub", "sub")))
+	require.True(t, conf.IsPkgInScope(types.NewPackage("example.com/app/libutil", "libutil")))
+	// The packages of unknown modules are not excluded.
+	require.True(t, conf.IsPkgInScope(types.NewPackage("example.com/other", "other")))
+}
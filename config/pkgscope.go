@@ -0,0 +1,91 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// pkgScopeFlag is a flag.Value that enables a feature either for all packages or for a list of
+// package patterns. It behaves like a boolean flag (i.e., `-flag`, `-flag=true` and `-flag=false`
+// are all accepted), but additionally accepts a comma-separated list of package patterns (e.g.,
+// `-flag=github.com/foo/...,github.com/bar`), where a pattern ending with "/..." matches the
+// package and all its subpackages, and any other pattern is treated as a package prefix (similar
+// to the include / exclude package lists).
+type pkgScopeFlag struct {
+	// pkgs is the list of package patterns the feature is enabled for, where nil means the feature
+	// is disabled and an empty pattern matches all packages.
+	pkgs []string
+}
+
+// String returns the string representation of the flag value.
+func (f *pkgScopeFlag) String() string {
+	if f == nil || f.pkgs == nil {
+		return "false"
+	}
+	if len(f.pkgs) == 1 && f.pkgs[0] == "" {
+		return "true"
+	}
+	return strings.Join(f.pkgs, ",")
+}
+
+// Set parses the flag value, which is either a boolean or a comma-separated list of patterns.
+func (f *pkgScopeFlag) Set(s string) error {
+	if b, err := strconv.ParseBool(s); err == nil {
+		f.pkgs = nil
+		if b {
+			f.pkgs = []string{""}
+		}
+		return nil
+	}
+
+	f.pkgs = nil
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			f.pkgs = append(f.pkgs, p)
+		}
+	}
+	return nil
+}
+
+// Get returns the list of package patterns the feature is enabled for.
+func (f *pkgScopeFlag) Get() any {
+	return f.pkgs
+}
+
+// IsBoolFlag allows the flag to be specified without a value (i.e., `-flag`), which enables the
+// feature for all packages. As a result, the patterns must be given with "=" (i.e., `-flag=...`),
+// since the flag package does not consume the next argument as the value of a boolean flag.
+func (f *pkgScopeFlag) IsBoolFlag() bool {
+	return true
+}
+
+// matchesAnyPkgPattern returns true iff the package path matches any of the patterns (see
+// pkgScopeFlag for the pattern syntax).
+func matchesAnyPkgPattern(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if base, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if path == base || strings.HasPrefix(path, base+"/") {
+				return true
+			}
+			continue
+		}
+		if strings.HasPrefix(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
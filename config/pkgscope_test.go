@@ -0,0 +1,96 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"flag"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPkgScopeFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		pkgs  []string
+		str   string
+	}{
+		{name: "True", value: "true", pkgs: []string{""}, str: "true"},
+		{name: "False", value: "false", pkgs: nil, str: "false"},
+		{name: "Empty", value: "", pkgs: nil, str: "false"},
+		{name: "Patterns", value: "github.com/foo/..., github.com/bar,", pkgs: []string{"github.com/foo/...", "github.com/bar"}, str: "github.com/foo/...,github.com/bar"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := &pkgScopeFlag{}
+			require.NoError(t, f.Set(tt.value))
+			require.Equal(t, tt.pkgs, f.Get())
+			require.Equal(t, tt.str, f.String())
+		})
+	}
+}
+
+func TestPkgScopeFlag_Parse(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	require.NoError(t, fs.Parse([]string{"-" + ExperimentalStructInitEnableFlag + "=github.com/foo/...", "-" + ExperimentalAnonymousFunctionFlag, "github.com/bar"}))
+	require.Equal(t, []string{"github.com/foo/..."}, fs.Lookup(ExperimentalStructInitEnableFlag).Value.(flag.Getter).Get())
+	// The patterns must be given with "=", otherwise the flag is enabled for all packages and the
+	// patterns are left as the remaining arguments.
+	require.Equal(t, []string{""}, fs.Lookup(ExperimentalAnonymousFunctionFlag).Value.(flag.Getter).Get())
+	require.Equal(t, []string{"github.com/bar"}, fs.Args())
+}
+
+func TestIsExperimentalFeatureEnabled(t *testing.T) {
+	t.Parallel()
+
+	conf := &Config{
+		experimentalStructInitPkgs:    []string{"github.com/foo/...", "github.com/bar"},
+		experimentalAnonymousFuncPkgs: []string{""},
+	}
+	tests := []struct {
+		path    string
+		enabled bool
+	}{
+		{path: "github.com/foo", enabled: true},
+		{path: "github.com/foo/sub", enabled: true},
+		{path: "github.com/foobar", enabled: false},
+		{path: "github.com/bar", enabled: true},
+		{path: "github.com/barbaz", enabled: true},
+		{path: "github.com/baz", enabled: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := types.NewPackage(tt.path, "main")
+			require.Equal(t, tt.enabled, conf.IsExperimentalStructInitEnabled(pkg))
+			require.True(t, conf.IsExperimentalAnonymousFuncEnabled(pkg))
+			require.False(t, (&Config{}).IsExperimentalStructInitEnabled(pkg))
+			// The deprecated fields enable the features for all packages.
+			require.True(t, (&Config{ExperimentalStructInitEnable: true}).IsExperimentalStructInitEnabled(pkg))
+			require.True(t, (&Config{ExperimentalAnonymousFuncEnable: true}).IsExperimentalAnonymousFuncEnabled(pkg))
+		})
+	}
+}
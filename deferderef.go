@@ -0,0 +1,63 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nilaway
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// DeferDerefCategory is the category of the diagnostics occurring within deferred function
+// literals if the `defer-derefs` flag is set to "categorize".
+const DeferDerefCategory = "nilaway/defer-deref"
+
+// deferredRange is the range of the body of a deferred function literal.
+type deferredRange struct {
+	start, end token.Pos
+}
+
+// deferredRanges returns the ranges of the bodies of the function literals that are directly
+// deferred (e.g., `defer func() { ... }()`) in all files of the package. Note that the arguments
+// of the deferred calls are not included since they are evaluated when the defer statement is
+// executed instead of during cleanup.
+func deferredRanges(pass *analysis.Pass) []deferredRange {
+	var ranges []deferredRange
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			stmt, ok := node.(*ast.DeferStmt)
+			if !ok {
+				return true
+			}
+			if lit, ok := astutil.Unparen(stmt.Call.Fun).(*ast.FuncLit); ok {
+				ranges = append(ranges, deferredRange{start: lit.Body.Pos(), end: lit.Body.End()})
+			}
+			return true
+		})
+	}
+	return ranges
+}
+
+// isDeferred returns true if the given position falls in any of the deferred ranges.
+func isDeferred(ranges []deferredRange, pos token.Pos) bool {
+	for _, r := range ranges {
+		if r.start <= pos && pos < r.end {
+			return true
+		}
+	}
+	return false
+}
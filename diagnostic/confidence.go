@@ -0,0 +1,73 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"strings"
+
+	"go.uber.org/nilaway/config"
+)
+
+// _confidencePrefix is the prefix of the line appended to the diagnostic messages for the
+// confidence levels.
+const _confidencePrefix = "Confidence: "
+
+// _maxMediumConfidenceFlowLen is the maximum number of nodes in the nil flow (i.e., the steps
+// from the nil source to the dereference point) of a medium-confidence conflict, where the longer
+// flows are of low confidence since they rely more on the inference.
+const _maxMediumConfidenceFlowLen = 3
+
+// _confidenceRanks ranks the confidence levels, where a higher rank means a higher confidence.
+var _confidenceRanks = map[string]int{
+	config.ConfidenceLow:    0,
+	config.ConfidenceMedium: 1,
+	config.ConfidenceHigh:   2,
+}
+
+// SetMinConfidence sets the minimum confidence level (one of the config.Confidence* constants) of
+// the conflicts to be reported, where the reported ones are labeled with their levels. Empty
+// level means that all conflicts are reported without the levels.
+func (e *Engine) SetMinConfidence(level string) {
+	e.minConfidence = level
+}
+
+// confidence returns the confidence level of the nil flow. The definite flows (i.e., the ones
+// whose producers and consumers fail regardless of the nilability of any sites) are of high
+// confidence, and the others are of medium or low confidence depending on their lengths.
+func (n *nilFlow) confidence(definite bool) string {
+	switch {
+	case definite:
+		return config.ConfidenceHigh
+	case len(n.nilPath)+len(n.nonnilPath) > _maxMediumConfidenceFlowLen:
+		return config.ConfidenceLow
+	default:
+		return config.ConfidenceMedium
+	}
+}
+
+// meetsMinConfidence returns true if the conflict of the given confidence level should be
+// reported under the configured minimum confidence level.
+func (e *Engine) meetsMinConfidence(level string) bool {
+	return e.minConfidence == "" || _confidenceRanks[level] >= _confidenceRanks[e.minConfidence]
+}
+
+// withConfidence appends the confidence level as a separate line to the message if a minimum
+// confidence level is configured, and returns the message as is otherwise.
+func (e *Engine) withConfidence(message, level string) string {
+	if e.minConfidence == "" {
+		return message
+	}
+	return strings.TrimSuffix(message, "\n") + "\n" + _confidencePrefix + level
+}
@@ -30,6 +30,12 @@ type conflict struct {
 	position token.Position
 	// flow stores nil flow from source to dereference point
 	flow nilFlow
+	// category is the category of the diagnostic reported for the conflict, e.g.,
+	// DiscardedErrorCategory, or empty for the default category.
+	category string
+	// confidence is the confidence level of the conflict (one of the config.Confidence*
+	// constants), which is derived from its nil flow (see nilFlow.confidence).
+	confidence string
 	// similarConflicts stores other conflicts that are similar to this one.
 	similarConflicts []*conflict
 }
@@ -57,6 +63,28 @@ func (c *conflict) String() string {
 		"source to dereference point: %s%s\n", c.flow.String(), similarConflictsString)
 }
 
+// explainedString is like String, but it also includes the explanations of the similar conflicts,
+// i.e., the parts of their nil flows from the conflict point to their own dereference points. The
+// parts from the nil source to the conflict point are shared with this conflict (see
+// groupConflicts), so they are not repeated.
+func (c *conflict) explainedString() string {
+	if len(c.similarConflicts) == 0 {
+		return c.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(c.String(), "\n"))
+	b.WriteString("\n\nObserved nil flow(s) from the same source to the other place(s):")
+	for _, s := range c.similarConflicts {
+		fmt.Fprintf(&b, "\n\"%s\":", s.flow.nonnilPath[len(s.flow.nonnilPath)-1].consumerPosition.String())
+		for _, n := range s.flow.nonnilPath {
+			b.WriteString("\n" + n.String())
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
 func (c *conflict) addSimilarConflict(conflict conflict) {
 	c.similarConflicts = append(c.similarConflicts, &conflict)
 }
@@ -0,0 +1,88 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"go/token"
+	"strings"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
+)
+
+// DiscardedErrorCategory is the category of the diagnostics rooted in the value results whose
+// error results are explicitly discarded (e.g., `v, _ := f()`) if the `discarded-errors` flag is
+// set to "categorize".
+const DiscardedErrorCategory = "nilaway/discarded-error"
+
+// _intentionalDiscardDirective is the comment that marks the discard of an error result on the
+// same line as intentional (e.g., the error can never happen for the given arguments), such that
+// the uses of the value results are not reported. An optional reason may follow the directive.
+const _intentionalDiscardDirective = "//nilaway:intentional-discard"
+
+// SetDiscardedErrors sets how the engine reports the conflicts rooted in the value results whose
+// error results are explicitly discarded, and the mode is one of the config.DiscardedErrors*
+// constants.
+func (e *Engine) SetDiscardedErrors(mode string) {
+	e.discardedErrors = mode
+}
+
+// isErrDiscarded returns true if the nil flow is rooted in a value result whose error result is
+// explicitly discarded.
+func (n *nilFlow) isErrDiscarded() bool {
+	p, ok := n.root().producer.(annotation.GuardMissingPrestring)
+	return ok && p.ErrDiscarded
+}
+
+// discardedErrorCategory returns the category of the conflict with the given nil flow rooted at
+// the given position, and false if the conflict should be dropped instead. The conflicts rooted
+// in intentional discards (see _intentionalDiscardDirective) are always dropped.
+func (e *Engine) discardedErrorCategory(flow nilFlow, root token.Position) (string, bool) {
+	if !flow.isErrDiscarded() {
+		return "", true
+	}
+	if e.isIntentionalDiscard(root) || e.discardedErrors == config.DiscardedErrorsIgnore {
+		return "", false
+	}
+	if e.discardedErrors == config.DiscardedErrorsCategorize {
+		return DiscardedErrorCategory, true
+	}
+	return "", true
+}
+
+// isIntentionalDiscard returns true if the line of the position in the current package carries
+// the _intentionalDiscardDirective comment.
+func (e *Engine) isIntentionalDiscard(position token.Position) bool {
+	if e.intentionalDiscards == nil {
+		e.intentionalDiscards = make(map[string]map[int]bool)
+		for _, file := range e.pass.Files {
+			for _, group := range file.Comments {
+				for _, c := range group.List {
+					text := strings.TrimSpace(c.Text)
+					if text != _intentionalDiscardDirective && !strings.HasPrefix(text, _intentionalDiscardDirective+" ") {
+						continue
+					}
+					p := e.pass.Fset.Position(c.Pos())
+					name := e.trimFilename(p.Filename)
+					if e.intentionalDiscards[name] == nil {
+						e.intentionalDiscards[name] = make(map[int]bool)
+					}
+					e.intentionalDiscards[name][p.Line] = true
+				}
+			}
+		}
+	}
+	return e.intentionalDiscards[e.trimFilename(position.Filename)][position.Line]
+}
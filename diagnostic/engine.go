@@ -21,11 +21,13 @@ import (
 	"cmp"
 	"fmt"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
 	"slices"
 
 	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/config"
 	"go.uber.org/nilaway/inference"
 	"go.uber.org/nilaway/util"
 	"golang.org/x/tools/go/analysis"
@@ -42,6 +44,21 @@ type fileInfo struct {
 type Engine struct {
 	pass      *analysis.Pass
 	conflicts []conflict
+	// redundantAnnotations stores the annotations that have no effect on the analysis, which are
+	// reported after the conflicts (see AddRedundantAnnotation).
+	redundantAnnotations []inference.RedundantAnnotation
+	// relaxableAnnotations stores the parameter annotations that all call sites already satisfy,
+	// which are reported after the redundant annotations (see AddRelaxableAnnotation).
+	relaxableAnnotations []inference.RelaxableAnnotation
+	// contractViolations stores the violations of the annotations on the exported API, which are
+	// reported after the relaxable annotations (see AddContractViolation).
+	contractViolations []inference.ContractViolation
+	// inferredAnnotations stores the inferred annotations of the exported API, which are reported
+	// after the contract violations (see AddInferredAnnotation).
+	inferredAnnotations []inference.InferredAnnotation
+	// nilabilityReports stores the nilability reports of the functions, which are reported after
+	// the inferred annotations (see AddNilabilityReport).
+	nilabilityReports []inference.FuncNilability
 	// files maps the file name (modulo the possible build-system prefix) to the token.File object
 	// for faster lookup when converting correct upstream position back to local token.Pos for
 	// reporting purposes.
@@ -49,6 +66,28 @@ type Engine struct {
 	// cwd is the current working directory for trimming the file names to get truly package- and
 	// build-system- (bazel for example adds a random sandbox prefix) independent positions.
 	cwd string
+	// hints indicates whether a one-line hint for fixing the issue should be appended to each
+	// diagnostic message (see EnableHints).
+	hints bool
+	// rootScope is the config for checking the packages of the nil sources if only the conflicts
+	// whose nil sources are in the packages in scope should be kept (see EnableRootInScopeOnly),
+	// and is nil otherwise.
+	rootScope *config.Config
+	// discardedErrors controls how the conflicts rooted in discarded errors are reported (see
+	// SetDiscardedErrors).
+	discardedErrors string
+	// intentionalDiscards stores the lines (indexed by the trimmed file names) marked as
+	// intentional discards of errors, which is lazily populated (see isIntentionalDiscard).
+	intentionalDiscards map[string]map[int]bool
+	// explanationsInGroups indicates whether the grouped diagnostics should also include the
+	// explanations of the similar conflicts (see EnableExplanationsInGroups).
+	explanationsInGroups bool
+	// minConfidence is the minimum confidence level of the conflicts to be reported, or empty if
+	// all conflicts are reported without the levels (see SetMinConfidence).
+	minConfidence string
+	// oncePerSite indicates whether the conflicts at the same site should be collapsed into one
+	// (see EnableReportOncePerSite).
+	oncePerSite bool
 }
 
 // NewEngine creates a new diagnostic engine.
@@ -96,6 +135,35 @@ func NewEngine(pass *analysis.Pass) *Engine {
 	return &Engine{pass: pass, files: files, cwd: cwd}
 }
 
+// EnableHints makes the engine append a one-line hint for fixing the issue (e.g., "add a nil
+// check before this dereference") to each diagnostic message.
+func (e *Engine) EnableHints() {
+	e.hints = true
+}
+
+// EnableExplanationsInGroups makes the engine include the explanations of the similar conflicts
+// (i.e., their nil flows to their own dereference points) in the grouped diagnostics, instead of
+// only listing their positions.
+func (e *Engine) EnableExplanationsInGroups() {
+	e.explanationsInGroups = true
+}
+
+// EnableRootInScopeOnly makes the engine drop the overconstraint conflicts whose nil sources (i.e.,
+// the roots of the nil flows) are not in the packages in scope of the given config, e.g., the
+// nilable values returned from the out-of-scope dependencies, which are usually not actionable.
+func (e *Engine) EnableRootInScopeOnly(conf *config.Config) {
+	e.rootScope = conf
+}
+
+// trimFilename trims the build system prefix (i.e., the current working directory) from the file
+// name if possible, such that the names match the ones in the positions of the inference.
+func (e *Engine) trimFilename(name string) string {
+	if rel, err := filepath.Rel(e.cwd, name); err == nil {
+		return rel
+	}
+	return name
+}
+
 // Diagnostics generates diagnostics from the internally-stored conflicts. The grouping parameter
 // controls whether the conflicts with the same nil flow -- the part in the complete nil flow going
 // from a nilable source point to the conflict point -- are grouped together (under the first
@@ -112,22 +180,84 @@ func (e *Engine) Diagnostics(grouping bool) []analysis.Diagnostic {
 	})
 
 	conflicts := e.conflicts
+	if e.oncePerSite {
+		conflicts = oneConflictPerSite(conflicts)
+	}
 	if grouping {
 		// Group conflicts with the same nil path together for concise reporting.
-		conflicts = groupConflicts(e.conflicts, e.pass, e.cwd)
+		conflicts = groupConflicts(conflicts, e.pass, e.cwd)
 	}
 
 	// Build diagnostics from conflicts.
 	diagnostics := make([]analysis.Diagnostic, 0, len(conflicts))
 	for _, c := range conflicts {
+		message := c.String()
+		if e.explanationsInGroups {
+			message = c.explainedString()
+		}
+		diagnostics = append(diagnostics, analysis.Diagnostic{
+			Pos:      e.toPos(c.position),
+			Category: c.category,
+			Message:  e.withHint(e.withConfidence(message, c.confidence), c.flow.hint()),
+		})
+	}
+	for _, r := range e.redundantAnnotations {
+		kind, reason := "nonnil", "all values returned through it are already known to be nonnil"
+		if r.IsNilable {
+			kind, reason = "nilable", "no potentially nil value is ever returned through it"
+		}
+		diagnostics = append(diagnostics, analysis.Diagnostic{
+			Pos: r.Key.Object().Pos(),
+			Message: e.withHint(fmt.Sprintf("Redundant annotation: `%s` annotation on %s has no effect, since %s", kind, r.Key.String(), reason),
+				_redundantAnnotationHint),
+		})
+	}
+	for _, r := range e.relaxableAnnotations {
+		diagnostics = append(diagnostics, analysis.Diagnostic{
+			Pos: r.Key.Object().Pos(),
+			Message: e.withHint(fmt.Sprintf("Relaxable annotation: `nonnil` annotation on %s adds no safety, since all %d call site(s) in this package already pass nonnil values",
+				r.Key.String(), r.NumCallSites), _relaxableAnnotationHint),
+		})
+	}
+	for _, v := range e.contractViolations {
+		kind, reason, hint := "nonnil", fmt.Sprintf("%s is returned through it", v.Reason), _nonnilContractHint
+		if v.IsNilable {
+			kind, reason, hint = "nilable", fmt.Sprintf("it is unconditionally %s", v.Reason), _nilableContractHint
+		}
 		diagnostics = append(diagnostics, analysis.Diagnostic{
-			Pos:     e.toPos(c.position),
-			Message: c.String(),
+			Pos:     v.Pos,
+			Message: e.withHint(fmt.Sprintf("API contract violation: `%s` annotation on %s is violated, since %s", kind, v.Key.String(), reason), hint),
 		})
 	}
+	diagnostics = append(diagnostics, e.inferredAnnotationDiagnostics()...)
+	diagnostics = append(diagnostics, e.nilabilityReportDiagnostics()...)
 	return diagnostics
 }
 
+// AddContractViolation adds a new violation of an annotation on the exported API to the engine,
+// which will be reported at the position of the violation.
+func (e *Engine) AddContractViolation(v inference.ContractViolation) {
+	e.contractViolations = append(e.contractViolations, v)
+}
+
+// DiscardConflicts discards all conflicts added to the engine so far, such that only the
+// annotation-related diagnostics (e.g., contract violations) are reported.
+func (e *Engine) DiscardConflicts() {
+	e.conflicts = nil
+}
+
+// AddRelaxableAnnotation adds a new relaxable parameter annotation to the engine, which will be
+// reported at the position of the annotated parameter.
+func (e *Engine) AddRelaxableAnnotation(r inference.RelaxableAnnotation) {
+	e.relaxableAnnotations = append(e.relaxableAnnotations, r)
+}
+
+// AddRedundantAnnotation adds a new redundant annotation to the engine, which will be reported
+// at the position of the annotated object.
+func (e *Engine) AddRedundantAnnotation(r inference.RedundantAnnotation) {
+	e.redundantAnnotations = append(e.redundantAnnotations, r)
+}
+
 // AddSingleAssertionConflict adds a new single assertion conflict to the engine.
 func (e *Engine) AddSingleAssertionConflict(trigger annotation.FullTrigger) {
 	producer, consumer := trigger.Prestrings(e.pass)
@@ -141,14 +271,37 @@ func (e *Engine) AddSingleAssertionConflict(trigger annotation.FullTrigger) {
 	if filename, err := filepath.Rel(e.cwd, position.Filename); err == nil {
 		position.Filename = filename
 	}
+	var root token.Position
+	if trigger.Producer.Expr != nil {
+		root = e.pass.Fset.Position(trigger.Producer.Expr.Pos())
+	}
+	category, ok := e.discardedErrorCategory(flow, root)
+	if !ok {
+		return
+	}
+	definite := trigger.Producer.Annotation.Kind() == annotation.Always && trigger.Consumer.Annotation.Kind() == annotation.Always
+	confidence := flow.confidence(definite)
+	if !e.meetsMinConfidence(confidence) {
+		return
+	}
 	e.conflicts = append(e.conflicts, conflict{
-		position: position,
-		flow:     flow,
+		position:   position,
+		flow:       flow,
+		category:   category,
+		confidence: confidence,
 	})
 }
 
 // AddOverconstraintConflict adds a new overconstraint conflict to the engine.
 func (e *Engine) AddOverconstraintConflict(nilReason, nonnilReason inference.ExplainedBool) {
+	root := nilReason
+	for root.DeeperReason() != nil {
+		root = root.DeeperReason()
+	}
+	if e.rootScope != nil && !e.rootScope.IsPkgInScope(types.NewPackage(root.PkgPath(), "")) {
+		return
+	}
+
 	flow := nilFlow{}
 
 	// Build nil path by traversing the inference graph from `nilReason` part of the overconstraint failure.
@@ -194,9 +347,19 @@ func (e *Engine) AddOverconstraintConflict(nilReason, nonnilReason inference.Exp
 		}
 	}
 
+	category, ok := e.discardedErrorCategory(flow, root.Position())
+	if !ok {
+		return
+	}
+	confidence := flow.confidence(false /* definite */)
+	if !e.meetsMinConfidence(confidence) {
+		return
+	}
 	e.conflicts = append(e.conflicts, conflict{
-		position: reportPosition,
-		flow:     flow,
+		position:   reportPosition,
+		flow:       flow,
+		category:   category,
+		confidence: confidence,
 	})
 }
 
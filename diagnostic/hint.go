@@ -0,0 +1,117 @@
+//  Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/nilaway/annotation"
+)
+
+// _hintPrefix is the prefix of the line appended to the diagnostic messages for the hints.
+const _hintPrefix = "Hint: "
+
+// Hints for the potential nil panics, chosen by the root cause or the dereference point of the
+// nil flow (see nilFlow.hint).
+const (
+	_checkErrorHintFormat     = "check the error returned by `%s()` before using its other results"
+	_discardedErrorHintFormat = "check the error returned by `%s()` instead of discarding it"
+	_commaOkHint              = "use the comma-ok form (e.g., `v, ok := m[k]`) and check `ok` before using the value"
+	_unassignedVarHint        = "assign a nonnil value to the variable on all paths before using it"
+	_unassignedFieldHint      = "initialize the field with a nonnil value when the struct is created"
+	_derefHint                = "add a nil check (e.g., `if x != nil { ... }`) before this dereference"
+	_sliceIndexHint           = "check the length of the slice (e.g., `if len(s) > i { ... }`) before indexing into it"
+	_mapWriteHint             = "initialize the map (e.g., with `make`) before writing to it"
+	_methodCallHintFormat     = "add a nil check before calling `%s()`, or make the method handle a nil receiver"
+	_defaultNilFlowHint       = "add a nil check before the value reaches this point, or annotate the receiving site as `nilable` if nil is expected"
+)
+
+// Hints for the annotation-related diagnostics, which only depend on the kind of the diagnostic.
+const (
+	_redundantAnnotationHint = "remove the annotation"
+	_relaxableAnnotationHint = "consider removing the `nonnil` annotation to accept nil arguments"
+	_nonnilContractHint      = "make sure a nonnil value is returned on all paths, or change the annotation to `nilable`"
+	_nilableContractHint     = "handle the nil case before the use, or change the annotation to `nonnil`"
+)
+
+// withHint appends the hint as a separate line to the message if the hints are enabled, and
+// returns the message as is otherwise.
+func (e *Engine) withHint(message, hint string) string {
+	if !e.hints {
+		return message
+	}
+	return strings.TrimSuffix(message, "\n") + "\n" + _hintPrefix + hint
+}
+
+// hint returns a one-line suggestion for fixing the nil flow. The suggestion is chosen by the
+// root cause of the flow (i.e., the producer of the first node) if it is specific enough (e.g.,
+// an unchecked error or a missing comma-ok check), and by the category of the dereference point
+// (i.e., the consumer of the last node) otherwise.
+func (n *nilFlow) hint() string {
+	var last node
+	switch {
+	case len(n.nonnilPath) > 0:
+		last = n.nonnilPath[len(n.nonnilPath)-1]
+	case len(n.nilPath) > 0:
+		last = n.nilPath[len(n.nilPath)-1]
+	}
+
+	if h := rootCauseHint(n.root().producer); h != "" {
+		return h
+	}
+
+	switch c := last.consumer.(type) {
+	case annotation.PtrLoadPrestring, annotation.FldAccessPrestring:
+		return _derefHint
+	case annotation.SliceAccessPrestring:
+		return _sliceIndexHint
+	case annotation.MapWrittenToPrestring:
+		return _mapWriteHint
+	case annotation.RecvPassPrestring:
+		return fmt.Sprintf(_methodCallHintFormat, c.FuncName)
+	default:
+		return _defaultNilFlowHint
+	}
+}
+
+// rootCauseHint returns the hint specific to the root cause (i.e., the source of nilability) of
+// a nil flow, or an empty string if the root cause does not call for a specific hint.
+func rootCauseHint(p annotation.Prestring) string {
+	switch p := p.(type) {
+	case annotation.GuardMissingPrestring:
+		funcName := ""
+		switch old := p.OldPrestring.(type) {
+		case annotation.FuncReturnPrestring:
+			funcName = old.FuncName
+		case annotation.MethodReturnPrestring:
+			funcName = old.FuncName
+		}
+		switch {
+		case funcName != "" && p.ErrDiscarded:
+			return fmt.Sprintf(_discardedErrorHintFormat, funcName)
+		case funcName != "":
+			return fmt.Sprintf(_checkErrorHintFormat, funcName)
+		default:
+			return _commaOkHint
+		}
+	case annotation.NoVarAssignPrestring:
+		return _unassignedVarHint
+	case annotation.UnassignedFldPrestring:
+		return _unassignedFieldHint
+	default:
+		return ""
+	}
+}
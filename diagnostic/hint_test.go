@@ -0,0 +1,138 @@
+//  Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/nilaway/annotation"
+)
+
+func TestNilFlowHint(t *testing.T) {
+	t.Parallel()
+
+	type step struct {
+		producer, consumer annotation.Prestring
+	}
+	tests := []struct {
+		name       string
+		nilPath    []step
+		nonnilPath []step
+		expected   string
+	}{
+		{
+			name:       "dereference",
+			nonnilPath: []step{{annotation.ConstNilPrestring{}, annotation.PtrLoadPrestring{}}},
+			expected:   "add a nil check (e.g., `if x != nil { ... }`) before this dereference",
+		},
+		{
+			name: "field access at the end of a longer flow",
+			nilPath: []step{
+				{annotation.ConstNilPrestring{}, annotation.UseAsReturnPrestring{FuncName: "f"}},
+			},
+			nonnilPath: []step{
+				{annotation.FuncReturnPrestring{FuncName: "f"}, annotation.FldAccessPrestring{FieldName: "x"}},
+			},
+			expected: "add a nil check (e.g., `if x != nil { ... }`) before this dereference",
+		},
+		{
+			name:       "slice index",
+			nonnilPath: []step{{annotation.ConstNilPrestring{}, annotation.SliceAccessPrestring{}}},
+			expected:   "check the length of the slice (e.g., `if len(s) > i { ... }`) before indexing into it",
+		},
+		{
+			name:       "map write",
+			nonnilPath: []step{{annotation.ConstNilPrestring{}, annotation.MapWrittenToPrestring{}}},
+			expected:   "initialize the map (e.g., with `make`) before writing to it",
+		},
+		{
+			name:       "method call",
+			nonnilPath: []step{{annotation.ConstNilPrestring{}, annotation.RecvPassPrestring{FuncName: "m"}}},
+			expected:   "add a nil check before calling `m()`, or make the method handle a nil receiver",
+		},
+		{
+			name:       "other consumer",
+			nonnilPath: []step{{annotation.ConstNilPrestring{}, annotation.ArgPassPrestring{FuncName: "f"}}},
+			expected:   "add a nil check before the value reaches this point, or annotate the receiving site as `nilable` if nil is expected",
+		},
+		{
+			name: "unchecked error",
+			nonnilPath: []step{{
+				annotation.GuardMissingPrestring{OldPrestring: annotation.FuncReturnPrestring{FuncName: "f"}},
+				annotation.PtrLoadPrestring{},
+			}},
+			expected: "check the error returned by `f()` before using its other results",
+		},
+		{
+			name: "discarded error",
+			nilPath: []step{{
+				annotation.GuardMissingPrestring{OldPrestring: annotation.MethodReturnPrestring{FuncName: "m"}, ErrDiscarded: true},
+				annotation.UseAsReturnPrestring{FuncName: "g"},
+			}},
+			nonnilPath: []step{{annotation.FuncReturnPrestring{FuncName: "g"}, annotation.PtrLoadPrestring{}}},
+			expected:   "check the error returned by `m()` instead of discarding it",
+		},
+		{
+			name: "unguarded map read",
+			nonnilPath: []step{{
+				annotation.GuardMissingPrestring{OldPrestring: annotation.StrictMapReadPrestring{}},
+				annotation.PtrLoadPrestring{},
+			}},
+			expected: "use the comma-ok form (e.g., `v, ok := m[k]`) and check `ok` before using the value",
+		},
+		{
+			name: "unassigned variable",
+			nonnilPath: []step{{
+				annotation.LocatedPrestring{Contained: annotation.NoVarAssignPrestring{VarName: "v"}},
+				annotation.PtrLoadPrestring{},
+			}},
+			expected: "assign a nonnil value to the variable on all paths before using it",
+		},
+		{
+			name:       "unassigned field",
+			nonnilPath: []step{{annotation.UnassignedFldPrestring{}, annotation.FldAccessPrestring{FieldName: "x"}}},
+			expected:   "initialize the field with a nonnil value when the struct is created",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			flow := nilFlow{}
+			// The nil path is built backwards (see addNilPathNode).
+			for i := len(tt.nilPath) - 1; i >= 0; i-- {
+				flow.addNilPathNode(tt.nilPath[i].producer, tt.nilPath[i].consumer)
+			}
+			for _, s := range tt.nonnilPath {
+				flow.addNonNilPathNode(s.producer, s.consumer)
+			}
+			require.Equal(t, tt.expected, flow.hint())
+		})
+	}
+}
+
+func TestWithHint(t *testing.T) {
+	t.Parallel()
+
+	e := &Engine{}
+	require.Equal(t, "message\n", e.withHint("message\n", "fix it"))
+
+	e.EnableHints()
+	require.Equal(t, "message\nHint: fix it", e.withHint("message\n", "fix it"))
+	require.Equal(t, "message\nHint: fix it", e.withHint("message", "fix it"))
+}
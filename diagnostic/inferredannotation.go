@@ -0,0 +1,122 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/inference"
+	"golang.org/x/tools/go/analysis"
+)
+
+// InferredAnnotationCategory is the category of the diagnostics suggesting the annotations
+// inferred for the exported API, whose suggested fixes insert the annotations into the source if
+// the `apply-inferred-annotations` flag is set.
+const InferredAnnotationCategory = "nilaway/inferred-annotation"
+
+// AddInferredAnnotation adds a new inferred annotation to the engine, which will be reported
+// (along with the other inferred annotations of the same function) at the function declaration.
+func (e *Engine) AddInferredAnnotation(a inference.InferredAnnotation) {
+	e.inferredAnnotations = append(e.inferredAnnotations, a)
+}
+
+// inferredAnnotationDiagnostics returns one diagnostic per function with inferred annotations,
+// whose suggested fix inserts the annotations as doc comments (e.g., `// nilable(p, result 0)`)
+// right above the function declaration, such that they are read back as explicit annotations.
+func (e *Engine) inferredAnnotationDiagnostics() []analysis.Diagnostic {
+	// Group the annotations by their functions in the order they were added, i.e., the order of
+	// the function declarations.
+	var funcs []*types.Func
+	keywords := make(map[*types.Func]map[bool][]string)
+	for _, a := range e.inferredAnnotations {
+		var funcObj *types.Func
+		var ident string
+		switch key := a.Key.(type) {
+		case *annotation.ParamAnnotationKey:
+			funcObj = key.FuncDecl
+			// Params are annotated by their names if they are named (and non-blank), and by
+			// their positions otherwise.
+			ident = fmt.Sprintf("param %d", key.ParamNum)
+			if p := key.ParamName(); p != nil && p.Name() != "" && p.Name() != "_" {
+				ident = p.Name()
+			}
+		case *annotation.RetAnnotationKey:
+			funcObj = key.FuncDecl
+			ident = fmt.Sprintf("result %d", key.RetNum)
+		default:
+			continue
+		}
+		if keywords[funcObj] == nil {
+			funcs = append(funcs, funcObj)
+			keywords[funcObj] = make(map[bool][]string)
+		}
+		keywords[funcObj][a.IsNilable] = append(keywords[funcObj][a.IsNilable], ident)
+	}
+	if len(funcs) == 0 {
+		return nil
+	}
+
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for _, file := range e.pass.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				if funcObj, ok := e.pass.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func); ok {
+					decls[funcObj] = funcDecl
+				}
+			}
+		}
+	}
+
+	diagnostics := make([]analysis.Diagnostic, 0, len(funcs))
+	for _, funcObj := range funcs {
+		decl, ok := decls[funcObj]
+		if !ok {
+			continue
+		}
+		var lines []string
+		for _, isNilable := range []bool{true, false} {
+			if idents := keywords[funcObj][isNilable]; len(idents) > 0 {
+				keyword := "nonnil"
+				if isNilable {
+					keyword = "nilable"
+				}
+				lines = append(lines, fmt.Sprintf("// %s(%s)", keyword, strings.Join(idents, ", ")))
+			}
+		}
+
+		// Insert the annotations at the start of the line of the `func` keyword, i.e., after the
+		// existing doc comment (if any), such that they become part of it.
+		tokFile := e.pass.Fset.File(decl.Pos())
+		lineStart := tokFile.LineStart(tokFile.Line(decl.Pos()))
+		diagnostics = append(diagnostics, analysis.Diagnostic{
+			Pos:      decl.Name.Pos(),
+			Category: InferredAnnotationCategory,
+			Message:  fmt.Sprintf("Inferred annotation: `%s()` can be annotated with `%s`", decl.Name.Name, strings.Join(lines, "` and `")),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "Insert the inferred annotations",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     lineStart,
+					End:     lineStart,
+					NewText: []byte(strings.Join(lines, "\n") + "\n"),
+				}},
+			}},
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,99 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/inference"
+	"golang.org/x/tools/go/analysis"
+)
+
+// NilabilityReportCategory is the category of the diagnostics carrying the nilability reports of
+// the functions as JSON if the `nilability-report` flag is set.
+const NilabilityReportCategory = "nilaway/nilability-report"
+
+// funcNilabilityJSON is the JSON form of the nilability report of a function.
+type funcNilabilityJSON struct {
+	// Function is the full name of the function, e.g., "example.com/pkg.(*T).Method".
+	Function string           `json:"function"`
+	Params   []siteNilability `json:"params"`
+	Results  []siteNilability `json:"results"`
+}
+
+// siteNilability is the JSON form of the nilability report of a parameter or a result.
+type siteNilability struct {
+	// Name is the name of the parameter (or "param <i>" if unnamed), or "result <i>".
+	Name       string `json:"name"`
+	Nilability string `json:"nilability"`
+	// Callees are the full names of the functions that contributed to the nilability.
+	Callees []string `json:"callees"`
+}
+
+// AddNilabilityReport adds the nilability report of a function to the engine, which will be
+// reported at the function declaration.
+func (e *Engine) AddNilabilityReport(r inference.FuncNilability) {
+	e.nilabilityReports = append(e.nilabilityReports, r)
+}
+
+// nilabilityReportDiagnostics returns one diagnostic per function whose message is the JSON form
+// of its nilability report.
+func (e *Engine) nilabilityReportDiagnostics() []analysis.Diagnostic {
+	diagnostics := make([]analysis.Diagnostic, 0, len(e.nilabilityReports))
+	for _, r := range e.nilabilityReports {
+		report := funcNilabilityJSON{
+			Function: r.Func.FullName(),
+			Params:   toSiteNilabilities(r.Params),
+			Results:  toSiteNilabilities(r.Results),
+		}
+		b, err := json.Marshal(report)
+		if err != nil {
+			panic(fmt.Sprintf("failed to marshal nilability report of %q: %v", report.Function, err))
+		}
+		diagnostics = append(diagnostics, analysis.Diagnostic{
+			Pos:      r.Func.Pos(),
+			Category: NilabilityReportCategory,
+			Message:  string(b),
+		})
+	}
+	return diagnostics
+}
+
+// toSiteNilabilities converts the nilability reports of the sites to their JSON forms.
+func toSiteNilabilities(sites []inference.SiteNilability) []siteNilability {
+	out := make([]siteNilability, 0, len(sites))
+	for _, s := range sites {
+		var name string
+		switch key := s.Key.(type) {
+		case *annotation.ParamAnnotationKey:
+			name = fmt.Sprintf("param %d", key.ParamNum)
+			if p := key.ParamName(); p != nil && p.Name() != "" && p.Name() != "_" {
+				name = p.Name()
+			}
+		case *annotation.RetAnnotationKey:
+			name = fmt.Sprintf("result %d", key.RetNum)
+		default:
+			name = s.Key.String()
+		}
+		callees := make([]string, 0, len(s.Callees))
+		for _, f := range s.Callees {
+			callees = append(callees, f.FullName())
+		}
+		out = append(out, siteNilability{Name: name, Nilability: s.Nilability, Callees: callees})
+	}
+	return out
+}
@@ -44,6 +44,18 @@ func (n *nilFlow) addNonNilPathNode(p annotation.Prestring, c annotation.Prestri
 	n.nonnilPath = append(n.nonnilPath, nodeObj)
 }
 
+// root returns the first node of the flow, whose producer is the source of nilability.
+func (n *nilFlow) root() node {
+	switch {
+	case len(n.nilPath) > 0:
+		return n.nilPath[0]
+	case len(n.nonnilPath) > 0:
+		return n.nonnilPath[0]
+	default:
+		return node{}
+	}
+}
+
 // String converts a nilFlow to a string representation, where each entry is the flow of the form: `<pos>: <reason>`
 func (n *nilFlow) String() string {
 	var allNodes []node
@@ -62,6 +74,10 @@ type node struct {
 	consumerPosition token.Position
 	producerRepr     string
 	consumerRepr     string
+	// producer and consumer are the Prestrings (unwrapped from LocatedPrestring, if any) the
+	// node is created from, which are kept for choosing the hint for the nil flow (see hint).
+	producer annotation.Prestring
+	consumer annotation.Prestring
 }
 
 // newNode creates a new node object from the given producer and consumer Prestrings.
@@ -74,16 +90,20 @@ func newNode(p annotation.Prestring, c annotation.Prestring) node {
 	if l, ok := p.(annotation.LocatedPrestring); ok {
 		nodeObj.producerPosition = l.Location
 		nodeObj.producerRepr = l.Contained.String()
+		nodeObj.producer = l.Contained
 	} else if p != nil {
 		nodeObj.producerRepr = p.String()
+		nodeObj.producer = p
 	}
 
 	// get consumer representation string
 	if l, ok := c.(annotation.LocatedPrestring); ok {
 		nodeObj.consumerPosition = l.Location
 		nodeObj.consumerRepr = l.Contained.String()
+		nodeObj.consumer = l.Contained
 	} else if c != nil {
 		nodeObj.consumerRepr = c.String()
+		nodeObj.consumer = c
 	}
 
 	return nodeObj
@@ -109,10 +129,16 @@ func (n *node) String() string {
 	return fmt.Sprintf("\t- %s: %s", posStr, reasonStr)
 }
 
+// pathString returns the string representation of the path for grouping the conflicts. Note that
+// the nodes of the annotated sites have no consumer positions (i.e., they are printed with
+// "<no pos info>"), so the positions of the annotations are included to tell them apart.
 func pathString(nodes []node) string {
 	path := ""
 	for _, n := range nodes {
 		path += n.String()
+		if !n.consumerPosition.IsValid() && n.producerPosition.IsValid() {
+			path += "@" + n.producerPosition.String()
+		}
 	}
 	return path
 }
@@ -0,0 +1,51 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import "go/token"
+
+// EnableReportOncePerSite makes the engine report each unique site (i.e., position and category)
+// at most once, even if multiple conflicts (e.g., with different nil sources or through different
+// paths) are found at it.
+func (e *Engine) EnableReportOncePerSite() {
+	e.oncePerSite = true
+}
+
+// site is the key of the conflicts that are collapsed into one by EnableReportOncePerSite.
+type site struct {
+	position token.Position
+	category string
+}
+
+// oneConflictPerSite returns the conflicts where only one is kept for each site, in the original
+// order. For determinism, the kept one is the conflict with the lexicographically smallest nil
+// flow among the ones at the same site.
+func oneConflictPerSite(conflicts []conflict) []conflict {
+	kept := make(map[site]int, len(conflicts))
+	var result []conflict
+	for _, c := range conflicts {
+		key := site{position: c.position, category: c.category}
+		i, ok := kept[key]
+		if !ok {
+			kept[key] = len(result)
+			result = append(result, c)
+			continue
+		}
+		if c.flow.String() < result[i].flow.String() {
+			result[i] = c
+		}
+	}
+	return result
+}
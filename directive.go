@@ -0,0 +1,79 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nilaway
+
+import (
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	// _disableDirective is the comment that disables the reporting of diagnostics from its
+	// position until the next _enableDirective (or the end of file if there is none).
+	_disableDirective = "//nilaway:disable"
+	// _enableDirective is the comment that re-enables the reporting of diagnostics disabled by a
+	// previous _disableDirective.
+	_enableDirective = "//nilaway:enable"
+)
+
+// disabledRange is a range of positions in which diagnostics are not reported.
+type disabledRange struct {
+	start, end token.Pos
+}
+
+// disabledRanges returns the ranges delimited by the paired `//nilaway:disable` and
+// `//nilaway:enable` comments in all files of the package. A `//nilaway:disable` comment without a
+// matching `//nilaway:enable` comment disables the rest of the file.
+func disabledRanges(pass *analysis.Pass) []disabledRange {
+	var ranges []disabledRange
+	for _, file := range pass.Files {
+		tokenFile := pass.Fset.File(file.Pos())
+		if tokenFile == nil {
+			continue
+		}
+		start := token.NoPos
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				switch strings.TrimSpace(c.Text) {
+				case _disableDirective:
+					if !start.IsValid() {
+						start = c.Pos()
+					}
+				case _enableDirective:
+					if start.IsValid() {
+						ranges = append(ranges, disabledRange{start: start, end: c.End()})
+						start = token.NoPos
+					}
+				}
+			}
+		}
+		if start.IsValid() {
+			ranges = append(ranges, disabledRange{start: start, end: token.Pos(tokenFile.Base() + tokenFile.Size())})
+		}
+	}
+	return ranges
+}
+
+// isDisabled returns true if the given position falls in any of the disabled ranges.
+func isDisabled(ranges []disabledRange, pos token.Pos) bool {
+	for _, r := range ranges {
+		if r.start <= pos && pos <= r.end {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"cmp"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util"
+)
+
+// ContractViolation describes a syntactic annotation on the exported API of the current package
+// that the annotated function itself contradicts, e.g., a `nonnil` annotation on a result
+// through which a nil value is returned.
+type ContractViolation struct {
+	// Key is the annotation site that carries the violated annotation.
+	Key annotation.Key
+	// IsNilable is true if the violated annotation marks the site as nilable, false if nonnil.
+	IsNilable bool
+	// Pos is the position where the annotation is violated, e.g., the return statement.
+	Pos token.Pos
+	// Reason describes the violating value (for results, e.g., "literal `nil`") or use (for
+	// parameters, e.g., "dereferenced").
+	Reason string
+}
+
+// ContractViolations returns the violations of the syntactic annotations in pkgAnnotations on the
+// exported API (i.e., exported functions and exported methods of exported types) of the current
+// package, sorted by their positions. It must be called after ObservePackage, with the same
+// (unfiltered) triggers that were passed to it.
+//
+// Two kinds of violations are reported: a `nonnil` result through which a value that is known to
+// be nil is returned, and a `nilable` parameter that is used unconditionally (e.g., dereferenced
+// without a nil check) in the function body. Note that the non-error results of error-returning
+// functions are not checked, since their nilability depends on the error results.
+func (e *Engine) ContractViolations(pkgAnnotations *annotation.ObservedMap, triggers []annotation.FullTrigger) []ContractViolation {
+	funcs := e.localFuncsWithBodies()
+
+	// Collect the annotated sites on the exported API.
+	type annotatedSite struct {
+		key       annotation.Key
+		isNilable bool
+	}
+	sites := make(map[primitiveSite]annotatedSite)
+	pkgAnnotations.Range(func(key annotation.Key, isDeep bool, val bool) {
+		if isDeep {
+			return
+		}
+		var funcObj *types.Func
+		switch key := key.(type) {
+		case *annotation.RetAnnotationKey:
+			funcObj = key.FuncDecl
+		case *annotation.ParamAnnotationKey:
+			funcObj = key.FuncDecl
+		default:
+			return
+		}
+		if !funcs[funcObj] || !isExportedAPI(funcObj) {
+			return
+		}
+		sites[e.primitive.site(key, false /* isDeep */)] = annotatedSite{key: key, isNilable: val}
+	}, true /* setSitesOnly */)
+
+	var violations []ContractViolation
+	for _, trigger := range triggers {
+		if trigger.Controlled() || trigger.CreatedFromDuplication {
+			continue
+		}
+
+		// A nil value returned through a `nonnil` result.
+		if c, ok := trigger.Consumer.Annotation.(*annotation.UseAsReturn); ok && !c.IsTrackingAlwaysSafe {
+			if cSite, ok := c.UnderlyingSite().(*annotation.RetAnnotationKey); ok {
+				site, ok := sites[e.primitive.site(cSite, false /* isDeep */)]
+				if ok && !site.isNilable && e.producesNil(trigger.Producer) {
+					violations = append(violations, ContractViolation{
+						Key:       site.key,
+						IsNilable: false,
+						Pos:       trigger.Consumer.Expr.Pos(),
+						Reason:    trigger.Producer.Annotation.Prestring().String(),
+					})
+				}
+			}
+			continue
+		}
+
+		// A `nilable` parameter used unconditionally.
+		if trigger.Producer.Annotation.Kind() != annotation.Conditional || trigger.Consumer.Annotation.Kind() != annotation.Always {
+			continue
+		}
+		if pSite, ok := trigger.Producer.Annotation.UnderlyingSite().(*annotation.ParamAnnotationKey); ok {
+			site, ok := sites[e.primitive.site(pSite, false /* isDeep */)]
+			if ok && site.isNilable {
+				violations = append(violations, ContractViolation{
+					Key:       site.key,
+					IsNilable: true,
+					Pos:       trigger.Consumer.Expr.Pos(),
+					Reason:    trigger.Consumer.Annotation.Prestring().String(),
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(violations, func(a, b ContractViolation) int {
+		if n := cmp.Compare(a.Pos, b.Pos); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Key.String(), b.Key.String())
+	})
+	return slices.CompactFunc(violations, func(a, b ContractViolation) bool {
+		return a.Pos == b.Pos && a.Key.String() == b.Key.String()
+	})
+}
+
+// producesNil returns true if the producer is known to produce a nil value, i.e., it is either
+// always nil by construction, or its underlying site has been determined to be nilable.
+func (e *Engine) producesNil(producer *annotation.ProduceTrigger) bool {
+	switch producer.Annotation.Kind() {
+	case annotation.Always:
+		return true
+	case annotation.Conditional:
+		site := producer.Annotation.UnderlyingSite()
+		if site == nil {
+			return false
+		}
+		val, ok := e.inferredMap.Load(e.primitive.site(site, false /* isDeep */))
+		if !ok {
+			return false
+		}
+		v, ok := val.(*DeterminedVal)
+		return ok && v.Bool.Val()
+	default:
+		return false
+	}
+}
+
+// isExportedAPI returns true if the function is part of the exported API of its package, i.e., it
+// is an exported function, or an exported method of an exported type.
+func isExportedAPI(funcObj *types.Func) bool {
+	if !funcObj.Exported() {
+		return false
+	}
+	recv := funcObj.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return true
+	}
+	named, ok := util.UnwrapPtr(recv.Type()).(*types.Named)
+	return ok && named.Obj().Exported()
+}
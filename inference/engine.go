@@ -53,6 +53,13 @@ type Engine struct {
 	// controls any triggers. This field is for internal use in the struct only and should not be
 	// accessed elsewhere.
 	controlledTriggersBySite map[primitiveSite]map[annotation.FullTrigger]bool
+	// maxIterations is the maximum number of the propagation steps (i.e., the calls to
+	// observeSiteExplanation) after it is set via Engine.SetMaxIterations, or zero if unbounded.
+	maxIterations int
+	// iterations is the number of the propagation steps since the bound was set.
+	iterations int
+	// iterationsExceeded is true if the propagation stopped since the bound was exceeded.
+	iterationsExceeded bool
 }
 
 // NewEngine constructs an inference engine that is ready to run inference.
@@ -72,6 +79,20 @@ func (e *Engine) InferredMap() *InferredMap {
 	return e.inferredMap
 }
 
+// SetMaxIterations bounds the number of the propagation steps of the subsequent observations (e.g.,
+// ObservePackage) to n, where zero means no bound. Once the bound is exceeded, the propagation
+// stops and the inferred map keeps the nilabilities determined so far, which is reported by
+// Engine.IterationsExceeded.
+func (e *Engine) SetMaxIterations(n int) {
+	e.maxIterations, e.iterations = n, 0
+}
+
+// IterationsExceeded returns true if the propagation stopped since the bound set via
+// Engine.SetMaxIterations was exceeded, i.e., the inferred map may be incomplete.
+func (e *Engine) IterationsExceeded() bool {
+	return e.iterationsExceeded
+}
+
 // ObserveUpstream imports all information from upstream dependencies. Specifically, it iterates
 // over the direct imports of the passed pass's package, using the Facts mechanism to observe any
 // InferredMap's that were computed by multi-package inference for that imported package.
@@ -138,9 +159,9 @@ func (e *Engine) ObserveAnnotations(pkgAnnotations *annotation.ObservedMap, mode
 	pkgAnnotations.Range(func(key annotation.Key, isDeep bool, val bool) {
 		site := e.primitive.site(key, isDeep)
 		if val {
-			e.observeSiteExplanation(site, TrueBecauseAnnotation{AnnotationPos: site.Position})
+			e.observeSiteExplanation(site, TrueBecauseAnnotation{AnnotationPos: site.Position, AnnotationPkgPath: site.PkgPath})
 		} else {
-			e.observeSiteExplanation(site, FalseBecauseAnnotation{AnnotationPos: site.Position})
+			e.observeSiteExplanation(site, FalseBecauseAnnotation{AnnotationPos: site.Position, AnnotationPkgPath: site.PkgPath})
 		}
 	}, mode != NoInfer)
 }
@@ -397,8 +418,17 @@ func (e *Engine) buildFromSingleFullTrigger(trigger annotation.FullTrigger) {
 // with the passed ExplainedBool, _and_ we walk the graph (forward if determining the site to be
 // true (nilable), backwards if determining the site to be false (nonnil)), recursively calling
 // observeSiteExplanation to determine all sites that must be determined from our knowledge of this
-// call in the context of the current implication graph.
+// call in the context of the current implication graph. Each call counts as one propagation step
+// toward the bound set via Engine.SetMaxIterations, and is a no-op once the bound is exceeded.
 func (e *Engine) observeSiteExplanation(site primitiveSite, siteExplained ExplainedBool) {
+	if e.maxIterations > 0 {
+		if e.iterations >= e.maxIterations {
+			e.iterationsExceeded = true
+			return
+		}
+		e.iterations++
+	}
+
 	val, ok := e.inferredMap.Load(site)
 	if !ok {
 		e.storeDeterminedAndActivateControlledTriggers(site, siteExplained)
@@ -641,4 +671,16 @@ func GobRegister() {
 	gob.RegisterName(nextStr(), annotation.RecvPassPrestring{})
 	gob.RegisterName(nextStr(), annotation.MethodRecvDeepPrestring{})
 	gob.RegisterName(nextStr(), annotation.FldReturnPrestring{})
+	gob.RegisterName(nextStr(), annotation.UnassignedArrayElemPrestring{})
+	gob.RegisterName(nextStr(), annotation.UnresolvedCallResultPrestring{})
+	gob.RegisterName(nextStr(), annotation.DeletedMapValuePrestring{})
+	gob.RegisterName(nextStr(), annotation.StrictMapReadPrestring{})
+	gob.RegisterName(nextStr(), annotation.UnsafePointerConversionPrestring{})
+	gob.RegisterName(nextStr(), annotation.NewZeroValuePrestring{})
+	gob.RegisterName(nextStr(), annotation.FuncValueCallPrestring{})
+	gob.RegisterName(nextStr(), annotation.ProtobufGetterResultPrestring{})
+	gob.RegisterName(nextStr(), annotation.OkTypeAssertionPrestring{})
+	gob.RegisterName(nextStr(), annotation.MapZeroValueFldPrestring{})
+	gob.RegisterName(nextStr(), annotation.OutParamWritePrestring{})
+	gob.RegisterName(nextStr(), annotation.ChanZeroValueFldPrestring{})
 }
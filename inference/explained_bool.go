@@ -32,6 +32,7 @@ type ExplainedBool interface {
 
 	Val() bool
 	Position() token.Position
+	PkgPath() string
 	TriggerReprs() (producer fmt.Stringer, consumer fmt.Stringer)
 	DeeperReason() ExplainedBool
 }
@@ -74,6 +75,11 @@ func (t TrueBecauseShallowConstraint) Position() token.Position {
 	return t.ExternalAssertion.Position
 }
 
+// PkgPath is the path of the package the underlying site resides in.
+func (t TrueBecauseShallowConstraint) PkgPath() string {
+	return t.ExternalAssertion.PkgPath
+}
+
 // TriggerReprs returns the compact representation structs for the producer and consumer.
 func (t TrueBecauseShallowConstraint) TriggerReprs() (fmt.Stringer, fmt.Stringer) {
 	return t.ExternalAssertion.ProducerRepr, t.ExternalAssertion.ConsumerRepr
@@ -107,6 +113,11 @@ func (f FalseBecauseShallowConstraint) Position() token.Position {
 	return f.ExternalAssertion.Position
 }
 
+// PkgPath is the path of the package the underlying site resides in.
+func (f FalseBecauseShallowConstraint) PkgPath() string {
+	return f.ExternalAssertion.PkgPath
+}
+
 // TriggerReprs returns the compact representation structs for the producer and consumer.
 func (f FalseBecauseShallowConstraint) TriggerReprs() (fmt.Stringer, fmt.Stringer) {
 	return f.ExternalAssertion.ProducerRepr, f.ExternalAssertion.ConsumerRepr
@@ -139,6 +150,11 @@ func (t TrueBecauseDeepConstraint) Position() token.Position {
 	return t.InternalAssertion.Position
 }
 
+// PkgPath is the path of the package the underlying site resides in.
+func (t TrueBecauseDeepConstraint) PkgPath() string {
+	return t.InternalAssertion.PkgPath
+}
+
 // TriggerReprs returns the compact representation structs for the producer and consumer.
 func (t TrueBecauseDeepConstraint) TriggerReprs() (fmt.Stringer, fmt.Stringer) {
 	return t.InternalAssertion.ProducerRepr, t.InternalAssertion.ConsumerRepr
@@ -171,6 +187,11 @@ func (f FalseBecauseDeepConstraint) Position() token.Position {
 	return f.InternalAssertion.Position
 }
 
+// PkgPath is the path of the package the underlying site resides in.
+func (f FalseBecauseDeepConstraint) PkgPath() string {
+	return f.InternalAssertion.PkgPath
+}
+
 // TriggerReprs returns the compact representation structs for the producer and consumer.
 func (f FalseBecauseDeepConstraint) TriggerReprs() (fmt.Stringer, fmt.Stringer) {
 	return f.InternalAssertion.ProducerRepr, f.InternalAssertion.ConsumerRepr
@@ -186,7 +207,8 @@ func (f FalseBecauseDeepConstraint) DeeperReason() ExplainedBool {
 // has been discovered - forcing that site to be nilable.
 type TrueBecauseAnnotation struct {
 	ExplainedTrue
-	AnnotationPos token.Position
+	AnnotationPos     token.Position
+	AnnotationPkgPath string
 }
 
 func (TrueBecauseAnnotation) String() string {
@@ -198,6 +220,11 @@ func (t TrueBecauseAnnotation) Position() token.Position {
 	return t.AnnotationPos
 }
 
+// PkgPath is the path of the package the annotated site resides in.
+func (t TrueBecauseAnnotation) PkgPath() string {
+	return t.AnnotationPkgPath
+}
+
 // TriggerReprs simply returns nil, nil since this constraint is the result of an annotation.
 func (TrueBecauseAnnotation) TriggerReprs() (fmt.Stringer, fmt.Stringer) {
 	return nil, nil
@@ -213,7 +240,8 @@ func (TrueBecauseAnnotation) DeeperReason() ExplainedBool {
 // has been discovered - forcing that site to be nonnil.
 type FalseBecauseAnnotation struct {
 	ExplainedFalse
-	AnnotationPos token.Position
+	AnnotationPos     token.Position
+	AnnotationPkgPath string
 }
 
 func (FalseBecauseAnnotation) String() string {
@@ -225,6 +253,11 @@ func (f FalseBecauseAnnotation) Position() token.Position {
 	return f.AnnotationPos
 }
 
+// PkgPath is the path of the package the annotated site resides in.
+func (f FalseBecauseAnnotation) PkgPath() string {
+	return f.AnnotationPkgPath
+}
+
 // TriggerReprs simply returns nil, nil since this constraint is the result of an annotation.
 func (FalseBecauseAnnotation) TriggerReprs() (fmt.Stringer, fmt.Stringer) {
 	return nil, nil
@@ -0,0 +1,92 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"go/ast"
+	"go/types"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util"
+)
+
+// InferredAnnotation describes the nilability of a site on the exported API of the current
+// package that the inference determined, but that is not explicitly annotated in the source.
+type InferredAnnotation struct {
+	// Key is the inferred annotation site, i.e., a parameter or a result of an exported function.
+	Key annotation.Key
+	// IsNilable is true if the site is inferred to be nilable, false if nonnil.
+	IsNilable bool
+}
+
+// InferredAnnotations returns the determined (shallow) nilability of the parameters and results
+// of the exported API (i.e., exported functions and exported methods of exported types) declared
+// with bodies in the current package, in the order of their declarations. It must be called
+// after ObservePackage. The sites explicitly annotated in pkgAnnotations, the sites whose types
+// bar nilness, and the sites that the inference left undetermined are skipped.
+func (e *Engine) InferredAnnotations(pkgAnnotations *annotation.ObservedMap) []InferredAnnotation {
+	// Collect the explicitly annotated sites, which are never overridden.
+	annotated := make(map[primitiveSite]bool)
+	pkgAnnotations.Range(func(key annotation.Key, isDeep bool, _ bool) {
+		if !isDeep {
+			annotated[e.primitive.site(key, false /* isDeep */)] = true
+		}
+	}, true /* setSitesOnly */)
+
+	var inferred []InferredAnnotation
+	add := func(key annotation.Key, typ types.Type) {
+		if util.TypeBarsNilness(typ) {
+			return
+		}
+		site := e.primitive.site(key, false /* isDeep */)
+		if annotated[site] {
+			return
+		}
+		val, ok := e.inferredMap.Load(site)
+		if !ok {
+			return
+		}
+		if v, ok := val.(*DeterminedVal); ok {
+			inferred = append(inferred, InferredAnnotation{Key: key, IsNilable: v.Bool.Val()})
+		}
+	}
+
+	for _, file := range e.pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			funcObj, ok := e.pass.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func)
+			if !ok || !isExportedAPI(funcObj) {
+				continue
+			}
+			sig := funcObj.Type().(*types.Signature)
+			for i := 0; i < sig.Params().Len(); i++ {
+				typ := sig.Params().At(i).Type()
+				if sig.Variadic() && i == sig.Params().Len()-1 {
+					// Similar to the annotations, the variadic parameters `...T` are treated
+					// as having type `T`.
+					typ = typ.(*types.Slice).Elem()
+				}
+				add(annotation.ParamKeyFromArgNum(funcObj, i), typ)
+			}
+			for i := 0; i < sig.Results().Len(); i++ {
+				add(annotation.RetKeyFromRetNum(funcObj, i), sig.Results().At(i).Type())
+			}
+		}
+	}
+	return inferred
+}
@@ -301,6 +301,18 @@ func (i *InferredMap) CheckFuncCallSiteRetAnn(key *annotation.CallSiteRetAnnotat
 	return i.checkAnnotationKey(key)
 }
 
+// CheckFuncTypeParamAnn checks this InferredMap for a concrete mapping of the param key of the
+// named function type provided.
+func (i *InferredMap) CheckFuncTypeParamAnn(tdecl *types.TypeName, num int) (annotation.Val, bool) {
+	return i.checkAnnotationKey(&annotation.FuncTypeParamAnnotationKey{TypeDecl: tdecl, ParamNum: num})
+}
+
+// CheckFuncTypeRetAnn checks this InferredMap for a concrete mapping of the return key of the
+// named function type provided.
+func (i *InferredMap) CheckFuncTypeRetAnn(tdecl *types.TypeName, num int) (annotation.Val, bool) {
+	return i.checkAnnotationKey(&annotation.FuncTypeRetAnnotationKey{TypeDecl: tdecl, RetNum: num})
+}
+
 func (i *InferredMap) checkAnnotationKey(key annotation.Key) (annotation.Val, bool) {
 	shallowKey := i.primitive.site(key, false)
 	deepKey := i.primitive.site(key, true)
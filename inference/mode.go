@@ -33,12 +33,22 @@ const (
 	// it - this is the fully sound and complete version of inference: implication graphs are shared
 	// between packages
 	FullInfer
+
+	// DefiniteOnly implies that neither annotations nor inference are used at all: only the
+	// assertions that fail regardless of the nilability of any annotation sites (e.g., dereferences
+	// of literal nils) are reported. This is the fast, low-false-positive mode enabled by the
+	// "no-inference" flag.
+	DefiniteOnly
 )
 
-// DetermineMode searches the files in this package for docstrings that indicate
-// inference should be entirely suppressed (returns NoInfer). By default, if no such
-// docstring is found, multi-package inference is used (returns FullInfer).
+// DetermineMode returns DefiniteOnly if it is requested via the config. Otherwise, it searches
+// the files in this package for docstrings that indicate inference should be entirely suppressed
+// (returns NoInfer). By default, if no such docstring is found, multi-package inference is used
+// (returns FullInfer).
 func DetermineMode(pass *analysis.Pass) ModeOfInference {
+	if conf, ok := pass.ResultOf[config.Analyzer].(*config.Config); ok && conf.NoInference {
+		return DefiniteOnly
+	}
 	for _, file := range pass.Files {
 		if asthelper.DocContains(file.Doc, config.NilAwayNoInferString) {
 			return NoInfer
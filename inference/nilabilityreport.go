@@ -0,0 +1,163 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"cmp"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util"
+)
+
+// The nilabilities of the sites in the nilability report.
+const (
+	NilabilityNilable      = "nilable"
+	NilabilityNonnil       = "nonnil"
+	NilabilityUndetermined = "undetermined"
+)
+
+// SiteNilability describes the concluded nilability of a parameter or a result of a function.
+type SiteNilability struct {
+	// Key is the site, i.e., a parameter or a result of the function.
+	Key annotation.Key
+	// Nilability is one of NilabilityNilable, NilabilityNonnil and NilabilityUndetermined.
+	Nilability string
+	// Callees are the other functions whose sites forced the same nilability onto the site through
+	// a direct flow, i.e., the callees with nonnil parameters that a nonnil parameter is passed to,
+	// or the callees with nilable results that a nilable result is returned from. They are sorted
+	// by their full names.
+	Callees []*types.Func
+}
+
+// FuncNilability describes the concluded nilability of the parameters and results of a function,
+// which forms a node of the nilability-annotated call graph.
+type FuncNilability struct {
+	Func    *types.Func
+	Params  []SiteNilability
+	Results []SiteNilability
+}
+
+// NilabilityReport returns the concluded nilability of the parameters and results of the functions
+// declared (with bodies) in the current package, in the order of their declarations, along with
+// the callees that contributed to them. The sites whose types bar nilness are skipped. It must be
+// called after ObservePackage, with the same (unfiltered) triggers that were passed to it. Note
+// that the contributions are only collected from the shallow flows between the parameters and
+// results of different functions.
+func (e *Engine) NilabilityReport(triggers []annotation.FullTrigger) []FuncNilability {
+	callees := make(map[primitiveSite][]*types.Func)
+	addCallee := func(key annotation.Key, callee *types.Func) {
+		site := e.primitive.site(key, false /* isDeep */)
+		if !slices.Contains(callees[site], callee) {
+			callees[site] = append(callees[site], callee)
+		}
+	}
+	for _, trigger := range triggers {
+		pKind, cKind := trigger.Producer.Annotation.Kind(), trigger.Consumer.Annotation.Kind()
+		if pKind == annotation.DeepConditional || cKind == annotation.DeepConditional {
+			continue
+		}
+		pFunc, pKey, pIsParam := funcSite(trigger.Producer.Annotation.UnderlyingSite())
+		cFunc, cKey, cIsParam := funcSite(trigger.Consumer.Annotation.UnderlyingSite())
+		if pKey == nil || cKey == nil || pFunc == cFunc || pIsParam != cIsParam {
+			continue
+		}
+		switch {
+		case pIsParam && e.nilability(pKey) == NilabilityNonnil && e.nilability(cKey) == NilabilityNonnil:
+			// A parameter is passed to a nonnil parameter of a callee.
+			addCallee(pKey, cFunc)
+		case !pIsParam && e.nilability(pKey) == NilabilityNilable && e.nilability(cKey) == NilabilityNilable:
+			// A nilable result of a callee is returned as a result.
+			addCallee(cKey, pFunc)
+		}
+	}
+
+	newSite := func(key annotation.Key) SiteNilability {
+		funcs := callees[e.primitive.site(key, false /* isDeep */)]
+		slices.SortFunc(funcs, func(a, b *types.Func) int {
+			return cmp.Compare(a.FullName(), b.FullName())
+		})
+		return SiteNilability{Key: key, Nilability: e.nilability(key), Callees: funcs}
+	}
+
+	var report []FuncNilability
+	for _, file := range e.pass.Files {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Body == nil {
+				continue
+			}
+			funcObj, ok := e.pass.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func)
+			if !ok {
+				continue
+			}
+			f := FuncNilability{Func: funcObj}
+			sig := funcObj.Type().(*types.Signature)
+			for i := 0; i < sig.Params().Len(); i++ {
+				typ := sig.Params().At(i).Type()
+				if sig.Variadic() && i == sig.Params().Len()-1 {
+					typ = typ.(*types.Slice).Elem()
+				}
+				if !util.TypeBarsNilness(typ) {
+					f.Params = append(f.Params, newSite(annotation.ParamKeyFromArgNum(funcObj, i)))
+				}
+			}
+			for i := 0; i < sig.Results().Len(); i++ {
+				if !util.TypeBarsNilness(sig.Results().At(i).Type()) {
+					f.Results = append(f.Results, newSite(annotation.RetKeyFromRetNum(funcObj, i)))
+				}
+			}
+			report = append(report, f)
+		}
+	}
+	return report
+}
+
+// funcSite returns the (generic) function of the parameter or result site, the site itself keyed
+// on the function, and whether the site is a parameter, mapping the sites of the functions with
+// contracts at each call site back to the sites of the functions. It returns a nil key for other
+// kinds of sites.
+func funcSite(key annotation.Key) (*types.Func, annotation.Key, bool) {
+	switch k := key.(type) {
+	case *annotation.ParamAnnotationKey:
+		return k.FuncDecl.Origin(), annotation.ParamKeyFromArgNum(k.FuncDecl.Origin(), k.ParamNum), true
+	case *annotation.CallSiteParamAnnotationKey:
+		return k.FuncDecl.Origin(), annotation.ParamKeyFromArgNum(k.FuncDecl.Origin(), k.ParamNum), true
+	case *annotation.RetAnnotationKey:
+		return k.FuncDecl.Origin(), annotation.RetKeyFromRetNum(k.FuncDecl.Origin(), k.RetNum), false
+	case *annotation.CallSiteRetAnnotationKey:
+		return k.FuncDecl.Origin(), annotation.RetKeyFromRetNum(k.FuncDecl.Origin(), k.RetNum), false
+	default:
+		return nil, nil, false
+	}
+}
+
+// nilability returns the concluded (shallow) nilability of the site.
+func (e *Engine) nilability(key annotation.Key) string {
+	val, ok := e.inferredMap.Load(e.primitive.site(key, false /* isDeep */))
+	if !ok {
+		return NilabilityUndetermined
+	}
+	v, ok := val.(*DeterminedVal)
+	if !ok {
+		return NilabilityUndetermined
+	}
+	if v.Bool.Val() {
+		return NilabilityNilable
+	}
+	return NilabilityNonnil
+}
@@ -31,13 +31,14 @@ import (
 // and thus cannot be present in Facts-communicated data structures. PrimitiveFullTriggers encode
 // only that information that will be relevant for formatting error messages: a prestring
 // representation  of their production, a prestring representation of their consumption, and the
-// position in the source.
+// position in the source (along with the path of the package it resides in).
 // See annotation.Prestring for more info, but in short prestrings are structs that store some
 // minimal information that will vary between string representations meant to be passed with the
 // static type information necessary to format that minimal information into a full string
 // representation without needing to encode it all when using Gob encodings through the Facts mechanism
 type primitiveFullTrigger struct {
 	Position     token.Position
+	PkgPath      string
 	ProducerRepr annotation.Prestring
 	ConsumerRepr annotation.Prestring
 }
@@ -190,6 +191,7 @@ func (p *primitivizer) fullTrigger(trigger annotation.FullTrigger) primitiveFull
 	producer, consumer := trigger.Prestrings(p.pass)
 	return primitiveFullTrigger{
 		Position:     p.toPosition(trigger.Consumer.Expr.Pos()),
+		PkgPath:      p.pass.Pkg.Path(),
 		ProducerRepr: producer,
 		ConsumerRepr: consumer,
 	}
@@ -0,0 +1,229 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"cmp"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"go.uber.org/nilaway/annotation"
+	"go.uber.org/nilaway/util"
+)
+
+// RedundantAnnotation describes a syntactic annotation that has no effect on the analysis, i.e.,
+// the inference would have reached the same conclusion without it.
+type RedundantAnnotation struct {
+	// Key is the annotation site that carries the redundant annotation.
+	Key annotation.Key
+	// IsNilable is true if the annotation marks the site as nilable, false if nonnil.
+	IsNilable bool
+}
+
+// RedundantAnnotations returns the syntactic annotations in pkgAnnotations that have no effect,
+// sorted by the positions of the annotated sites. It must be called after ObservePackage, with
+// the same (unfiltered) triggers that were passed to it.
+//
+// Currently, only annotations on results of functions declared (with bodies) in the current
+// package are checked, since all values flowing into such sites are visible in this package.
+// An annotation on such a site is considered redundant if every value returned through it is
+// known to be nonnil: a `nonnil` annotation is then already proven by inference, and a `nilable`
+// annotation guards against a value that is never potentially nil.
+func (e *Engine) RedundantAnnotations(pkgAnnotations *annotation.ObservedMap, triggers []annotation.FullTrigger) []RedundantAnnotation {
+	funcs := e.localFuncsWithBodies()
+
+	// Mark the result sites that may receive a potentially nil value.
+	potentiallyNil := make(map[primitiveSite]bool)
+	for _, trigger := range triggers {
+		if c, ok := trigger.Consumer.Annotation.(*annotation.UseAsReturn); ok && c.IsTrackingAlwaysSafe {
+			continue
+		}
+		cKind, cSite := trigger.Consumer.Annotation.Kind(), trigger.Consumer.Annotation.UnderlyingSite()
+		if cSite == nil || cKind == annotation.DeepConditional {
+			continue
+		}
+		if _, ok := cSite.(*annotation.RetAnnotationKey); !ok {
+			continue
+		}
+		// Values flowing from the site back into itself (e.g., recursive calls) are determined
+		// by the very annotation under check, so we conservatively treat them as potentially nil.
+		site := e.primitive.site(cSite, false /* isDeep */)
+		if pSite := trigger.Producer.Annotation.UnderlyingSite(); pSite != nil && pSite.Object() == cSite.Object() {
+			potentiallyNil[site] = true
+			continue
+		}
+		if !e.producesNonNil(trigger.Producer) {
+			potentiallyNil[site] = true
+		}
+	}
+
+	var redundant []RedundantAnnotation
+	pkgAnnotations.Range(func(key annotation.Key, isDeep bool, val bool) {
+		retKey, ok := key.(*annotation.RetAnnotationKey)
+		if !ok || isDeep || !funcs[retKey.FuncDecl] {
+			return
+		}
+		if potentiallyNil[e.primitive.site(key, false /* isDeep */)] {
+			return
+		}
+		redundant = append(redundant, RedundantAnnotation{Key: key, IsNilable: val})
+	}, true /* setSitesOnly */)
+
+	// pkgAnnotations is backed by Go maps, so we sort the results for determinism.
+	slices.SortFunc(redundant, func(a, b RedundantAnnotation) int {
+		if n := cmp.Compare(a.Key.Object().Pos(), b.Key.Object().Pos()); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Key.String(), b.Key.String())
+	})
+	return redundant
+}
+
+// producesNonNil returns true if the producer is known to never produce a nil value, i.e., it is
+// either never nil by construction, or its underlying site has been determined to be nonnil.
+func (e *Engine) producesNonNil(producer *annotation.ProduceTrigger) bool {
+	kind := producer.Annotation.Kind()
+	switch kind {
+	case annotation.Never:
+		return true
+	case annotation.Conditional, annotation.DeepConditional:
+		site := producer.Annotation.UnderlyingSite()
+		if site == nil {
+			return false
+		}
+		val, ok := e.inferredMap.Load(e.primitive.site(site, kind == annotation.DeepConditional))
+		if !ok {
+			return false
+		}
+		v, ok := val.(*DeterminedVal)
+		return ok && !v.Bool.Val()
+	default:
+		return false
+	}
+}
+
+// RelaxableAnnotation describes a syntactic `nonnil` annotation on a function parameter that all
+// call sites of the function already satisfy, i.e., the annotation adds no safety to the callers.
+type RelaxableAnnotation struct {
+	// Key is the parameter site that carries the `nonnil` annotation.
+	Key annotation.Key
+	// NumCallSites is the number of call sites of the function in the current package.
+	NumCallSites int
+}
+
+// RelaxableAnnotations returns the syntactic `nonnil` annotations on the parameters of the
+// functions in pkgAnnotations that every call site already passes nonnil values to, sorted by
+// the positions of the parameters. It must be called after ObservePackage, with the same
+// (unfiltered) triggers that were passed to it.
+//
+// Only the parameters of unexported (non-method) functions declared (with bodies) in the current
+// package that are called at least once and never used as function values are checked, such that
+// all call sites are known. Exported functions are skipped since their call sites in downstream
+// packages are not visible here.
+func (e *Engine) RelaxableAnnotations(pkgAnnotations *annotation.ObservedMap, triggers []annotation.FullTrigger) []RelaxableAnnotation {
+	funcs := e.localFuncsWithBodies()
+
+	// Count the call sites of each function, and find the functions that are used as values
+	// (e.g., `f := foo`) since we cannot know all their call sites.
+	numCallSites := make(map[*types.Func]int)
+	callIdents := make(map[*ast.Ident]bool)
+	for _, file := range e.pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			ident := util.FuncIdentFromCallExpr(e.pass.TypesInfo, call)
+			if ident == nil {
+				return true
+			}
+			if f, ok := e.pass.TypesInfo.Uses[ident].(*types.Func); ok {
+				callIdents[ident] = true
+				numCallSites[f.Origin()]++
+			}
+			return true
+		})
+	}
+	escaped := make(map[*types.Func]bool)
+	for ident, obj := range e.pass.TypesInfo.Uses {
+		if f, ok := obj.(*types.Func); ok && !callIdents[ident] {
+			escaped[f.Origin()] = true
+		}
+	}
+
+	// Mark the parameter sites that may receive a potentially nil value at any call site.
+	potentiallyNil := make(map[primitiveSite]bool)
+	for _, trigger := range triggers {
+		cKind, cSite := trigger.Consumer.Annotation.Kind(), trigger.Consumer.Annotation.UnderlyingSite()
+		if cSite == nil || cKind == annotation.DeepConditional {
+			continue
+		}
+		var key annotation.Key
+		switch k := cSite.(type) {
+		case *annotation.ParamAnnotationKey:
+			key = k
+		case *annotation.CallSiteParamAnnotationKey:
+			// Functions with contracts have separate parameter sites at each call site, which
+			// we map back to the parameter site of the function.
+			key = annotation.ParamKeyFromArgNum(k.FuncDecl, k.ParamNum)
+		default:
+			continue
+		}
+		if !e.producesNonNil(trigger.Producer) {
+			potentiallyNil[e.primitive.site(key, false /* isDeep */)] = true
+		}
+	}
+
+	var relaxable []RelaxableAnnotation
+	pkgAnnotations.Range(func(key annotation.Key, isDeep bool, val bool) {
+		paramKey, ok := key.(*annotation.ParamAnnotationKey)
+		if !ok || isDeep || val {
+			return
+		}
+		f := paramKey.FuncDecl
+		if !funcs[f] || f.Exported() || f.Type().(*types.Signature).Recv() != nil || numCallSites[f] == 0 || escaped[f] {
+			return
+		}
+		if potentiallyNil[e.primitive.site(key, false /* isDeep */)] {
+			return
+		}
+		relaxable = append(relaxable, RelaxableAnnotation{Key: key, NumCallSites: numCallSites[f]})
+	}, true /* setSitesOnly */)
+
+	// pkgAnnotations is backed by Go maps, so we sort the results for determinism.
+	slices.SortFunc(relaxable, func(a, b RelaxableAnnotation) int {
+		if n := cmp.Compare(a.Key.Object().Pos(), b.Key.Object().Pos()); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Key.String(), b.Key.String())
+	})
+	return relaxable
+}
+
+// localFuncsWithBodies returns the set of functions declared with bodies in the current package.
+func (e *Engine) localFuncsWithBodies() map[*types.Func]bool {
+	funcs := make(map[*types.Func]bool)
+	for _, file := range e.pass.Files {
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				if f, ok := e.pass.TypesInfo.ObjectOf(funcDecl.Name).(*types.Func); ok {
+					funcs[f] = true
+				}
+			}
+		}
+	}
+	return funcs
+}
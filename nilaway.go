@@ -17,8 +17,11 @@
 package nilaway
 
 import (
+	"fmt"
+
 	"go.uber.org/nilaway/accumulation"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
 	"go.uber.org/nilaway/util"
 	"golang.org/x/tools/go/analysis"
 )
@@ -31,20 +34,62 @@ const _doc = "Run NilAway on this package to report any possible flows of nil va
 var Analyzer = &analysis.Analyzer{
 	Name:      "nilaway",
 	Doc:       _doc,
-	Run:       run,
+	Run:       newRun(nil),
 	FactTypes: []analysis.Fact{},
 	Requires:  []*analysis.Analyzer{config.Analyzer, accumulation.Analyzer},
 }
 
-func run(pass *analysis.Pass) (interface{}, error) {
-	conf := pass.ResultOf[config.Analyzer].(*config.Config)
-	deferredErrors := pass.ResultOf[accumulation.Analyzer].([]analysis.Diagnostic)
-	for _, e := range deferredErrors {
-		if conf.PrettyPrint {
-			e.Message = util.PrettyPrintErrorMessage(e.Message)
+// DiagnosticProcessor is a hook that is invoked on each diagnostic right before it is reported.
+// It may modify the diagnostic in place (e.g., to attach ownership information or ticket links to
+// the message), and it returns false if the diagnostic should be dropped instead of reported.
+type DiagnosticProcessor func(pass *analysis.Pass, d *analysis.Diagnostic) bool
+
+// NewAnalyzer returns a copy of the top-level Analyzer that runs the given processors (in order)
+// on each diagnostic before reporting it. This is meant for integrators that use NilAway
+// programmatically and want to enrich or suppress diagnostics beyond what the flags offer.
+func NewAnalyzer(processors ...DiagnosticProcessor) *analysis.Analyzer {
+	a := *Analyzer
+	a.Run = newRun(processors)
+	return &a
+}
+
+// newRun returns the run function of the top-level analyzer that reports the diagnostics after
+// applying the given processors.
+func newRun(processors []DiagnosticProcessor) func(*analysis.Pass) (interface{}, error) {
+	return func(pass *analysis.Pass) (interface{}, error) {
+		conf := pass.ResultOf[config.Analyzer].(*config.Config)
+		deferredErrors := pass.ResultOf[accumulation.Analyzer].([]analysis.Diagnostic)
+		disabled := disabledRanges(pass)
+		var deferred []deferredRange
+		if conf.DeferDerefs != config.DeferDerefsReport {
+			deferred = deferredRanges(pass)
+		}
+	diagnosticLoop:
+		for _, e := range deferredErrors {
+			if e.Category == accumulation.InternalErrorCategory && conf.StrictInternalErrors {
+				return nil, fmt.Errorf("package %q: %s", pass.Pkg.Path(), e.Message)
+			}
+			if isDisabled(disabled, e.Pos) {
+				continue
+			}
+			if isDeferred(deferred, e.Pos) {
+				if conf.DeferDerefs == config.DeferDerefsIgnore {
+					continue
+				}
+				e.Category = DeferDerefCategory
+			}
+			// The nilability reports are kept as plain JSON to be machine-readable.
+			if conf.PrettyPrint && e.Category != diagnostic.NilabilityReportCategory {
+				e.Message = util.PrettyPrintErrorMessage(e.Message)
+			}
+			for _, process := range processors {
+				if !process(pass, &e) {
+					continue diagnosticLoop
+				}
+			}
+			pass.Report(e)
 		}
-		pass.Report(e)
-	}
 
-	return nil, nil
+		return nil, nil
+	}
 }
@@ -17,11 +17,18 @@ package nilaway
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
+	"go.uber.org/nilaway/accumulation"
+	"go.uber.org/nilaway/annotation"
 	"go.uber.org/nilaway/config"
+	"go.uber.org/nilaway/diagnostic"
+	"go.uber.org/nilaway/util/analysishelper"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -43,10 +50,10 @@ func TestNilAway(t *testing.T) {
 		{name: "ErrorReturn", patterns: []string{"go.uber.org/errorreturn", "go.uber.org/errorreturn/inference"}},
 		{name: "Maps", patterns: []string{"go.uber.org/maps"}},
 		{name: "Slices", patterns: []string{"go.uber.org/slices", "go.uber.org/slices/inference"}},
-		{name: "Arrays", patterns: []string{"go.uber.org/arrays"}},
+		{name: "Arrays", patterns: []string{"go.uber.org/arrays", "go.uber.org/arrays/inference"}},
 		{name: "Channels", patterns: []string{"go.uber.org/channels"}},
 		{name: "GoQuirks", patterns: []string{"go.uber.org/goquirks"}},
-		{name: "GlobalVars", patterns: []string{"go.uber.org/globalvars"}},
+		{name: "GlobalVars", patterns: []string{"go.uber.org/globalvars", "go.uber.org/globalvars/inference"}},
 		{name: "DeepNil", patterns: []string{"go.uber.org/deepnil", "go.uber.org/deepnil/inference"}},
 		{name: "NilableTypes", patterns: []string{"go.uber.org/nilabletypes"}},
 		{name: "HelloWorld", patterns: []string{"go.uber.org/helloworld"}},
@@ -67,6 +74,8 @@ func TestNilAway(t *testing.T) {
 		{name: "ErrorMessage", patterns: []string{"go.uber.org/errormessage", "go.uber.org/errormessage/inference"}},
 		{name: "LoopRange", patterns: []string{"go.uber.org/looprange"}},
 		{name: "AbnormalFlow", patterns: []string{"go.uber.org/abnormalflow"}},
+		{name: "DisableDirective", patterns: []string{"go.uber.org/disabledirective"}},
+		{name: "PackageDirective", patterns: []string{"go.uber.org/packagedirective"}},
 	}
 
 	for _, tt := range tests {
@@ -94,6 +103,171 @@ func TestStructInit(t *testing.T) { //nolint:paralleltest
 	analysistest.Run(t, testdata, Analyzer, "go.uber.org/structinit/funcreturnfields", "go.uber.org/structinit/local", "go.uber.org/structinit/global", "go.uber.org/structinit/paramfield", "go.uber.org/structinit/paramsideeffect", "go.uber.org/structinit/defaultfield")
 }
 
+func TestExperimentalFeatureScope(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the
+	// experimental support for struct initialization for a subset of packages.
+	err := config.Analyzer.Flags.Set(config.ExperimentalStructInitEnableFlag, "experimentalscope/enabled/...")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ExperimentalStructInitEnableFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "experimentalscope/enabled", "experimentalscope/disabled")
+}
+
+func TestConservativeUnknownCalls(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the
+	// conservative handling of unresolved calls to test this feature.
+	err := config.Analyzer.Flags.Set(config.ConservativeUnknownCallsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ConservativeUnknownCallsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "conservativeunknowncalls")
+}
+
+func TestStrictMapReads(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the strict map
+	// reads mode to test this feature.
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "strictmapreads/disabled")
+
+	err := config.Analyzer.Flags.Set(config.StrictMapReadsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.StrictMapReadsFlag, "false")
+		require.NoError(t, err)
+	}()
+	analysistest.Run(t, testdata, Analyzer, "strictmapreads/enabled")
+}
+
+func TestUnsafeConversions(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to treat the unsafe
+	// conversions as nonnil to test this feature.
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "unsafeconversions/nilable")
+
+	err := config.Analyzer.Flags.Set(config.NonnilUnsafeConversionsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.NonnilUnsafeConversionsFlag, "false")
+		require.NoError(t, err)
+	}()
+	analysistest.Run(t, testdata, Analyzer, "unsafeconversions/nonnil")
+}
+
+func TestProtobufGetters(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the modeling of
+	// protobuf getters for testing this feature.
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "protobufgetters/unmodeled")
+
+	err := config.Analyzer.Flags.Set(config.ModelProtobufGettersFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ModelProtobufGettersFlag, "false")
+		require.NoError(t, err)
+	}()
+	analysistest.Run(t, testdata, Analyzer, "protobufgetters/modeled")
+}
+
+func TestConcreteInterfaceReceivers(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the resolution
+	// of the concrete interface receivers for testing this feature.
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "concreteinterfacereceivers/disabled")
+
+	err := config.Analyzer.Flags.Set(config.ConcreteInterfaceReceiversFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ConcreteInterfaceReceiversFlag, "false")
+		require.NoError(t, err)
+	}()
+	analysistest.Run(t, testdata, Analyzer, "concreteinterfacereceivers/enabled")
+}
+
+func TestExcludeModules(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to exclude a module for
+	// testing this feature.
+	err := config.Analyzer.Flags.Set(config.ExcludeModulesFlag, "example.com/app/lib")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ExcludeModulesFlag, "")
+		require.NoError(t, err)
+	}()
+
+	// The test data is a workspace (instead of a GOPATH) of two modules, such that the modules of
+	// the packages are known.
+	testdata := filepath.Join(analysistest.TestData(), "excludemodules")
+	analysistest.Run(t, testdata, Analyzer, "example.com/app/lib", "example.com/app/libutil")
+}
+
+func TestPackages(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to set the list of
+	// packages for testing this feature.
+	err := config.Analyzer.Flags.Set(config.PackagesFlag, "packages/foo")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.PackagesFlag, "")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "packages/foo", "packages/foo/sub", "packages/foobar")
+}
+
+func TestMinConfidence(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to set the minimum
+	// confidence level for testing this feature.
+	testdata := analysistest.TestData()
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.MinConfidenceFlag, "")
+		require.NoError(t, err)
+	}()
+
+	err := config.Analyzer.Flags.Set(config.MinConfidenceFlag, config.ConfidenceHigh)
+	require.NoError(t, err)
+	analysistest.Run(t, testdata, Analyzer, "minconfidence/high")
+
+	err = config.Analyzer.Flags.Set(config.MinConfidenceFlag, config.ConfidenceMedium)
+	require.NoError(t, err)
+	analysistest.Run(t, testdata, Analyzer, "minconfidence/medium")
+}
+
+func TestReportOncePerSite(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable reporting once
+	// per site for testing this feature.
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "reportoncepersite/disabled")
+
+	err := config.Analyzer.Flags.Set(config.ReportOncePerSiteFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ReportOncePerSiteFlag, "false")
+		require.NoError(t, err)
+	}()
+	analysistest.Run(t, testdata, Analyzer, "reportoncepersite/enabled")
+}
+
+func TestWithHints(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the hints for
+	// testing this feature.
+	err := config.Analyzer.Flags.Set(config.WithHintsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.WithHintsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "withhints")
+}
+
 func TestAnonymousFunction(t *testing.T) { //nolint:paralleltest
 	// We specifically do not set this test to be parallel since we need to enable the
 	// experimental support for anonymous function to test this feature.
@@ -108,6 +282,61 @@ func TestAnonymousFunction(t *testing.T) { //nolint:paralleltest
 	analysistest.Run(t, testdata, Analyzer, "go.uber.org/anonymousfunction")
 }
 
+func TestDeferDerefs(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to enable the experimental
+	// support for anonymous function (for deferred function literals to be analyzed) and change
+	// the reporting of diagnostics within deferred functions.
+	err := config.Analyzer.Flags.Set(config.ExperimentalAnonymousFunctionFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ExperimentalAnonymousFunctionFlag, "false")
+		require.NoError(t, err)
+		err = config.Analyzer.Flags.Set(config.DeferDerefsFlag, config.DeferDerefsReport)
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+
+	err = config.Analyzer.Flags.Set(config.DeferDerefsFlag, config.DeferDerefsCategorize)
+	require.NoError(t, err)
+	results := analysistest.Run(t, testdata, Analyzer, "deferderef/categorized")
+	require.Len(t, results, 1)
+	var categories []string
+	for _, d := range results[0].Diagnostics {
+		categories = append(categories, d.Category)
+	}
+	require.ElementsMatch(t, []string{DeferDerefCategory, ""}, categories)
+
+	err = config.Analyzer.Flags.Set(config.DeferDerefsFlag, config.DeferDerefsIgnore)
+	require.NoError(t, err)
+	analysistest.Run(t, testdata, Analyzer, "deferderef/ignored")
+}
+
+func TestDiscardedErrors(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to change the reporting
+	// of diagnostics rooted in the discarded errors.
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.DiscardedErrorsFlag, config.DiscardedErrorsReport)
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+
+	err := config.Analyzer.Flags.Set(config.DiscardedErrorsFlag, config.DiscardedErrorsCategorize)
+	require.NoError(t, err)
+	results := analysistest.Run(t, testdata, Analyzer, "discardederror/categorized")
+	require.Len(t, results, 1)
+	var categories []string
+	for _, d := range results[0].Diagnostics {
+		categories = append(categories, d.Category)
+	}
+	require.ElementsMatch(t, []string{diagnostic.DiscardedErrorCategory, ""}, categories)
+
+	err = config.Analyzer.Flags.Set(config.DiscardedErrorsFlag, config.DiscardedErrorsIgnore)
+	require.NoError(t, err)
+	analysistest.Run(t, testdata, Analyzer, "discardederror/ignored")
+}
+
 func TestPrettyPrint(t *testing.T) { //nolint:paralleltest
 	// We specifically do not set this test to be parallel such that this test is run separately
 	// from the parallel tests. This makes it possible to set the pretty-print flag to true for
@@ -146,6 +375,264 @@ func TestGroupErrorMessages(t *testing.T) { //nolint:paralleltest
 	}()
 }
 
+func TestKeepExplanationsInGroups(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to keep the explanations
+	// in the grouped error messages for testing this feature.
+	err := config.Analyzer.Flags.Set(config.KeepExplanationsInGroupsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.KeepExplanationsInGroupsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "grouping/explained")
+}
+
+func TestAnnotationAliases(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to set the annotation aliases for this test only.
+	err := config.Analyzer.Flags.Set(config.AnnotationAliasesFlag, "opt=nilable,req=nonnil")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.AnnotationAliasesFlag, "")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "annotationaliases")
+}
+
+func TestDefaultNilability(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to override the default nilability for this test only.
+	err := config.Analyzer.Flags.Set(config.DefaultNilabilityFlag, "pointer=nilable,map=nonnil,slice=nonnil,chan=nonnil,interface=nilable,func=nilable")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.DefaultNilabilityFlag, "")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "defaultnilability")
+}
+
+func TestRootInScopeOnly(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to suppress the errors rooted in dependencies for this
+	// test only.
+	err := config.Analyzer.Flags.Set(config.RootInScopeOnlyFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.RootInScopeOnlyFlag, "false")
+		require.NoError(t, err)
+	}()
+	err = config.Analyzer.Flags.Set(config.ExcludePkgsFlag, "ignoredpkg1,ignoredpkg2,rootinscope/excluded")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ExcludePkgsFlag, "ignoredpkg1,ignoredpkg2")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "rootinscope")
+}
+
+func TestWarnRedundantAnnotations(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to enable the redundant annotation warnings for this
+	// test only.
+	err := config.Analyzer.Flags.Set(config.WarnRedundantAnnotationsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.WarnRedundantAnnotationsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "redundantannotations")
+}
+
+func TestAPILint(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to enable the API lint mode for this test only.
+	err := config.Analyzer.Flags.Set(config.APILintFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.APILintFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "apilint")
+}
+
+func TestSuggestRelaxAnnotations(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to enable the relaxing suggestions for this test only.
+	err := config.Analyzer.Flags.Set(config.SuggestRelaxAnnotationsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.SuggestRelaxAnnotationsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "relaxannotations")
+}
+
+func TestNilabilityReport(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to enable the nilability report for this test only.
+	err := config.Analyzer.Flags.Set(config.NilabilityReportFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.NilabilityReportFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "nilabilityreport")
+}
+
+func TestMaxInferenceIterations(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to bound the inference for this test only.
+	err := config.Analyzer.Flags.Set(config.MaxInferenceIterationsFlag, "2")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.MaxInferenceIterationsFlag, "0")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "maxinferenceiterations")
+}
+
+func TestPanicIfNilFuncs(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to configure the panic-if-nil functions for this test
+	// only.
+	err := config.Analyzer.Flags.Set(config.PanicIfNilFuncsFlag, "panicifnil.mustNotBeNil,panicifnil.checkNotNil:1,panicifnil.checker.notNil:0")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.PanicIfNilFuncsFlag, "")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "panicifnil")
+}
+
+func TestExcludeSymbols(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to configure the excluded symbols for this test only.
+	err := config.Analyzer.Flags.Set(config.ExcludeSymbolsFlag, "excludesymbols/dep.MustGet,excludesymbols/dep.MustOpen,excludesymbols/dep.Store.MustGet,excludesymbols.mustLoad")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.ExcludeSymbolsFlag, "")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "excludesymbols")
+}
+
+func TestNoInference(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to disable the inference engine for this test only.
+	err := config.Analyzer.Flags.Set(config.NoInferenceFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.NoInferenceFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "noinference")
+}
+
+func TestOptionalAnnotations(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel such that this test is run separately
+	// from the parallel tests, since we need to recognize `// +optional` comments for this test only.
+	err := config.Analyzer.Flags.Set(config.OptionalAnnotationsFlag, "true")
+	require.NoError(t, err)
+	defer func() {
+		err := config.Analyzer.Flags.Set(config.OptionalAnnotationsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, Analyzer, "optionalannotations")
+}
+
+// errorRecorder records the errors reported by analysistest instead of failing the test.
+type errorRecorder struct {
+	errs []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestStrictInternalErrors(t *testing.T) { //nolint:paralleltest
+	// We specifically do not set this test to be parallel since we need to simulate an internal
+	// error by replacing the run function of a sub-analyzer, and change the strictness.
+	run := annotation.Analyzer.Run
+	annotation.Analyzer.Run = analysishelper.WrapRun(func(*analysis.Pass) (*annotation.ObservedMap, error) {
+		panic("unexpected AST shape")
+	})
+	defer func() {
+		annotation.Analyzer.Run = run
+		err := config.Analyzer.Flags.Set(config.StrictInternalErrorsFlag, "false")
+		require.NoError(t, err)
+	}()
+
+	testdata := analysistest.TestData()
+
+	// By default, the internal error is reported as a diagnostic.
+	recorder := &errorRecorder{}
+	results := analysistest.Run(recorder, testdata, Analyzer, "internalerror")
+	require.Len(t, results, 1)
+	require.NoError(t, results[0].Err)
+	require.Len(t, results[0].Diagnostics, 1)
+	require.Equal(t, accumulation.InternalErrorCategory, results[0].Diagnostics[0].Category)
+
+	// With the flag, the internal error fails the analysis with the stack trace and the package.
+	err := config.Analyzer.Flags.Set(config.StrictInternalErrorsFlag, "true")
+	require.NoError(t, err)
+	recorder = &errorRecorder{}
+	results = analysistest.Run(recorder, testdata, Analyzer, "internalerror")
+	require.Len(t, results, 1)
+	require.Error(t, results[0].Err)
+	require.Empty(t, results[0].Diagnostics)
+	require.Contains(t, results[0].Err.Error(), `package "internalerror"`)
+	require.Contains(t, results[0].Err.Error(), "INTERNAL PANIC")
+	require.Contains(t, results[0].Err.Error(), "unexpected AST shape")
+	require.Contains(t, results[0].Err.Error(), "goroutine")
+}
+
+func TestDiagnosticProcessor(t *testing.T) {
+	t.Parallel()
+
+	// The processor attaches an owner tag based on the file path, and drops all diagnostics in the
+	// "dropped" package.
+	owners := map[string]string{"teama": "team-a"}
+	processor := func(pass *analysis.Pass, d *analysis.Diagnostic) bool {
+		dir := filepath.Base(filepath.Dir(pass.Fset.File(d.Pos).Name()))
+		if dir == "dropped" {
+			return false
+		}
+		if owner, ok := owners[dir]; ok {
+			d.Message = strings.TrimSpace(d.Message) + fmt.Sprintf(" [owner: %s]", owner)
+		}
+		return true
+	}
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, NewAnalyzer(processor), "postprocess/teama", "postprocess/dropped")
+}
+
 func TestMain(m *testing.M) {
 	flags := map[string]string{
 		// Pretty print should be turned off for easier error message matching in test files.
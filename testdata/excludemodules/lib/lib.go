@@ -0,0 +1,8 @@
+// Package lib is in a separate (dependency) module "example.com/app/lib", which is excluded by the
+// `exclude-modules` flag, hence the potential nil panics here are not reported.
+package lib
+
+func Deref() int {
+	var p *int
+	return *p
+}
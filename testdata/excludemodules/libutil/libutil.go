@@ -0,0 +1,8 @@
+// Package libutil is in the main module "example.com/app", and it is still analyzed even though its
+// package path shares the prefix "example.com/app/lib" with the excluded module.
+package libutil
+
+func Deref() int {
+	var p *int
+	return *p //want "dereferenced"
+}
@@ -0,0 +1,26 @@
+package downstream
+
+import "go.uber.org/nilaway/integration/fields/upstream"
+
+// The struct is returned by value from the upstream package.
+func derefUpstreamAnnotatedField() int {
+	c := upstream.NewConfig()
+	return c.Logger.Level //want "field `Logger` accessed field `Level`"
+}
+
+func derefUpstreamNonnilField() string {
+	c := upstream.NewConfig()
+	return *c.Name
+}
+
+// The struct is returned by pointer from the upstream package, which assigns nil to the field in
+// one of its methods.
+func derefUpstreamInferredField() int {
+	o := upstream.NewOptions()
+	return o.Debug.Level //want "literal `nil` assigned into field `Debug`"
+}
+
+func derefUpstreamUnaffectedField() int {
+	o := upstream.NewOptions()
+	return o.Logger.Level
+}
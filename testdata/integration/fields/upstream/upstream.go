@@ -0,0 +1,31 @@
+package upstream
+
+type Logger struct {
+	Level int
+}
+
+// Config has a field that is explicitly annotated as nilable.
+// nilable(Logger)
+type Config struct {
+	Logger *Logger
+	Name   *string
+}
+
+// Options has a field whose nilability is inferred from the nil assigned to it.
+type Options struct {
+	Logger *Logger
+	Debug  *Logger
+}
+
+func NewConfig() Config {
+	name := "n"
+	return Config{Name: &name}
+}
+
+func NewOptions() *Options {
+	return &Options{Logger: &Logger{}}
+}
+
+func (o *Options) DisableDebug() {
+	o.Debug = nil
+}
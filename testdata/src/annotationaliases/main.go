@@ -0,0 +1,37 @@
+// Package annotationaliases is meant to check if our annotation-aliases flag has effect: the test
+// configures the aliases "opt=nilable,req=nonnil".
+//
+// <nilaway no inference>
+package annotationaliases
+
+// opt(f)
+type A struct {
+	f *int
+	g *int
+}
+
+// opt(a) req(result 0)
+func foo(a *int, b *int) *int {
+	print(*a) //want "dereferenced"
+	print(*b)
+	return nil //want "returned from `foo.*`"
+}
+
+// opt(result 0)
+func bar() *int {
+	return nil
+}
+
+func useFields(x *A) {
+	print(*x.f) //want "field `f` dereferenced"
+	print(*x.g)
+	print(*bar()) //want "dereferenced"
+}
+
+// Non-aliased keywords should continue to work, and identifiers that merely contain an alias
+// should not be expanded, e.g., the `nopt(...)` below.
+// nilable(a) nopt(b)
+func baz(a *int, b *int) {
+	print(*a) //want "dereferenced"
+	print(*b)
+}
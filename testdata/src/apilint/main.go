@@ -0,0 +1,69 @@
+// Package apilint is meant to check if our api-lint flag has effect: only the violations of the
+// annotations on the exported API are reported, while the potential nil panics are not.
+package apilint
+
+var global int
+
+// nonnil(result 0)
+func Get(b bool) *int {
+	if b {
+		return nil //want "API contract violation: `nonnil` annotation on Result 0 of Function Get is violated, since literal `nil` is returned through it"
+	}
+	return &global
+}
+
+// nilable(p)
+func Use(p *int) int {
+	return *p //want "API contract violation: `nilable` annotation on Param 0: 'p' of Function Use is violated, since it is unconditionally dereferenced"
+}
+
+type Exported struct{}
+
+// nonnil(result 0)
+func (*Exported) Get() *int {
+	return nil //want "API contract violation: `nonnil` annotation on Result 0 of Function Get is violated"
+}
+
+// The annotations below are respected, and hence should not be reported.
+
+// nilable(result 0)
+func MaybeGet(b bool) *int {
+	if b {
+		return nil
+	}
+	return &global
+}
+
+// nilable(p)
+func SafeUse(p *int) int {
+	if p != nil {
+		return *p
+	}
+	return 0
+}
+
+// The violations below are not on the exported API, and hence should not be reported.
+
+// nonnil(result 0)
+func get() *int {
+	return nil
+}
+
+type unexported struct{}
+
+// nonnil(result 0)
+func (*unexported) Get() *int {
+	return nil
+}
+
+// The potential nil panics below are not reported in the API lint mode.
+
+func deref() int {
+	var p *int
+	return *p
+}
+
+func use() {
+	print(*MaybeGet(true), *get(), *Get(true))
+	print(SafeUse(nil), Use(nil))
+}
@@ -0,0 +1,44 @@
+// Package disabled tests that, without the `concrete-interface-receivers` flag, a nilable pointer
+// assigned to an interface variable is reported at the method calls on the interface, regardless of
+// whether the methods of the concrete type handle nil receivers.
+package disabled
+
+type T struct {
+	f int
+}
+
+func (t *T) Deref() int {
+	return t.f
+}
+
+func (t *T) NilSafe() int {
+	if t == nil {
+		return 0
+	}
+	return t.f
+}
+
+type I interface {
+	Deref() int
+	NilSafe() int
+}
+
+var dummy bool
+
+func nilableT() *T {
+	if dummy {
+		return nil
+	}
+	return &T{}
+}
+
+func callDeref() int {
+	var i I = nilableT()
+	return i.Deref() //want "called `Deref\\(\\)`"
+}
+
+// nilable(t)
+func callNilSafe(t *T) int {
+	var i I = t
+	return i.NilSafe() //want "called `NilSafe\\(\\)`"
+}
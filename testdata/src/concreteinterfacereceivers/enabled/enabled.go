@@ -0,0 +1,72 @@
+// Package enabled tests that, with the `concrete-interface-receivers` flag, the method calls on
+// local interface variables that are only assigned pointers of a single concrete type are resolved
+// to the methods of the concrete type, such that a nilable pointer assigned to the interface is
+// reported only if the called method dereferences its receiver.
+package enabled
+
+type T struct {
+	f int
+}
+
+func (t *T) Deref() int {
+	return t.f //want "used as receiver to call `Deref\\(\\)`"
+}
+
+func (t *T) NilSafe() int {
+	if t == nil {
+		return 0
+	}
+	return t.f
+}
+
+type U struct{}
+
+func (*U) Deref() int   { return 0 }
+func (*U) NilSafe() int { return 0 }
+
+type I interface {
+	Deref() int
+	NilSafe() int
+}
+
+var dummy bool
+
+func nilableT() *T {
+	if dummy {
+		return nil
+	}
+	return &T{}
+}
+
+func callDeref() int {
+	var i I = nilableT()
+	return i.Deref()
+}
+
+func callNilSafe() int {
+	var i I = nilableT()
+	return i.NilSafe()
+}
+
+func callNilSafeAfterReassignment() int {
+	var i I = nilableT()
+	if dummy {
+		i = &T{}
+	}
+	return i.NilSafe()
+}
+
+func callNilSafeOnMixedTypes() int {
+	// The concrete type is unknown if different types are assigned.
+	var i I = nilableT()
+	if dummy {
+		i = &U{}
+	}
+	return i.NilSafe() //want "called `NilSafe\\(\\)`"
+}
+
+func callNilSafeOnNilInterface() int {
+	// The interface itself is nil if no value is assigned.
+	var i I
+	return i.NilSafe() //want "called `NilSafe\\(\\)`"
+}
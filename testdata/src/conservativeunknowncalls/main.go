@@ -0,0 +1,57 @@
+// Package conservativeunknowncalls tests that the results of calls whose callees cannot be
+// resolved statically are treated as nilable when the `conservative-unknown-calls` flag is set.
+package conservativeunknowncalls
+
+type getter struct {
+	get func() *int
+}
+
+func known() *int {
+	return new(int)
+}
+
+func makeGetter() func() *int {
+	return known
+}
+
+func callParam(fn func() *int) int {
+	return *fn() //want "result 0 of unresolved call `fn\\(\\)`"
+}
+
+func callField(g *getter) int {
+	return *g.get() //want "result 0 of unresolved call `g.get\\(\\)`"
+}
+
+func callLocal() int {
+	fn := known
+	v := fn()
+	return *v //want "result 0 of unresolved call `fn\\(\\)`"
+}
+
+func callReturned() int {
+	return *makeGetter()() //want "result 0 of unresolved call `makeGetter\\(\\)\\(\\)`"
+}
+
+func callMultiResult(fn func() (int, *int)) int {
+	n, p := fn()
+	return n + *p //want "result 1 of unresolved call `fn\\(\\)`"
+}
+
+func callGuarded(fn func() *int) int {
+	if v := fn(); v != nil {
+		return *v
+	}
+	return 0
+}
+
+func callKnown() int {
+	return *known()
+}
+
+func callNonPointer(fn func() int) int {
+	return fn() + 1
+}
+
+func callFuncLit() int {
+	return *func() *int { return new(int) }()
+}
@@ -0,0 +1,79 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package defaultnilability is meant to check if our default-nilability flag has effect: the test
+// configures "pointer=nilable,map=nonnil,slice=nonnil,chan=nonnil,interface=nilable,func=nilable",
+// i.e., the opposite of the built-in defaults for every category.
+//
+// <nilaway no inference>
+package defaultnilability
+
+type A struct {
+	ptr *int
+	m   map[int]int
+	s   []int
+}
+
+func usePointer(p *int) {
+	print(*p) //want "dereferenced"
+}
+
+func useMap(m map[int]int) {
+	m[0] = 1
+}
+
+func useSlice(s []int) {
+	print(s[0])
+}
+
+// nonnil(result 0)
+func useChan(c chan int) chan int {
+	return c
+}
+
+// nonnil(result 0)
+func useInterface(i interface{ Foo() }) interface{ Foo() } {
+	return i //want "returned"
+}
+
+// The calls of the function-typed parameters are checked, since they are nilable by default now.
+// nonnil(h)
+func useFunc(f func(), g func(), h func()) {
+	f() //want "called as a function"
+	if g != nil {
+		g()
+	}
+	h()
+}
+
+// The `error` type is always nilable by default, regardless of the interface category.
+// nonnil(result 0)
+func useError(err error) error {
+	return err //want "for return"
+}
+
+// The annotations take precedence over the defaults.
+// nonnil(p) nilable(s)
+func useAnnotated(p *int, s []int) {
+	print(*p)
+	print(s[0]) //want "sliced into"
+}
+
+// The defaults of the fields and the deep nilability of the elements are overridden as well.
+func useFields(a A, ps []*int) {
+	print(*a.ptr) //want "dereferenced"
+	a.m[0] = 1
+	print(a.s[0])
+	print(*ps[0]) //want "dereferenced"
+}
@@ -0,0 +1,15 @@
+// Package categorized tests that the potential nil panics within deferred function literals are
+// reported under a separate category if the `defer-derefs` flag is set to "categorize".
+package categorized
+
+func cleanup() {
+	defer func() {
+		var p *int
+		print(*p) //want "unassigned variable `p` dereferenced"
+	}()
+}
+
+func direct() {
+	var p *int
+	print(*p) //want "unassigned variable `p` dereferenced"
+}
@@ -0,0 +1,15 @@
+// Package ignored tests that the potential nil panics within deferred function literals are not
+// reported if the `defer-derefs` flag is set to "ignore", while the other ones still are.
+package ignored
+
+func cleanup() {
+	defer func() {
+		var p *int
+		print(*p)
+	}()
+}
+
+func direct() {
+	var p *int
+	print(*p) //want "unassigned variable `p` dereferenced"
+}
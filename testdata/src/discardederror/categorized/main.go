@@ -0,0 +1,31 @@
+// Package categorized tests that the potential nil panics rooted in the value results whose error
+// results are discarded with the blank identifier are reported under a separate category if the
+// `discarded-errors` flag is set to "categorize", while the intentional discards are honored.
+package categorized
+
+import "errors"
+
+var dummy bool
+
+func get() (*int, error) {
+	if dummy {
+		return nil, errors.New("some error")
+	}
+	return new(int), nil
+}
+
+func discarded() int {
+	v, _ := get()
+	return *v //want "used without checking the discarded error"
+}
+
+func intentional() int {
+	v, _ := get() //nilaway:intentional-discard get never fails in tests
+	return *v
+}
+
+func unchecked() int {
+	v, err := get()
+	_ = err
+	return *v //want "lacking guarding"
+}
@@ -0,0 +1,26 @@
+// Package ignored tests that the potential nil panics rooted in the value results whose error
+// results are discarded with the blank identifier are not reported if the `discarded-errors` flag
+// is set to "ignore", while the other ones still are.
+package ignored
+
+import "errors"
+
+var dummy bool
+
+func get() (*int, error) {
+	if dummy {
+		return nil, errors.New("some error")
+	}
+	return new(int), nil
+}
+
+func discarded() int {
+	v, _ := get()
+	return *v
+}
+
+func unchecked() int {
+	v, err := get()
+	_ = err
+	return *v //want "lacking guarding"
+}
@@ -0,0 +1,58 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package dep is a dependency of the excludesymbols package, which returns nilable values.
+package dep
+
+var dummy bool
+
+// MustGet returns a pointer that is known to be nonnil by external guarantees.
+func MustGet() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
+
+// Get returns a nilable pointer.
+func Get() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
+
+// MustOpen returns a pointer that is known to be nonnil by external guarantees, even if the error
+// is not checked.
+func MustOpen() (*int, error) {
+	return nil, nil
+}
+
+// Store stores the values.
+type Store struct{}
+
+// MustGet returns a pointer that is known to be nonnil by external guarantees.
+func (*Store) MustGet() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
+
+// Get returns a nilable pointer.
+func (*Store) Get() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
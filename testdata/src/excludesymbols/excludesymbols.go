@@ -0,0 +1,63 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package excludesymbols is meant to check if our exclude-symbols flag has effect: the potential
+// nil panics rooted in the results of the excluded functions and methods (from the dependency and
+// from this package) are suppressed, while the ones rooted in other functions are still reported.
+package excludesymbols
+
+import "excludesymbols/dep"
+
+var dummy bool
+
+func mustLoad() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
+
+func load() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
+
+func useExcluded() {
+	print(*dep.MustGet())
+	print(*mustLoad())
+	var s dep.Store
+	print(*s.MustGet())
+}
+
+func useNotExcluded() {
+	print(*dep.Get()) //want "dereferenced"
+	print(*load())    //want "dereferenced"
+	var s dep.Store
+	print(*s.Get()) //want "dereferenced"
+}
+
+func useUncheckedError() {
+	v, _ := dep.MustOpen()
+	print(*v)
+}
+
+// The nil flow passes through a local function, but it is still rooted in the excluded function.
+func wrap() *int {
+	return dep.MustGet()
+}
+
+func useWrapped() {
+	print(*wrap())
+}
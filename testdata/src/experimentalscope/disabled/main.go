@@ -0,0 +1,13 @@
+// Package disabled is not in the scope of the experimental struct initialization support, hence
+// the uninitialized field is not reported.
+package disabled
+
+type A struct {
+	ptr  *int
+	aptr *A
+}
+
+func m() {
+	b := &A{}
+	print(b.aptr.ptr)
+}
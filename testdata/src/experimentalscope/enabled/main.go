@@ -0,0 +1,13 @@
+// Package enabled is in the scope of the experimental struct initialization support, hence the
+// uninitialized field is reported.
+package enabled
+
+type A struct {
+	ptr  *int
+	aptr *A
+}
+
+func m() {
+	b := &A{}
+	print(b.aptr.ptr) //want "uninitialized accessed field `ptr`"
+}
@@ -0,0 +1,75 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+This test checks that the annotations on named function types are parsed correctly, and that
+they apply to all the calls through values of those types.
+
+<nilaway no inference>
+*/
+package annotationparse
+
+type Request struct {
+	Path string
+}
+
+type Response struct {
+	Body string
+}
+
+// Handler handles a request, returning nil if the request is not handled.
+// nilable(result 0)
+type Handler func(*Request) *Response
+
+// Middleware wraps a handler, where the wrapped handler may be nil.
+// nilable(next)
+type Middleware func(next Handler, req *Request) *Response
+
+// Fallback is a handler that always responds.
+type Fallback func(*Request) *Response
+
+type router struct {
+	handler  Handler
+	fallback Fallback
+}
+
+func serve(h Handler, req *Request) string {
+	return h(req).Body //want "result 0 of `Handler\\(\\)`"
+}
+
+func serveChecked(h Handler, req *Request) string {
+	if resp := h(req); resp != nil {
+		return resp.Body
+	}
+	return ""
+}
+
+func serveFallback(f Fallback, req *Request) string {
+	return f(req).Body
+}
+
+func (r *router) route(req *Request) string {
+	if resp := r.handler(req); resp != nil {
+		return resp.Body
+	}
+	resp := r.handler(req)
+	print(resp.Body) //want "result 0 of `Handler\\(\\)`"
+	return r.fallback(req).Body
+}
+
+func wrap(m Middleware, req *Request) *Response {
+	// the params of Middleware are annotated as well, `next` is nilable while `req` is not
+	m(nil, req)
+	return m(nil, nil) //want "passed as arg `req` to `Middleware\\(\\)`"
+}
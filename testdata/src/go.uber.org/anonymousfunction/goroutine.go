@@ -0,0 +1,63 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anonymousfunction
+
+// Tests the nilability of the variables captured by the function literals launched as goroutines,
+// which is captured at the go statements like the immediately invoked function literals.
+
+func testGoroutineCapture() {
+	var t *int
+	go func() {
+		print(*t) //want "unassigned variable `t`"
+	}()
+
+	i := 1
+	t = &i
+	go func() {
+		print(*t)
+	}()
+}
+
+func testGoroutineCaptureNil() {
+	i := 1
+	t := &i
+	t = nil
+	go func() {
+		print(*t) //want "literal `nil`"
+	}()
+}
+
+func testGoroutineCaptureGuarded(t *int) {
+	if t != nil {
+		go func() {
+			print(*t)
+		}()
+	}
+
+	// The variable is reassigned between the guard and the go statement.
+	if t != nil {
+		t = nil
+		go func() {
+			print(*t) //want "literal `nil`"
+		}()
+	}
+}
+
+func testGoroutineCaptureArg() {
+	var t *int
+	go func(p *int) {
+		print(*p) //want "unassigned variable `t`"
+	}(t)
+}
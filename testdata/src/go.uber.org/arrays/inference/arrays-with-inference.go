@@ -0,0 +1,76 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inference tests the nilability of the elements of arrays of pointers with inference
+// enabled, where the zero value of such an array holds nil elements.
+package inference
+
+type A struct {
+	f int
+}
+
+func testUninitializedArray(i int) int {
+	var a [3]*A
+	switch i {
+	case 0:
+		return a[0].f //want "uninitialized array element accessed field `f`"
+	case 1:
+		a[2] = &A{}
+		return a[2].f
+	}
+	return 0
+}
+
+func testUninitializedArrayDeref() A {
+	var a [3]*A
+	return *a[1] //want "uninitialized array element dereferenced"
+}
+
+// Test that the elements of a named array type are treated in the same way.
+
+type Arr [2]*A
+
+func testUninitializedNamedArray() int {
+	var a Arr
+	return a[1].f //want "uninitialized array element accessed field `f`"
+}
+
+func testEmptyArrayLiteral() int {
+	a := [3]*A{}
+	return a[0].f //want "uninitialized array element accessed field `f`(.|\n)*via the assignment"
+}
+
+func testPartiallyInitializedArrayLiteral() int {
+	a := [3]*A{&A{}, &A{}}
+	// TODO: this is a false negative since we do not track which elements of a partially
+	//  initialized array literal are assigned.
+	return a[0].f + a[2].f
+}
+
+func testFullyInitializedArrayLiteral(x, y *A) int {
+	a := [3]*A{&A{}, &A{}, &A{}}
+	b := [...]*A{&A{}, &A{}}
+	c := [2]*A{1: &A{}, 0: &A{}}
+	if x != nil && y != nil {
+		d := [2]*A{x, y}
+		return d[0].f + d[1].f
+	}
+	return a[0].f + a[2].f + b[1].f + c[0].f
+}
+
+func testArrayOfNonNilableElements() int {
+	var a [3]int
+	var b [2][2]int
+	return a[0] + b[1][1]
+}
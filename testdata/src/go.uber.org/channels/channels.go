@@ -631,3 +631,54 @@ func testSendRecvFuncRet() {
 
 	func(...any) {}(v1, v2)
 }
+
+// Below tests check that pointers assigned in only some of the cases of a select statement are
+// considered nilable after the select statement.
+
+func testSelectAssignInOneCase(ch1, ch2 chan *int) {
+	var v *int
+	select {
+	case v = <-ch1:
+	case <-ch2:
+	}
+	print(*v) //want "unassigned variable `v` dereferenced"
+}
+
+func testSelectAssignInAllCases(ch1, ch2 chan *int) {
+	var v *int
+	select {
+	case v = <-ch1:
+	case v = <-ch2:
+	}
+	print(*v)
+}
+
+func testSelectDefaultKeepsPriorValue(ch chan *int) {
+	var v *int
+	select {
+	case v = <-ch:
+	default:
+		// The default case leaves `v` at its prior (nil) value.
+	}
+	print(*v) //want "unassigned variable `v` dereferenced"
+}
+
+func testSelectDefaultKeepsPriorNonnilValue(ch chan *int) {
+	v := new(int)
+	select {
+	case v = <-ch:
+	default:
+	}
+	print(*v)
+}
+
+func testSelectAssignInBody(ch chan *int) {
+	var v *int
+	select {
+	case x := <-ch:
+		v = x
+	default:
+		v = nil
+	}
+	print(*v) //want "literal `nil` dereferenced"
+}
@@ -0,0 +1,64 @@
+//  Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package channels
+
+// This file tests the `//nilaway:open(ch)` directive, which states that the channel `ch` is never
+// closed while the function receives from it. The receives from such channels always succeed, so
+// the received values are never the zero values of closed channels, and the `ok`s are always true.
+
+type openChanElem struct {
+	f int
+}
+
+//nilaway:open(ch)
+func recvFromOpenChan(ch chan *openChanElem) int {
+	v := <-ch
+	return v.f
+}
+
+//nilaway:open(ch)
+func recvOkFromOpenChan(ch chan *openChanElem) int {
+	v, ok := <-ch
+	_ = ok
+	return v.f
+}
+
+//nilaway:open(ch)
+func recvOkDiscardedFromOpenChan(ch chan *openChanElem) int {
+	v, _ := <-ch
+	return v.f
+}
+
+//nilaway:open(local)
+func recvFromOpenLocalChan() int {
+	local := make(chan *openChanElem, 1)
+	local <- &openChanElem{}
+	v, _ := <-local
+	return v.f
+}
+
+// The directive only applies to the named channels.
+//
+//nilaway:open(ch)
+func recvFromOtherChan(ch, other chan *openChanElem) int {
+	v, _ := <-ch
+	u, _ := <-other
+	return v.f + u.f //want "lacking guarding"
+}
+
+func recvOkFromUnannotatedChan(ch chan *openChanElem) int {
+	v, _ := <-ch
+	return v.f //want "lacking guarding"
+}
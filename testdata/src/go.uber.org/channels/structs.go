@@ -0,0 +1,85 @@
+//  Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package channels
+
+// This file tests the fields of the struct values received from channels, which carry the
+// nilability of the fields. A closed channel yields the zero value of the struct (i.e., all pointer
+// fields are nil), which is only checked in the `ok` form, consistent with the other channels.
+
+type chanStructElem struct {
+	f int
+}
+
+// nilable(nilable)
+type chanStruct struct {
+	nilable *chanStructElem
+	nonnil  *chanStructElem
+}
+
+func recvStructNilableFld(ch chan chanStruct) int {
+	s := <-ch
+	return s.nilable.f //want "field `nilable` accessed field `f`"
+}
+
+func recvStructNonnilFld(ch chan chanStruct) int {
+	s := <-ch
+	return s.nonnil.f
+}
+
+func recvStructDirect(ch chan chanStruct) int {
+	return (<-ch).nilable.f //want "field `nilable` accessed field `f`"
+}
+
+func rangeStructNilableFld(ch chan chanStruct) int {
+	for s := range ch {
+		return s.nilable.f //want "field `nilable` accessed field `f`"
+	}
+	return 0
+}
+
+func selectStructNilableFld(ch chan chanStruct) int {
+	select {
+	case s := <-ch:
+		return s.nilable.f //want "field `nilable` accessed field `f`"
+	}
+}
+
+// The struct received from a closed channel is the zero value, whose fields are all nil.
+func recvOkStructUnguarded(ch chan chanStruct) int {
+	s, _ := <-ch
+	return s.nonnil.f //want "field `nonnil` of zero value received from a possibly closed channel lacking guarding"
+}
+
+func recvOkStructGuarded(ch chan chanStruct) int {
+	s, ok := <-ch
+	if !ok {
+		return 0
+	}
+	return s.nonnil.f
+}
+
+func recvOkStructGuardedNilableFld(ch chan chanStruct) int {
+	s, ok := <-ch
+	if ok {
+		return s.nilable.f //want "field `nilable` accessed field `f`"
+	}
+	return 0
+}
+
+//nilaway:open(ch)
+func recvOkStructFromOpenChan(ch chan chanStruct) int {
+	s, _ := <-ch
+	return s.nonnil.f
+}
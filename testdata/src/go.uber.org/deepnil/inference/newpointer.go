@@ -0,0 +1,57 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the dereferences of the pointers to pointers allocated by `new`, where the
+// pointed-to pointer is the nil zero value until it is assigned.
+
+type newPtrStruct struct {
+	val int
+}
+
+func derefNewPtrPtr() int {
+	p := new(*int)
+	return **p //want "zero value `\\*new\\(\\*int\\)` dereferenced"
+}
+
+func derefNewPtrPtrField() int {
+	p := new(*newPtrStruct)
+	return (**p).val //want "zero value `\\*new\\(\\*newPtrStruct\\)` dereferenced"
+}
+
+func derefNewPtrPtrThroughLocal() int {
+	p := new(*newPtrStruct)
+	q := *p
+	return q.val //want "accessed field `val`"
+}
+
+func derefNewPtrPtrAssigned() int {
+	i := 42
+	p := new(*int)
+	*p = &i
+	return **p
+}
+
+func derefNewPtrPtrAssignedField() int {
+	p := new(*newPtrStruct)
+	*p = &newPtrStruct{}
+	return (*p).val
+}
+
+func derefNewPtr() int {
+	// The zero value of a non-pointer type can never be nil.
+	p := new(int)
+	return *p
+}
@@ -0,0 +1,93 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the pointers set by the callees through pointer-to-pointer parameters (i.e., out
+// parameters), e.g., `p` after `fill(&p)` for `func fill(out **T)`.
+
+type outParamStruct struct {
+	val int
+}
+
+func fillNilOutParam(out **outParamStruct) {
+	*out = nil
+}
+
+func clearOutParam(out **outParamStruct) {
+	var empty *outParamStruct
+	*out = empty
+}
+
+func fillNonnilOutParam(out **outParamStruct) {
+	*out = &outParamStruct{}
+}
+
+func noopOutParam(out **outParamStruct) {}
+
+func maybeFillOutParam(out **outParamStruct, cond bool) {
+	if cond {
+		*out = &outParamStruct{}
+	}
+}
+
+func derefNilOutParam() int {
+	var p *outParamStruct
+	fillNilOutParam(&p)
+	return p.val //want "written through out parameter `out` of `fillNilOutParam\\(\\)` accessed field `val`" "unassigned variable `p` accessed field `val`"
+}
+
+func derefNilOutParamOverwritingNonnil() int {
+	p := &outParamStruct{}
+	clearOutParam(&p)
+	return p.val //want "written through out parameter `out` of `clearOutParam\\(\\)` accessed field `val`"
+}
+
+func derefNonnilOutParam() int {
+	var p *outParamStruct
+	fillNonnilOutParam(&p)
+	// TODO: this is a false positive since `fillNonnilOutParam` writes `*out` on every path. The
+	//  value before the call is currently joined with the written one for all callees, which
+	//  requires knowing (e.g., via a fact) which callees always write their out parameters.
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
+
+func derefNilOutParamChecked() int {
+	var p *outParamStruct
+	fillNilOutParam(&p)
+	if p != nil {
+		return p.val
+	}
+	return 0
+}
+
+// The callees may not write the out parameters on every path, leaving the values before the calls.
+
+func derefNoopOutParam() int {
+	var p *outParamStruct
+	noopOutParam(&p)
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
+
+func derefMaybeFilledOutParam(cond bool) int {
+	var p *outParamStruct
+	maybeFillOutParam(&p, cond)
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
+
+func derefMaybeFilledOutParamInitialized(cond bool) int {
+	p := &outParamStruct{}
+	maybeFillOutParam(&p, cond)
+	return p.val
+}
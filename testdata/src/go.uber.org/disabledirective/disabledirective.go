@@ -0,0 +1,48 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+// Package disabledirective tests that diagnostics within the ranges delimited by the paired
+// `//nilaway:disable` and `//nilaway:enable` comments are suppressed.
+package disabledirective
+
+func beforeDisabledRange() {
+	var x *int
+	print(*x) //want "dereferenced"
+}
+
+//nilaway:disable
+
+func insideDisabledRange() {
+	var x *int
+	print(*x)
+}
+
+//nilaway:enable
+
+func afterDisabledRange() {
+	var x *int
+	print(*x) //want "dereferenced"
+}
+
+func partiallyDisabled() {
+	var x *int
+	var y *int
+	var z *int
+	print(*x) //want "dereferenced"
+	//nilaway:disable
+	print(*y)
+	//nilaway:enable
+	print(*z) //want "dereferenced"
+}
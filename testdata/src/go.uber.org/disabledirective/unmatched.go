@@ -0,0 +1,36 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package disabledirective
+
+// This file tests that a `//nilaway:disable` comment without a matching `//nilaway:enable` comment
+// suppresses the diagnostics until the end of the file.
+
+func beforeUnmatchedDisable() {
+	var x *int
+	print(*x) //want "dereferenced"
+}
+
+//nilaway:disable
+
+func afterUnmatchedDisable() {
+	var x *int
+	print(*x)
+}
+
+func atEndOfFile() {
+	var x *int
+	print(*x)
+}
@@ -0,0 +1,92 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import "errors"
+
+// This file tests the error contract of a function whose only nonnil value result is returned
+// together with a nil error, while the nil value result is returned together with a nonnil error.
+// Callers that check the error get a nonnil value, while callers that ignore it get a nilable one.
+
+type branchT struct {
+	f int
+}
+
+var branchX branchT
+
+func branchGet(cond bool) (*branchT, error) {
+	if cond {
+		return nil, errors.New("some error")
+	}
+	return &branchX, nil
+}
+
+func branchCheckErr(cond bool) int {
+	v, err := branchGet(cond)
+	if err != nil {
+		return 0
+	}
+	return v.f
+}
+
+func branchCheckErrEarlyUse(cond bool) int {
+	v, err := branchGet(cond)
+	if err == nil {
+		return v.f
+	}
+	return 0
+}
+
+func branchIgnoreErr(cond bool) int {
+	v, _ := branchGet(cond)
+	return v.f //want "result 0 of `branchGet\\(\\)` used without checking the discarded error(.|\n)*accessed field `f`"
+}
+
+func branchUseBeforeCheck(cond bool) int {
+	v, err := branchGet(cond)
+	x := v.f //want "result 0 of `branchGet\\(\\)` lacking guarding(.|\n)*accessed field `f`"
+	if err != nil {
+		return 0
+	}
+	return x
+}
+
+// In contrast, if the value result is nonnil on all branches, ignoring the error is safe.
+func branchGetAlwaysNonNil(cond bool) (*branchT, error) {
+	if cond {
+		return &branchT{}, errors.New("some error")
+	}
+	return &branchT{f: 1}, nil
+}
+
+func branchIgnoreErrAlwaysNonNil(cond bool) int {
+	v, _ := branchGetAlwaysNonNil(cond)
+	return v.f
+}
+
+func branchGetAlwaysGlobal(cond bool) (*branchT, error) {
+	if cond {
+		return &branchX, errors.New("some error")
+	}
+	return &branchX, nil
+}
+
+func branchIgnoreErrAlwaysGlobal(cond bool) int {
+	v, _ := branchGetAlwaysGlobal(cond)
+	// TODO: this is a false positive since taking the address of a global struct variable is
+	//  currently modeled as a read of the global variable, which is not known to be nonnil when
+	//  checking the "always safe" paths.
+	return v.f //want "result 0 of `branchGetAlwaysGlobal\\(\\)` used without checking the discarded error"
+}
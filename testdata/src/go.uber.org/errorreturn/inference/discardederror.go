@@ -0,0 +1,70 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package inference
+
+import "errors"
+
+// This file tests the dedicated diagnostic for using the value results of error-returning
+// functions whose error results are explicitly discarded with the blank identifier.
+
+var discardDummy bool
+
+type discardT struct {
+	f int
+}
+
+func discardGet() (*discardT, error) {
+	if discardDummy {
+		return nil, errors.New("some error")
+	}
+	return &discardT{}, nil
+}
+
+func discardGetPair() (*discardT, *discardT, error) {
+	if discardDummy {
+		return nil, nil, errors.New("some error")
+	}
+	return &discardT{}, &discardT{}, nil
+}
+
+func derefWithDiscardedErr() int {
+	v, _ := discardGet()
+	return v.f //want "result 0 of `discardGet\\(\\)` used without checking the discarded error(.|\n)*accessed field `f`"
+}
+
+func derefWithDiscardedErrPair() int {
+	_, v, _ := discardGetPair()
+	return v.f //want "result 1 of `discardGetPair\\(\\)` used without checking the discarded error(.|\n)*accessed field `f`"
+}
+
+func derefWithUncheckedErr() int {
+	v, err := discardGet()
+	_ = err
+	return v.f //want "result 0 of `discardGet\\(\\)` lacking guarding(.|\n)*accessed field `f`"
+}
+
+func derefWithCheckedErr() int {
+	v, err := discardGet()
+	if err != nil {
+		return 0
+	}
+	return v.f
+}
+
+func derefWithIntentionallyDiscardedErr() int {
+	v, _ := discardGet() //nilaway:intentional-discard
+	return v.f
+}
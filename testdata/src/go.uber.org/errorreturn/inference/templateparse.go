@@ -0,0 +1,51 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	htmltemplate "html/template"
+	"text/template"
+)
+
+// This file tests that the templates returned from `Parse` (which are nil on errors) are
+// reported when used without checking the errors, while the templates from `New` and `Must` are
+// known to be nonnil.
+
+func parseWithDiscardedErr(s string) template.Template {
+	t, _ := template.New("t").Parse(s)
+	return *t //want "result 0 of `Parse\\(\\)` used without checking the discarded error(.|\n)*dereferenced"
+}
+
+func parseHTMLWithDiscardedErr(s string) htmltemplate.Template {
+	t, _ := htmltemplate.New("t").Parse(s)
+	return *t //want "result 0 of `Parse\\(\\)` used without checking the discarded error(.|\n)*dereferenced"
+}
+
+func parseWithCheckedErr(s string) template.Template {
+	t, err := template.New("t").Parse(s)
+	if err != nil {
+		return template.Template{}
+	}
+	return *t
+}
+
+func parseWithMust(s string) template.Template {
+	t := template.Must(template.New("t").Parse(s))
+	return *t
+}
+
+func newTemplate() template.Template {
+	return *template.New("t")
+}
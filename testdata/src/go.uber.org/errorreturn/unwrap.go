@@ -0,0 +1,44 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorreturn
+
+import "errors"
+
+// This file tests that `errors.Unwrap` is modeled as returning a nilable error, since it returns
+// nil if the error does not wrap another one.
+
+type wrappedErr struct {
+	msg string
+}
+
+func (e *wrappedErr) Error() string { return e.msg }
+
+func unwrapMessage(err error) string {
+	return errors.Unwrap(err).Error() //want "nilable by a trusted function called `Error"
+}
+
+func unwrapGuarded(err error) string {
+	if inner := errors.Unwrap(err); inner != nil {
+		return inner.Error()
+	}
+	return ""
+}
+
+func unwrapAssertGuarded(err error) string {
+	if inner, ok := errors.Unwrap(err).(*wrappedErr); ok {
+		return inner.msg
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+import (
+	"log"
+	"os"
+)
+
+// This file tests the calls to functions that never return, which make the code after them
+// unreachable such that a preceding nil check acts as a guard.
+
+type failer interface {
+	FailNow()
+}
+
+// mustFail never returns since the FailNow implementations never do, which cannot be known
+// statically without the directive.
+//
+//nilaway:noreturn
+func mustFail(f failer) {
+	f.FailNow()
+}
+
+// mayFail is the same as mustFail, but without the directive.
+func mayFail(f failer) {
+	f.FailNow()
+}
+
+// fatal is known to never return without the directive since log.Fatal never does.
+func fatal(msg string) {
+	log.Fatal(msg)
+}
+
+type checker struct {
+	f failer
+}
+
+//nilaway:noreturn
+func (c *checker) fail() {
+	c.f.FailNow()
+}
+
+func guardedByNoReturnHelper(f failer) string {
+	var c *conn
+	if c == nil {
+		mustFail(f)
+	}
+	return c.addr
+}
+
+func guardedByNoReturnMethod(ch *checker) string {
+	var c *conn
+	if c == nil {
+		ch.fail()
+	}
+	return c.addr
+}
+
+func guardedByMayReturnHelper(f failer) string {
+	var c *conn
+	if c == nil {
+		mayFail(f)
+	}
+	return c.addr //want "accessed field `addr`"
+}
+
+func guardedByFatalHelper() string {
+	var c *conn
+	if c == nil {
+		fatal("no conn")
+	}
+	return c.addr
+}
+
+func guardedByLogFatal() string {
+	var c *conn
+	if c == nil {
+		log.Fatalf("no conn")
+	}
+	return c.addr
+}
+
+func guardedByOSExit() string {
+	var c *conn
+	if c == nil {
+		os.Exit(1)
+	}
+	return c.addr
+}
+
+func guardedByPanic() string {
+	var c *conn
+	if c == nil {
+		panic("no conn")
+	}
+	return c.addr
+}
+
+func noReturnHelperInOtherBranch(f failer, ok bool) string {
+	var c *conn
+	if !ok {
+		mustFail(f)
+	}
+	return c.addr //want "accessed field `addr`"
+}
@@ -0,0 +1,107 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package inference
+
+// This file tests the predicate contracts, where a boolean result of a function guarantees the
+// nilness of its arguments.
+
+type conn struct {
+	addr string
+}
+
+func lookup(addr string) *conn {
+	if addr == "" {
+		return nil
+	}
+	return &conn{addr: addr}
+}
+
+// nilaway:contract(arg0=nonnil <- result=true)
+func isValid(c *conn) bool {
+	return c != nil && c.addr != ""
+}
+
+// nilaway:contract(arg0=nonnil <- result=false)
+func isInvalid(c *conn) bool {
+	return c == nil || c.addr == ""
+}
+
+// nilaway:contract(arg0=nonnil, arg1=nonnil <- result=true)
+func bothValid(a, b *conn) bool {
+	return isValid(a) && isValid(b)
+}
+
+func guardedByValid(addr string) string {
+	c := lookup(addr)
+	if isValid(c) {
+		return c.addr
+	}
+	return ""
+}
+
+func guardedByNegatedValid(addr string) string {
+	c := lookup(addr)
+	if !isValid(c) {
+		return ""
+	}
+	return c.addr
+}
+
+func guardedByInvalid(addr string) string {
+	c := lookup(addr)
+	if isInvalid(c) {
+		return ""
+	}
+	return c.addr
+}
+
+func guardedByBothValid(addrA, addrB string) string {
+	a, b := lookup(addrA), lookup(addrB)
+	if bothValid(a, b) {
+		return a.addr + b.addr
+	}
+	return ""
+}
+
+func guardedInConjunction(addr string, ok bool) string {
+	c := lookup(addr)
+	if ok && isValid(c) {
+		return c.addr
+	}
+	return ""
+}
+
+func derefOnWrongBranchOfValid() string {
+	var c *conn
+	if isValid(c) {
+		return ""
+	}
+	return c.addr //want "accessed field `addr`"
+}
+
+func derefOnWrongBranchOfInvalid() string {
+	var c *conn
+	if isInvalid(c) {
+		return c.addr //want "accessed field `addr`"
+	}
+	return ""
+}
+
+func derefWithoutGuard() string {
+	var c *conn
+	_ = isValid(c)
+	return c.addr //want "accessed field `addr`"
+}
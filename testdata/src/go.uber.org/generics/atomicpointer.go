@@ -0,0 +1,52 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generics
+
+import "sync/atomic"
+
+// This file tests that `atomic.Pointer[T].Load` (and `Swap`) are modeled as returning nilable
+// pointers, since nothing may have been stored yet.
+
+type config struct {
+	name string
+}
+
+var current atomic.Pointer[config]
+
+func loadUnset() string {
+	var p atomic.Pointer[config]
+	return p.Load().name //want "accessed field `name`"
+}
+
+func loadGlobal() string {
+	c := current.Load()
+	return c.name //want "accessed field `name`"
+}
+
+func loadGuarded() string {
+	if c := current.Load(); c != nil {
+		return c.name
+	}
+	return ""
+}
+
+func swapPrevious(next *config) string {
+	prev := current.Swap(next)
+	return prev.name //want "accessed field `name`"
+}
+
+func loadInt(p *atomic.Pointer[int]) int {
+	return *p.Load() //want "dereferenced"
+}
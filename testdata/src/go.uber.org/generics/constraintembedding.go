@@ -0,0 +1,103 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generics
+
+// This file tests that the methods promoted from the interfaces embedded in the constraints of
+// type parameters carry the nilability annotations of their declarations.
+
+type entry struct {
+	key string
+}
+
+type getter interface {
+	// nilable(result 0)
+	Get() *entry
+}
+
+type lister interface {
+	List() *entry
+}
+
+type finder interface {
+	// nilable(result 0)
+	Find(key string) *entry
+}
+
+// store embeds the interfaces, so its methods are promoted from them.
+type store interface {
+	getter
+	lister
+}
+
+func getKey[S store](s S) string {
+	return s.Get().key //want "result 0 of `Get\\(\\)`"
+}
+
+func getKeyChecked[S store](s S) string {
+	if e := s.Get(); e != nil {
+		return e.key
+	}
+	return ""
+}
+
+func listKey[S store](s S) string {
+	return s.List().key
+}
+
+// inline constraints embedding the interfaces are resolved the same way.
+func findKey[S interface {
+	getter
+	finder
+}](s S, key string) string {
+	return s.Find(key).key //want "result 0 of `Find\\(\\)`"
+}
+
+// nested embeddings are resolved transitively.
+type nestedStore interface {
+	store
+}
+
+func nestedGetKey[S nestedStore](s S) string {
+	return s.Get().key //want "result 0 of `Get\\(\\)`"
+}
+
+// otherGetter declares the same method `Get` as getter but without the nilable annotation. Since
+// the calls through an interface embedding both are resolved to the declaration in the first
+// embedded interface, the declarations must agree on their nilability, which is reported here.
+type otherGetter interface {
+	Get() *entry //want "returned as result 0 from interface method `otherGetter.Get\\(\\)` \\(implemented by `getter.Get\\(\\)`\\)"
+}
+
+// collidingStore embeds two interfaces declaring the same method `Get`, where only one of them is
+// annotated as nilable, and the calls are resolved to the nilable one.
+type collidingStore interface {
+	getter
+	otherGetter
+}
+
+func collidingGetKey[S collidingStore](s S) string {
+	return s.Get().key //want "result 0 of `Get\\(\\)`"
+}
+
+// collidingStoreReversed embeds the same interfaces in the reversed order, so the calls are
+// resolved to the nonnil `otherGetter.Get`, whose mismatch with `getter.Get` is reported above.
+type collidingStoreReversed interface {
+	otherGetter
+	getter
+}
+
+func collidingGetKeyReversed[S collidingStoreReversed](s S) string {
+	return s.Get().key
+}
@@ -0,0 +1,75 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inference tests the potential nil panics at the initialization of the package, i.e.,
+// within the initializer expressions of package-level variables and within `init` functions.
+package inference
+
+var dummy bool
+
+type config struct {
+	name string
+}
+
+func loadConfig() *config {
+	if dummy {
+		return nil
+	}
+	return &config{}
+}
+
+var cfg = loadConfig()
+
+// The initializer dereferences another possibly-nil package-level variable.
+var cfgName = cfg.name //want "accessed field `name`"
+
+// The initializer depends on a variable declared later, which is still initialized first.
+var laterName = later.name //want "accessed field `name`"
+var later = loadConfig()
+
+var direct = loadConfig().name //want "accessed field `name`"
+
+var uninit *config
+var uninitName = uninit.name //want "accessed field `name`"
+
+var nonnilCfg = &config{}
+var nonnilName = nonnilCfg.name
+
+var count = new(int)
+var countVal = *count + 1
+
+// The bodies of function literals are not run at the initialization.
+var lazyName = func() string {
+	if cfg != nil {
+		return cfg.name
+	}
+	return ""
+}
+
+// initCfg is only dereferenced in the init functions, since NilAway reports a single error for
+// all the dereferences of the same nilable global variable.
+var initCfg = loadConfig()
+
+func init() {
+	print(initCfg.name) //want "accessed field `name`"
+}
+
+func init() {
+	if initCfg != nil {
+		print(initCfg.name)
+	}
+}
+
+// The right operands of the logical operators are only conditionally evaluated.
+var guardedName = cfg != nil && cfg.name != ""
@@ -0,0 +1,87 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package inference
+
+// This file tests that nil checks on a variable also guard the variables aliasing it via simple
+// assignments, and vice versa.
+
+var dummyAlias bool
+
+func getPtr() *int {
+	if dummyAlias {
+		return nil
+	}
+	return new(int)
+}
+
+func derefAlias() {
+	a := getPtr()
+	b := a
+	print(*b) //want "dereferenced"
+}
+
+func aliasThenGuardOriginal() {
+	a := getPtr()
+	b := a
+	if a != nil {
+		print(*b)
+	}
+}
+
+func aliasThenGuardAlias() {
+	a := getPtr()
+	b := a
+	if b != nil {
+		print(*a)
+	}
+}
+
+func aliasThenEarlyReturn() {
+	a := getPtr()
+	b := a
+	if a == nil {
+		return
+	}
+	print(*b)
+}
+
+func aliasChainThenGuard() {
+	a := getPtr()
+	b := a
+	var c *int
+	c = b
+	if c != nil {
+		print(*a + *b)
+	}
+}
+
+func aliasThenReassignThenGuard(m map[int]*int) {
+	a := m[0]
+	b := a
+	b = new(int)
+	if b != nil {
+		print(*a) //want "dereferenced"
+	}
+}
+
+func aliasThenGuardThenReassign(m map[int]*int) {
+	a := getPtr()
+	b := a
+	if a != nil {
+		b = m[1]
+		print(*b) //want "dereferenced"
+	}
+}
@@ -0,0 +1,65 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the nilability of the pointer fields of anonymous structs, which can be
+// annotated via the doc or line comments of the fields since there is no type declaration to
+// carry a docstring.
+
+func anonAnnotatedLineComment() int {
+	v := struct {
+		p *int // nilable(p)
+	}{}
+	return *v.p //want "field `p` dereferenced"
+}
+
+func anonAnnotatedDocComment() int {
+	v := struct {
+		// nilable(p)
+		p *int
+	}{}
+	return *v.p //want "field `p` dereferenced"
+}
+
+func anonAnnotatedGuarded() int {
+	v := struct {
+		p *int // nilable(p)
+	}{}
+	if v.p != nil {
+		return *v.p
+	}
+	return 0
+}
+
+func anonUnannotatedSet() int {
+	v := struct{ p *int }{p: new(int)}
+	return *v.p
+}
+
+func anonAssignedNil() int {
+	v := struct{ p *int }{p: new(int)}
+	v.p = nil
+	return *v.p //want "literal `nil`(.|\n)*dereferenced"
+}
+
+type withAnonField struct {
+	inner struct {
+		p *int // nilable(p)
+	}
+}
+
+func nestedAnonAnnotated(w *withAnonField) int {
+	return *w.inner.p //want "field `p` dereferenced"
+}
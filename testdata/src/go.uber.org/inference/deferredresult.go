@@ -0,0 +1,95 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that the deferred function literals assigning the named results overwrite the
+// values returned by the return statements, e.g., `defer func() { p = maybeNil() }()`.
+
+type deferredResult struct {
+	val int
+}
+
+func nilDeferredResult() *deferredResult {
+	return nil
+}
+
+func overwriteInDefer() (p *deferredResult) {
+	var x deferredResult
+	defer func() { p = nilDeferredResult() }()
+	return &x
+}
+
+func useOverwriteInDefer() {
+	print(overwriteInDefer().val) //want "result 0 of `nilDeferredResult\\(\\)` returned from `overwriteInDefer\\(\\)` via named return `p`"
+}
+
+// The deferred call may or may not overwrite the result, so the result is still nilable.
+func overwriteInDeferIf(b bool) (p *deferredResult) {
+	var x deferredResult
+	defer func() {
+		if b {
+			p = nil
+		}
+	}()
+	return &x
+}
+
+func useOverwriteInDeferIf() {
+	print(overwriteInDeferIf(true).val) //want "literal `nil` returned from `overwriteInDeferIf\\(\\)` via named return `p`"
+}
+
+func overwriteNonnilInDefer() (p *deferredResult) {
+	var x deferredResult
+	defer func() { p = &x }()
+	return &x
+}
+
+func useOverwriteNonnilInDefer() {
+	print(overwriteNonnilInDefer().val)
+}
+
+// The return statement before the defer statement does not run the deferred call.
+func returnBeforeDefer(b bool) (p *deferredResult) {
+	var x deferredResult
+	if b {
+		return &x
+	}
+	defer func() { p = &x }()
+	return &x
+}
+
+func useReturnBeforeDefer() {
+	print(returnBeforeDefer(true).val)
+}
+
+// The deferred writes of the error-returning functions (e.g., clearing the results on errors) are
+// bound to the error contract, hence they are not modeled.
+func clearOnError() (p *deferredResult, err error) {
+	var x deferredResult
+	defer func() {
+		if err != nil {
+			p = nil
+		}
+	}()
+	return &x, nil
+}
+
+func useClearOnError() {
+	p, err := clearOnError()
+	if err != nil {
+		return
+	}
+	print(p.val)
+}
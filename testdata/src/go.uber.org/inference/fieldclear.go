@@ -0,0 +1,59 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that a pointer field that is cleared (i.e., set to nil) in the middle of a
+// function is tracked as nilable for the subsequent dereferences in the same function, even if it
+// was assigned a nonnil value earlier.
+
+type clearable struct {
+	p *int
+}
+
+func clearUnconditionally(s *clearable) int {
+	x := 1
+	s.p = &x
+	print(*s.p)
+	s.p = nil
+	return *s.p //want "dereferenced"
+}
+
+func clearConditionally(s *clearable) int {
+	x := 1
+	s.p = &x
+	if dummyBool {
+		s.p = nil
+	}
+	return *s.p //want "dereferenced"
+}
+
+func clearThenReassign(s *clearable) int {
+	x := 1
+	s.p = nil
+	s.p = &x
+	return *s.p
+}
+
+func clearThenCheck(s *clearable) int {
+	x := 1
+	s.p = &x
+	if dummyBool {
+		s.p = nil
+	}
+	if s.p != nil {
+		return *s.p
+	}
+	return 0
+}
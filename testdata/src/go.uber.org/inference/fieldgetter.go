@@ -0,0 +1,71 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the nilability of a field flows to the result of a method that returns it from its
+// receiver, unless the method guards against the nil value before returning it.
+
+type fieldGetterT struct {
+	f int
+}
+
+// nilable(p)
+type fieldGetterS struct {
+	p *fieldGetterT
+	q *fieldGetterT
+}
+
+func (s *fieldGetterS) P() *fieldGetterT {
+	return s.p
+}
+
+func (s *fieldGetterS) GuardedP() *fieldGetterT {
+	if s.p == nil {
+		return &fieldGetterT{}
+	}
+	return s.p
+}
+
+func (s *fieldGetterS) GuardedPNegated() *fieldGetterT {
+	if s.p != nil {
+		return s.p
+	}
+	return &fieldGetterT{}
+}
+
+// The nil check only guards one of the returns.
+func (s *fieldGetterS) PartiallyGuardedP() *fieldGetterT {
+	if dummyBool && s.p != nil {
+		return s.p
+	}
+	return s.p
+}
+
+// The field `q` is not annotated, so its nilability is inferred from the assignments to it.
+func (s *fieldGetterS) Q() *fieldGetterT {
+	return s.q
+}
+
+func (s *fieldGetterS) clearQ() {
+	s.q = nil
+}
+
+func useFieldGetters(s *fieldGetterS) {
+	print(s.P().f) //want "accessed field `f`"
+	print(s.GuardedP().f)
+	print(s.GuardedPNegated().f)
+	print(s.PartiallyGuardedP().f) //want "accessed field `f`"
+	print(s.Q().f)                 //want "accessed field `f`"
+}
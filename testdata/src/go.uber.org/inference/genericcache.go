@@ -0,0 +1,108 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the zero-value-return paths of the methods of generic containers, where the
+// zero value of a type parameter is nil if the type parameter is instantiated with a pointer.
+
+type cacheEntry struct {
+	f int
+}
+
+type Cache[K comparable, V any] struct {
+	entries map[K]V
+}
+
+func (c *Cache[K, V]) GetOrZero(k K) V {
+	if v, ok := c.entries[k]; ok {
+		return v
+	}
+	var zero V
+	return zero
+}
+
+func (c *Cache[K, V]) GetOrDefault(k K, def V) V {
+	if v, ok := c.entries[k]; ok {
+		return v
+	}
+	return def
+}
+
+func (c *Cache[K, V]) GetOrNew(k K) V {
+	if v, ok := c.entries[k]; ok {
+		return v
+	}
+	return *new(V)
+}
+
+func (c *Cache[K, V]) Lookup(k K) (V, bool) {
+	if v, ok := c.entries[k]; ok {
+		return v, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *Cache[K, V]) Zero() (zero V) {
+	return
+}
+
+func useCacheGetOrZero() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	e := c.GetOrZero("missing")
+	return e.f //want "unassigned variable `zero` returned from `GetOrZero\\(\\)`(.|\n)*result 0 of `GetOrZero\\(\\)` accessed field `f`"
+}
+
+func useCacheNamedZero() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	return c.Zero().f //want "unassigned variable `zero` returned from `Zero\\(\\)` via named return `zero`"
+}
+
+func useCacheGetOrDefault() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	return c.GetOrDefault("missing", &cacheEntry{}).f
+}
+
+func useCacheGetOrZeroGuarded() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	if e := c.GetOrZero("missing"); e != nil {
+		return e.f
+	}
+	return 0
+}
+
+func useCacheGetOrZeroValue() int {
+	c := &Cache[string, cacheEntry]{entries: map[string]cacheEntry{}}
+	return c.GetOrZero("missing").f
+}
+
+func useCacheGetOrNew() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	return c.GetOrNew("missing").f //want "zero value `\\*new\\(V\\)` returned from `GetOrNew\\(\\)`"
+}
+
+func useCacheLookup() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	e, _ := c.Lookup("missing")
+	return e.f //want "result 0 of `Lookup\\(\\)` lacking guarding"
+}
+
+func useCacheLookupChecked() int {
+	c := &Cache[string, *cacheEntry]{entries: map[string]*cacheEntry{}}
+	if e, ok := c.Lookup("missing"); ok {
+		return e.f
+	}
+	return 0
+}
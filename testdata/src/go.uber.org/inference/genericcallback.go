@@ -0,0 +1,45 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the result of a function invoked through a function-typed type parameter carries the
+// nilability of the callback's result.
+
+type callbackT struct {
+	f int
+}
+
+func Apply[F func() *T, T any](f F) *T {
+	return f()
+}
+
+func nilCallback() *callbackT {
+	if dummyBool {
+		return nil
+	}
+	return &callbackT{}
+}
+
+func nonnilCallback() *callbackT {
+	return &callbackT{}
+}
+
+func useApplyNilable() int {
+	return Apply(nilCallback).f //want "accessed field `f`"
+}
+
+func useApplyNonnil() int {
+	return Apply(nonnilCallback).f
+}
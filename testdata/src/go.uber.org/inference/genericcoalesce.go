@@ -0,0 +1,55 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the result of a variadic generic helper with a path returning the zero value of its
+// type parameter is nilable when the type parameter is instantiated with a pointer type, e.g., a
+// coalescing helper called with nil pointers only.
+
+type coalesceT struct {
+	f int
+}
+
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+func useCoalesceNils() int {
+	var a, b *coalesceT
+	// The error below is grouped with the one in useCoalesceSpread, since they share the same
+	// nil source.
+	return Coalesce(a, b).f //want "unassigned variable `zero` returned from `Coalesce\\(\\)`(.|\n)*result 0 of `Coalesce\\(\\)` accessed field `f`(.|\n)*genericcoalesce.go:43"
+}
+
+func useCoalesceSpread(ptrs []*int) int {
+	return *Coalesce(ptrs...)
+}
+
+func useCoalesceChecked(a, b *coalesceT) int {
+	if p := Coalesce(a, b); p != nil {
+		return p.f
+	}
+	return 0
+}
+
+func useCoalesceVal() int {
+	return Coalesce(0, 1) + Coalesce[int]()
+}
@@ -0,0 +1,53 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that a pointer result of a generic function is inferred nilable from a return-nil path in
+// its body, regardless of how the function is instantiated at the call sites.
+
+type findT struct {
+	f int
+}
+
+func Find[T any](s []*T, pred func(*T) bool) *T {
+	for _, e := range s {
+		if pred(e) {
+			return e
+		}
+	}
+	return nil
+}
+
+func isPositive(t *findT) bool {
+	return t.f > 0
+}
+
+func useFindUnchecked(s []*findT) int {
+	t := Find(s, isPositive)
+	// The error below is grouped with the one in useFindExplicitUnchecked, since they share the
+	// same nil source.
+	return t.f //want "literal `nil` returned from `Find\\(\\)`(.|\n)*result 0 of `Find\\(\\)` accessed field `f`(.|\n)*genericfind.go:45"
+}
+
+func useFindExplicitUnchecked(s []*int) int {
+	return *Find[int](s, func(*int) bool { return true })
+}
+
+func useFindChecked(s []*findT) int {
+	if t := Find(s, isPositive); t != nil {
+		return t.f
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the flow of nilability through methods of generic types, where the nilability
+// of the values depends on the type arguments the generic type is instantiated with.
+
+// SafeMap is a generic wrapper around a map, whose `Get` method returns the zero value of `V` for
+// missing keys, which is nil if `V` is instantiated with a pointer type.
+type SafeMap[K comparable, V any] struct{ m map[K]V }
+
+func (s *SafeMap[K, V]) Get(k K) V {
+	return s.m[k]
+}
+
+func useSafeMapWithPointer() {
+	s := &SafeMap[string, *int]{m: map[string]*int{}}
+	v := s.Get("missing")
+	print(*v) //want "deep read from field `m` lacking guarding; returned from `Get\\(\\)` in position 0(.|\n)*result 0 of `Get\\(\\)` dereferenced"
+}
+
+func useSafeMapWithPointerGuarded() {
+	s := &SafeMap[string, *int]{m: map[string]*int{}}
+	if v := s.Get("missing"); v != nil {
+		print(*v)
+	}
+}
+
+func useSafeMapWithValue() int {
+	// Values of non-pointer types cannot be nil, hence no errors should be reported.
+	s := &SafeMap[string, int]{m: map[string]int{}}
+	return s.Get("missing") + 1
+}
+
+func getGeneric[V any](m map[string]V) V {
+	return m["missing"]
+}
+
+func useGetGenericWithPointer() {
+	v := getGeneric(map[string]*int{})
+	print(*v) //want "deep read from parameter `m` lacking guarding; returned from `getGeneric\\(\\)` in position 0"
+}
+
+// Number bars nilness in its type set, so values of its type parameters can never be nil.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+func getNumber[V Number](m map[string]V) V {
+	return m["missing"]
+}
+
+func useGetNumber() int {
+	return getNumber(map[string]int{}) + 1
+}
@@ -0,0 +1,61 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that a returned zero value of a type parameter is nilable when the type parameter is
+// instantiated with a pointer type.
+
+type zeroU struct {
+	f int
+}
+
+type zeroV struct {
+	f int
+}
+
+func Zero[T any]() T {
+	var z T
+	return z
+}
+
+func ZeroPair[K comparable, V any]() (K, V) {
+	var k K
+	var v V
+	return k, v
+}
+
+func useZeroPtr() int {
+	p := Zero[*zeroU]()
+	return p.f //want "unassigned variable `z` returned from `Zero\\(\\)`(.|\n)*result 0 of `Zero\\(\\)` accessed field `f`"
+}
+
+func useZeroPairPtr() int {
+	_, v := ZeroPair[string, *zeroU]()
+	return v.f //want "unassigned variable `v` returned from `ZeroPair\\(\\)`"
+}
+
+func useZeroVal() int {
+	v := Zero[zeroU]()
+	i := Zero[int]()
+	_, w := ZeroPair[int, zeroV]()
+	return v.f + i + w.f
+}
+
+// Test that the calls to function values in an indexable are not mistaken for the explicit
+// instantiations of generic functions.
+
+func writeThroughIndexedFunc(fs []func() []*int, x *int) {
+	fs[0]()[0] = x
+}
@@ -0,0 +1,86 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the nilability of the result of a lazy getter is concluded from the nilability of the
+// function that initializes the cached field on the first call.
+
+type lazyT struct {
+	f int
+}
+
+func computeNilable() *lazyT {
+	if dummyBool {
+		return nil
+	}
+	return &lazyT{}
+}
+
+// nonnil(result 0)
+func computeNonnil() *lazyT {
+	return &lazyT{}
+}
+
+type lazyNilable struct {
+	t *lazyT
+}
+
+func (s *lazyNilable) Get() *lazyT {
+	if s.t == nil {
+		s.t = computeNilable()
+	}
+	return s.t
+}
+
+type lazyNonnil struct {
+	t *lazyT
+}
+
+func (s *lazyNonnil) Get() *lazyT {
+	if s.t == nil {
+		s.t = computeNonnil()
+	}
+	return s.t
+}
+
+// GetOrInit is the early-return variant of the lazy-init pattern.
+func (s *lazyNilable) GetOrInit() *lazyT {
+	if s.t != nil {
+		return s.t
+	}
+	s.t = computeNilable()
+	return s.t
+}
+
+// GetOrInit is the early-return variant of the lazy-init pattern.
+func (s *lazyNonnil) GetOrInit() *lazyT {
+	if s.t != nil {
+		return s.t
+	}
+	s.t = computeNonnil()
+	return s.t
+}
+
+func useLazyNilable(s *lazyNilable) int {
+	return s.Get().f //want "literal `nil` returned from `computeNilable\\(\\)`(.|\n)*returned from `Get\\(\\)`(.|\n)*result 0 of `Get\\(\\)` accessed field `f`"
+}
+
+func useLazyNilableEarlyReturn(s *lazyNilable) int {
+	return s.GetOrInit().f //want "result 0 of `GetOrInit\\(\\)` accessed field `f`"
+}
+
+func useLazyNonnil(s *lazyNonnil) int {
+	return s.Get().f + s.GetOrInit().f
+}
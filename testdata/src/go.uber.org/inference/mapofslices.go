@@ -0,0 +1,54 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the common map-of-slices pattern `m[k] = append(m[k], x)`: the read `m[k]`
+// may yield a nil slice, which `append` handles fine, and the assignment stores a nonnil slice
+// at the index, such that a subsequent read-and-index at the same index is not reported.
+
+func appendConstKey() int {
+	m := make(map[string][]int)
+	m["a"] = append(m["a"], 1)
+	return m["a"][0]
+}
+
+func appendConstKeyParam(m map[string][]int) int {
+	m["a"] = append(m["a"], 1)
+	return m["a"][0]
+}
+
+func appendVarKey(m map[string][]int, k string) int {
+	m[k] = append(m[k], 1)
+	return m[k][0]
+}
+
+func appendPointerElem(m map[string][]*int) *int {
+	m["a"] = append(m["a"], new(int))
+	return m["a"][0]
+}
+
+func appendTwice(m map[string][]int) int {
+	m["a"] = append(m["a"], 1)
+	m["a"] = append(m["a"], 2)
+	return m["a"][1]
+}
+
+func appendInBranch(m map[string][]int, cond bool) int {
+	if cond {
+		m["a"] = append(m["a"], 1)
+		return m["a"][0]
+	}
+	return 0
+}
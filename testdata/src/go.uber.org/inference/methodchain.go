@@ -0,0 +1,80 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that the nilability is checked at each link of a method call chain that spans
+// both pointer and interface results, e.g., `getClient().Conn().Write(...)`.
+
+type Conn interface {
+	Write(b []byte) int
+}
+
+type conn struct{}
+
+func (*conn) Write(b []byte) int { return len(b) }
+
+// The first call of the chain returns a nilable pointer, which is then used as the receiver of
+// `Conn()` and dereferenced in it.
+
+type nilableClient struct {
+	conn Conn
+}
+
+func (c *nilableClient) Conn() Conn {
+	return c.conn //want "literal `nil` returned from `getNilableClient\\(\\)` in position 0(.|\n)*result 0 of `getNilableClient\\(\\)` used as receiver to call `Conn\\(\\)`(.|\n)*accessed field `conn`"
+}
+
+var globalClient *nilableClient
+
+func getNilableClient() *nilableClient {
+	if globalClient == nil {
+		return nil
+	}
+	return globalClient
+}
+
+func useChainNilableFirst() {
+	getNilableClient().Conn().Write(nil)
+}
+
+// The second call of the chain returns a nilable interface, which is then used for calling
+// `Write()`.
+
+type client struct {
+	conn Conn
+}
+
+func (c *client) NilableConn() Conn {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn
+}
+
+func (c *client) Conn() Conn {
+	return &conn{}
+}
+
+func getClient() *client {
+	return &client{conn: &conn{}}
+}
+
+func useChainNilableSecond() {
+	getClient().NilableConn().Write(nil) //want "literal `nil` returned from `NilableConn\\(\\)` in position 0(.|\n)*result 0 of `NilableConn\\(\\)` called `Write\\(\\)`"
+}
+
+func useChainSafe() {
+	getClient().Conn().Write(nil)
+}
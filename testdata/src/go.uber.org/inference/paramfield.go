@@ -0,0 +1,65 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests the flow of nilability from a parameter of a function, to a field that the
+// function stores it in, and then to the dereference of that field on the struct returned by the
+// function (i.e., param -> field -> result).
+
+type holder struct {
+	p *int
+}
+
+// newHolder is a setter-style constructor that stores the parameter in a field of the result.
+func newHolder(p *int) *holder {
+	h := &holder{}
+	h.p = p
+	return h
+}
+
+type valueHolder struct {
+	p *int
+}
+
+// withP is a setter-style function that stores the parameter in a field of the struct passed by
+// value and returns the updated copy.
+func withP(h valueHolder, p *int) valueHolder {
+	h.p = p
+	return h
+}
+
+func passNilableToSetter() {
+	h := newHolder(nil)
+	print(*h.p) //want "literal `nil` passed as arg `p` to `newHolder\\(\\)`(.|\n)*function parameter `p` assigned into field `p`"
+}
+
+type otherHolder struct {
+	q *int
+}
+
+func setQ(h *otherHolder, q *int) *otherHolder {
+	h.q = q
+	return h
+}
+
+func passNilableToValueSetter() {
+	h := withP(valueHolder{}, nil)
+	print(*h.p) //want "literal `nil` passed as arg `p` to `withP\\(\\)`(.|\n)*function parameter `p` assigned into field `p`"
+}
+
+func passNonnilToSetter() {
+	h := setQ(&otherHolder{}, &dummyInt)
+	print(*h.q)
+}
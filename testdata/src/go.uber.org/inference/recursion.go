@@ -0,0 +1,79 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the pointer results of self-recursive and mutually recursive functions are inferred
+// nilable from their return-nil paths, i.e., the recursive calls do not make the inference assume
+// the results to be nonnil before the return-nil paths are seen.
+
+type recNode struct {
+	val  int
+	next *recNode
+}
+
+func findRec(n *recNode, v int) *recNode {
+	if n == nil {
+		return nil
+	}
+	if n.val == v {
+		return n
+	}
+	return findRec(n.next, v)
+}
+
+func useFindRecUnchecked(n *recNode) int {
+	return findRec(n, 42).val //want "literal `nil` returned from `findRec\\(\\)`(.|\n)*result 0 of `findRec\\(\\)` accessed field `val`"
+}
+
+func useFindRecChecked(n *recNode) int {
+	if m := findRec(n, 42); m != nil {
+		return m.val
+	}
+	return 0
+}
+
+// findEven and findOdd walk the list alternately, where only findOdd returns nil directly.
+
+func findEven(n *recNode) *recNode {
+	if n != nil && n.val%2 == 0 {
+		return n
+	}
+	return findOdd(n)
+}
+
+func findOdd(n *recNode) *recNode {
+	if n == nil {
+		return nil
+	}
+	if n.val%2 == 1 {
+		return n
+	}
+	return findEven(n.next)
+}
+
+func useFindEvenUnchecked(n *recNode) int {
+	return findEven(n).val //want "literal `nil` returned from `findOdd\\(\\)`(.|\n)*result 0 of `findEven\\(\\)` accessed field `val`"
+}
+
+func useFindOddUnchecked(n *recNode) int {
+	return findOdd(n).val //want "literal `nil` returned from `findOdd\\(\\)`(.|\n)*result 0 of `findOdd\\(\\)` accessed field `val`"
+}
+
+func useFindEvenChecked(n *recNode) int {
+	if m := findEven(n); m != nil {
+		return m.val
+	}
+	return 0
+}
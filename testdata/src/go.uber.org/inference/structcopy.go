@@ -0,0 +1,67 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that the nilability of a pointer field flows through a copy of its (value)
+// struct, such that a guard on the field of the original struct also applies to the field of a
+// copy made after the guard, while a mutation of the field of the copy does not affect the
+// original struct.
+
+type copyable struct {
+	p *int
+}
+
+func clearCopyable(c *copyable) {
+	c.p = nil
+}
+
+func copyUnguarded(a copyable) int {
+	b := a
+	return *b.p //want "dereferenced"
+}
+
+func copyAfterGuard(a copyable) int {
+	if a.p != nil {
+		b := a
+		return *b.p
+	}
+	return 0
+}
+
+func copyThenMutate(a copyable) int {
+	if a.p != nil {
+		b := a
+		b.p = nil
+		return *b.p //want "literal `nil`(.|\n)*dereferenced"
+	}
+	return 0
+}
+
+func copyThenMutateOriginal(a copyable) int {
+	if a.p != nil {
+		b := a
+		b.p = nil
+		// The original struct is not affected by the mutation of its copy.
+		return *a.p
+	}
+	return 0
+}
+
+func copyThenReassign(a copyable) int {
+	x := 1
+	b := a
+	b.p = &x
+	return *b.p
+}
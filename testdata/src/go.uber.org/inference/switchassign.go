@@ -0,0 +1,98 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that the nilability of a local variable assigned in the cases of a switch
+// statement is the join of the nilabilities of all cases, including the path where no case
+// matches (if there is no `default` case) and the variable keeps its previous value.
+
+var switchAssignVal int
+
+func switchAssignNilCase(x int) int {
+	var p *int
+	switch x {
+	case 1:
+		p = &switchAssignVal
+	case 2:
+		p = nil
+	default:
+		p = new(int)
+	}
+	return *p //want "literal `nil` dereferenced(.|\n)*`nil` to `p`"
+}
+
+func switchAssignNoDefault(x int) int {
+	var p *int
+	switch x {
+	case 1:
+		p = &switchAssignVal
+	case 2:
+		p = new(int)
+	}
+	return *p //want "unassigned variable `p` dereferenced"
+}
+
+func switchAssignWithDefault(x int) int {
+	var p *int
+	switch x {
+	case 1:
+		p = &switchAssignVal
+	default:
+		p = new(int)
+	}
+	return *p
+}
+
+func switchAssignExhaustiveBool(b bool) int {
+	var p *int
+	switch b {
+	case true:
+		p = &switchAssignVal
+	case false:
+		p = new(int)
+	}
+	return *p
+}
+
+func switchAssignExhaustiveBoolList(b bool) int {
+	var p *int
+	switch b {
+	case true, false:
+		p = &switchAssignVal
+	}
+	return *p
+}
+
+func switchAssignPartialBool(b bool) int {
+	var p *int
+	switch b {
+	case true:
+		p = &switchAssignVal
+	}
+	return *p //want "unassigned variable `p` dereferenced"
+}
+
+func switchAssignFallthrough(x int) int {
+	var p *int
+	switch x {
+	case 1:
+		fallthrough
+	case 2:
+		p = &switchAssignVal
+	default:
+		p = new(int)
+	}
+	return *p
+}
@@ -0,0 +1,86 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that all return statements across the cases of a switch statement contribute
+// to the nilability of the function result, including the implicit zero-value return path when
+// a switch without a `default` case does not match.
+
+var switchVal int
+
+// Every case returns nonnil, but there is no `default` case, so the (named) result keeps its
+// zero value (nil) if no case matches.
+func switchNoDefault(x int) (r *int) {
+	switch x {
+	case 1:
+		r = &switchVal
+	case 2:
+		return &switchVal
+	}
+	return
+}
+
+func useSwitchNoDefault() {
+	print(*switchNoDefault(3)) //want "unassigned variable `r` returned from `switchNoDefault\\(\\)` via named return `r`"
+}
+
+// Same as above but without named results, the function must explicitly return nil after the
+// switch statement.
+func switchNoDefaultExplicitNil(x int) *int {
+	switch x {
+	case 1:
+		return &switchVal
+	case 2:
+		return &switchVal
+	}
+	return nil
+}
+
+func useSwitchNoDefaultExplicitNil() {
+	print(*switchNoDefaultExplicitNil(3)) //want "literal `nil` returned from `switchNoDefaultExplicitNil\\(\\)` in position 0"
+}
+
+// Returns in nested blocks and fallthrough cases contribute to the result as well.
+func switchNested(x int, b bool) *int {
+	switch x {
+	case 1:
+		fallthrough
+	case 2:
+		if b {
+			return nil
+		}
+		return &switchVal
+	default:
+		return &switchVal
+	}
+}
+
+func useSwitchNested() {
+	print(*switchNested(1, true)) //want "literal `nil` returned from `switchNested\\(\\)` in position 0"
+}
+
+// With a `default` case and all cases returning nonnil, the result is nonnil.
+func switchWithDefault(x int) *int {
+	switch x {
+	case 1:
+		return &switchVal
+	default:
+		return new(int)
+	}
+}
+
+func useSwitchWithDefault() {
+	print(*switchWithDefault(3))
+}
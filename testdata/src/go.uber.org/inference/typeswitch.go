@@ -0,0 +1,96 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// This file tests that the assignments in the branches of a type switch are joined after the
+// switch, such that a pointer set to nil in only one of the branches (e.g., the default one) is
+// nilable after the switch.
+
+func typeSwitchDefaultNil(x any) int {
+	p := new(int)
+	switch x.(type) {
+	case int:
+		p = new(int)
+	case string:
+	default:
+		p = nil
+	}
+	return *p //want "literal `nil`(.|\n)*dereferenced"
+}
+
+func typeSwitchBindingDefaultNil(x any) int {
+	p := new(int)
+	switch v := x.(type) {
+	case int:
+		*p = v
+	default:
+		p = nil
+	}
+	return *p //want "literal `nil`(.|\n)*dereferenced"
+}
+
+func typeSwitchDefaultNilGuarded(x any) int {
+	p := new(int)
+	switch x.(type) {
+	case int:
+	default:
+		p = nil
+	}
+	if p != nil {
+		return *p
+	}
+	return 0
+}
+
+func typeSwitchDefaultReturns(x any) int {
+	var p *int
+	switch x.(type) {
+	case int:
+		p = new(int)
+	default:
+		return 0
+	}
+	return *p
+}
+
+func typeSwitchNoDefaultNilCase(x any) int {
+	p := new(int)
+	switch x.(type) {
+	case int:
+		p = nil
+	case string:
+	}
+	return *p //want "literal `nil`(.|\n)*dereferenced"
+}
+
+func typeSwitchNoDefaultUnassigned(x any) int {
+	var p *int
+	switch x.(type) {
+	case int:
+		p = new(int)
+	}
+	// Without a default case, the switch may match none of the cases.
+	return *p //want "unassigned variable `p`(.|\n)*dereferenced"
+}
+
+func typeSwitchNoDefaultNonnil(x any) int {
+	p := new(int)
+	switch x.(type) {
+	case int:
+		p = new(int)
+	case string:
+	}
+	return *p
+}
@@ -0,0 +1,154 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// These tests check that labeled `break` and `continue` statements in nested loops are captured
+// correctly, i.e., the jumps out of the inner loops may skip the (re)assignments of a pointer.
+
+package loopflow
+
+type node struct {
+	val int
+}
+
+func newNode() *node {
+	return &node{}
+}
+
+// the labeled break skips the assignment, so p may still be nil after the loops
+func labeledBreakSkipsAssign(n int) int {
+	var p *node
+outer:
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				break outer
+			}
+		}
+		p = newNode()
+	}
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
+
+// the labeled break happens only after the assignment, but the outer loop may not be entered
+func labeledBreakAfterAssign(n int) int {
+	var p *node
+outer:
+	for i := 0; i < n; i++ {
+		p = newNode()
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				break outer
+			}
+		}
+	}
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
+
+// the labeled break happens only after the assignment, and the loop is infinite, so the only way
+// out of the loops is the labeled break
+func labeledBreakAfterAssignInfinite(n int) int {
+	var p *node
+outer:
+	for {
+		p = newNode()
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				break outer
+			}
+		}
+	}
+	return p.val
+}
+
+// the labeled break skips the assignment in the infinite loop, so p is nil at the only way out
+func labeledBreakBeforeAssignInfinite(n int) int {
+	var p *node
+outer:
+	for {
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				break outer
+			}
+		}
+		p = newNode()
+	}
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
+
+// the labeled continue skips the reassignment to a nonnil value in the outer loop
+func labeledContinueSkipsReassign(n int) int {
+	p := newNode()
+outer:
+	for i := 0; i < n; i++ {
+		p = nil
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				continue outer
+			}
+		}
+		p = newNode()
+	}
+	return p.val //want "literal `nil` accessed field `val`"
+}
+
+// the labeled continue skips the reassignment to nil in the outer loop
+func labeledContinueSkipsNilReassign(n int) int {
+	p := newNode()
+outer:
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				continue outer
+			}
+		}
+		p = nil
+		p = newNode()
+	}
+	return p.val
+}
+
+// the unlabeled break only leaves the inner loop, so the assignment is always reached
+func unlabeledBreakReachesAssign(n int) int {
+	var p *node
+	for {
+		for j := 0; j < n; j++ {
+			if dummyBool() {
+				break
+			}
+		}
+		p = newNode()
+		if dummyBool() {
+			break
+		}
+	}
+	return p.val
+}
+
+// the labeled break out of a switch inside a loop skips the assignment
+func labeledBreakFromSwitch(n int) int {
+	var p *node
+loop:
+	for {
+		switch n {
+		case 0:
+			break loop
+		default:
+			p = newNode()
+			if dummyBool() {
+				break loop
+			}
+		}
+	}
+	return p.val //want "unassigned variable `p` accessed field `val`"
+}
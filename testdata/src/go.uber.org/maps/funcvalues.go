@@ -0,0 +1,67 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+// This file tests the calls of the functions stored in maps, where reading a missing key yields a
+// nil function, and calling it panics.
+
+type handlerResult struct {
+	val int
+}
+
+// nilable(result 0)
+type nilableHandler func() *handlerResult
+
+func callMissingHandler(handlers map[string]func() *handlerResult, k string) int {
+	p := handlers[k]() //want "called as a function"
+	return p.val
+}
+
+func callMissingHandlerFromLocalMap(k string) int {
+	handlers := map[string]func() *handlerResult{
+		"a": func() *handlerResult { return &handlerResult{} },
+	}
+	p := handlers[k]() //want "called as a function"
+	return p.val
+}
+
+func callCheckedHandler(handlers map[string]func() *handlerResult, k string) int {
+	if h, ok := handlers[k]; ok {
+		p := h()
+		return p.val
+	}
+	return 0
+}
+
+func callHandlerWithNilableResult(handlers map[string]nilableHandler, k string) int {
+	if h, ok := handlers[k]; ok {
+		p := h()
+		return p.val //want "accessed field `val`"
+	}
+	return 0
+}
+
+func callMissingHandlerWithNilableResult(handlers map[string]nilableHandler, k string) int {
+	p := handlers[k]() //want "called as a function"
+	return p.val       //want "accessed field `val`"
+}
+
+// Note that the function values are otherwise considered nonnil, hence the reads from the maps are
+// only checked when they are called directly.
+func callHandlerViaVariable(handlers map[string]func() *handlerResult, k string) int {
+	h := handlers[k]
+	p := h()
+	return p.val
+}
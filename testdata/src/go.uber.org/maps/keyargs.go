@@ -0,0 +1,55 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+// This file tests that the arguments of a call whose result is used as a map key are checked
+// against the annotations of the parameters in all positions, not just the first one.
+
+// nilable(result 0)
+func nilablePtr() *int {
+	return nil
+}
+
+// nilable(a, b)
+func computeKey(a *int, b *int, c *int) int {
+	return *c
+}
+
+func computeKeyAllNonnil(a *int, b *int, c *int) int {
+	return *a + *b + *c
+}
+
+// nonnil(m)
+func testKeyArgPositions(m map[int]*int) {
+	x := 1
+
+	// Only the third parameter of computeKey is nonnil.
+	_ = m[computeKey(nil, nil, &x)]
+	_ = m[computeKey(nilablePtr(), nilablePtr(), &x)]
+	_ = m[computeKey(&x, &x, nil)]            //want "passed as arg `c` to `computeKey\\(\\)`"
+	_ = m[computeKey(&x, &x, nilablePtr())]   //want "passed as arg `c` to `computeKey\\(\\)`"
+	m[computeKey(nil, &x, nilablePtr())] = &x //want "passed as arg `c` to `computeKey\\(\\)`"
+
+	// All parameters of computeKeyAllNonnil are nonnil.
+	_ = m[computeKeyAllNonnil(&x, &x, &x)]
+	_ = m[computeKeyAllNonnil(&x, nilablePtr(), &x)] //want "passed as arg `b` to `computeKeyAllNonnil\\(\\)`"
+	_ = m[computeKeyAllNonnil(&x, &x, nilablePtr())] //want "passed as arg `c` to `computeKeyAllNonnil\\(\\)`"
+
+	// The key computed from a nilable third argument is also checked when the map is read with
+	// the comma-ok form.
+	if v, ok := m[computeKey(&x, &x, nilablePtr())]; ok { //want "passed as arg `c` to `computeKey\\(\\)`"
+		print(v)
+	}
+}
@@ -1021,3 +1021,36 @@ func testNestedMaps(mapOfMap map[string]map[string]*int, mapOfmapOfMap map[strin
 		}
 	}
 }
+
+const deletedKey = "k"
+
+// nonnil(m, m[])
+func testReadAfterDelete(m map[string]*int, x int) int {
+	switch x {
+	case 0:
+		delete(m, "k")
+		v := m["k"]
+		return *v //want "value read from map after its key is deleted"
+	case 1:
+		delete(m, deletedKey)
+		return *m[deletedKey] //want "value read from map after its key is deleted"
+	case 2:
+		// the key is written again before the read
+		delete(m, "k")
+		m["k"] = &x
+		v := m["k"]
+		return *v
+	case 3:
+		// a different key is read
+		delete(m, "k")
+		if v, ok := m["other"]; ok {
+			return *v
+		}
+	case 4:
+		delete(m, "k")
+		if v := m["k"]; v != nil {
+			return *v
+		}
+	}
+	return 0
+}
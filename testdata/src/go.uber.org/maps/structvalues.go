@@ -0,0 +1,68 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maps
+
+// This file tests the reads of the pointer fields of the struct values stored in maps, where
+// reading a missing key yields the zero value of the struct, whose pointer fields are nil.
+
+type structValue struct {
+	ptr *int
+	num int
+}
+
+func derefFieldOfMissingKey(k string) int {
+	m := map[string]structValue{}
+	return *m[k].ptr //want "field `ptr` of zero value read from map for a possibly missing key lacking guarding"
+}
+
+func derefFieldOfMissingKeyParam(m map[string]structValue, k string) int {
+	return *m[k].ptr //want "field `ptr` of zero value read from map"
+}
+
+func derefFieldOfMissingKeyThroughLocal(m map[string]structValue, k string) int {
+	v := m[k]
+	return *v.ptr //want "field `ptr` of zero value read from map"
+}
+
+func derefFieldOfCheckedKey(m map[string]structValue, k string) int {
+	if _, ok := m[k]; ok {
+		return *m[k].ptr
+	}
+	return *m[k].ptr //want "field `ptr` of zero value read from map"
+}
+
+func derefFieldOfPopulatedLiteralKey() int {
+	i := 42
+	m := map[string]structValue{"a": {ptr: &i}}
+	return *m["a"].ptr
+}
+
+func derefFieldOfUnpopulatedLiteralKey() int {
+	i := 42
+	m := map[string]structValue{"a": {ptr: &i}}
+	return *m["b"].ptr //want "field `ptr` of zero value read from map"
+}
+
+func derefFieldOfAssignedKey() int {
+	i := 42
+	m := map[string]structValue{}
+	m["a"] = structValue{ptr: &i}
+	return *m["a"].ptr
+}
+
+func readNonPointerFieldOfMissingKey(m map[string]structValue, k string) int {
+	// The non-pointer fields of the zero value are not nil.
+	return m[k].num
+}
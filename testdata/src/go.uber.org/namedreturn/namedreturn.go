@@ -258,3 +258,45 @@ func retsNonnilNilableWithErr9(x *int, y *int, cond bool) (r0 *int, r1 *int, e e
 	// here - two different flows result in a nilable (L102, L187) or non-nil (L200) value for e
 	return // (error is reported for `r0` at function declaration)
 }
+
+// Below tests check that named results can be annotated either by their names or positionally,
+// and that the blank results (which cannot be referred to by name) can be annotated positionally.
+
+// nilable(res)
+func nilableByName() (res *int, n int) {
+	return nil, 0
+}
+
+// nilable(result 0)
+func nilableByPosition() (res *int, n int) {
+	return nil, 0
+}
+
+// nilable(result 0)
+func nilableBlank() (_ *int, n int) {
+	return nil, 0
+}
+
+// nonnil(res)
+func nonnilByName() (res *int, err error) {
+	return new(int), nil
+}
+
+// nonnil(res)
+func nonnilByNameRetsNil() (res *int, err error) {
+	return nil, nil //want "returned"
+}
+
+func callsNamedAnnotated() {
+	r1, _ := nilableByName()
+	print(*r1) //want "dereferenced"
+	r2, _ := nilableByPosition()
+	print(*r2) //want "dereferenced"
+	r3, _ := nilableBlank()
+	print(*r3) //want "dereferenced"
+	r4, err := nonnilByName()
+	if err != nil {
+		return
+	}
+	print(*r4)
+}
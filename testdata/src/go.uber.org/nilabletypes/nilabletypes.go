@@ -123,7 +123,8 @@ func nilableTypesTest() interface{} {
 		return &y
 	case 31:
 		var x *A
-		return &x //want "unassigned variable `x` returned"
+		// the address of a variable is never nil, even if the variable itself is
+		return &x
 	case 32:
 		var x *A
 		return x.f //want "unassigned variable `x` accessed field `f`"
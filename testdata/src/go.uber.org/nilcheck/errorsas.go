@@ -0,0 +1,74 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nilcheck
+
+import "errors"
+
+// This file tests the targets populated by `errors.As(err, &target)`, which are known to be nonnil
+// if the call succeeds, while their fields are still read from their own (possibly nilable) sites.
+
+type asDetail struct {
+	code int
+}
+
+// asError is a custom error type with a pointer field.
+// nilable(detail)
+type asError struct {
+	detail *asDetail
+	// msg is not annotated, hence nonnil.
+	msg *string
+}
+
+func (e *asError) Error() string {
+	return *e.msg
+}
+
+func testErrorsAsTarget(err error) string {
+	var target *asError
+	if errors.As(err, &target) {
+		return *target.msg
+	}
+	return ""
+}
+
+func testErrorsAsNilableField(err error) int {
+	var target *asError
+	if errors.As(err, &target) {
+		return target.detail.code //want "field `detail` accessed field `code`"
+	}
+	return 0
+}
+
+func testErrorsAsNilableFieldChecked(err error) int {
+	var target *asError
+	if errors.As(err, &target) && target.detail != nil {
+		return target.detail.code
+	}
+	return 0
+}
+
+func testErrorsAsNegated(err error) string {
+	var target *asError
+	if !errors.As(err, &target) {
+		return *target.msg //want "unassigned variable `target` accessed field `msg`"
+	}
+	return *target.msg
+}
+
+func testErrorsAsUnchecked(err error) string {
+	var target *asError
+	errors.As(err, &target)
+	return *target.msg //want "unassigned variable `target` accessed field `msg`"
+}
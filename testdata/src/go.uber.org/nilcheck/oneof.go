@@ -0,0 +1,152 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nilcheck
+
+// This file tests the oneof directive `//nilaway:oneof(a, b, c)` on struct types, where the last
+// remaining field of the group is known to be nonnil after all other fields are checked to be nil.
+
+// shape is a sum type where exactly one of the fields is set.
+// nilable(circle, square, triangle, other)
+//
+//nilaway:oneof(circle, square, triangle)
+type shape struct {
+	circle   *int
+	square   *int
+	triangle *int
+	other    *int
+}
+
+type (
+	// pair has a oneof group of two fields.
+	// nilable(left, right)
+	//
+	//nilaway:oneof(left, right)
+	pair struct {
+		left, right *int
+	}
+
+	// badOneOf has a malformed group that refers to an unknown field, so it is ignored.
+	// nilable(left, right)
+	//
+	//nilaway:oneof(left, missing)
+	badOneOf struct {
+		left, right *int
+	}
+)
+
+func testOneOfNested(s *shape) int {
+	if s.circle == nil {
+		if s.square == nil {
+			return *s.triangle
+		}
+		return *s.square
+	}
+	return *s.circle
+}
+
+func testOneOfEarlyReturns(s *shape) int {
+	if s.circle != nil {
+		return *s.circle
+	}
+	if s.square != nil {
+		return *s.square
+	}
+	return *s.triangle
+}
+
+func testOneOfConjunction(s *shape) int {
+	if s.triangle == nil && s.circle == nil {
+		return *s.square
+	}
+	return 0
+}
+
+func testOneOfThroughOtherConditionals(s *shape, b bool) int {
+	if s.circle == nil {
+		if b {
+			if s.square == nil {
+				return *s.triangle
+			}
+		}
+	}
+	return 0
+}
+
+func testOneOfTwoFields(p *pair) int {
+	if p.left == nil {
+		return *p.right
+	}
+	return *p.left
+}
+
+func testOneOfOnlyOneEliminated(s *shape) int {
+	if s.circle == nil {
+		return *s.triangle //want "dereferenced"
+	}
+	return 0
+}
+
+func testOneOfNotInGroup(s *shape) int {
+	if s.circle == nil && s.square == nil {
+		return *s.other //want "dereferenced"
+	}
+	return 0
+}
+
+func testOneOfEliminatedNonnil(s *shape) int {
+	if s.circle != nil {
+		if s.square == nil {
+			return *s.triangle //want "dereferenced"
+		}
+	}
+	return 0
+}
+
+func testOneOfOtherValue(s, t *shape) int {
+	if s.circle == nil && t.square == nil {
+		return *s.triangle //want "dereferenced"
+	}
+	return 0
+}
+
+func testOneOfReassigned(s *shape) int {
+	if s.circle == nil {
+		s.square = nil
+		if s.square == nil {
+			return *s.triangle //want "dereferenced"
+		}
+	}
+	return 0
+}
+
+func testOneOfMerged(s *shape, b bool) int {
+	if b {
+		if s.circle != nil {
+			return 0
+		}
+	}
+	// The check of `s.circle` does not hold on all paths here.
+	if s.square == nil {
+		return *s.triangle //want "dereferenced"
+	}
+	return 0
+}
+
+func testOneOfMalformed(b *badOneOf) int {
+	if b.left == nil {
+		return *b.right //want "dereferenced"
+	}
+	return 0
+}
@@ -0,0 +1,79 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// These tests check that a nil check as the left operand of `&&` establishes the nonnil-ness of
+// the pointer for both the right operands and the guarded body, while a nil check as the left
+// operand of `||` establishes it for neither.
+
+package nilcheck
+
+type config struct {
+	valid bool
+	name  string
+}
+
+func useConfig(c config) {}
+
+// nilable(x)
+func andCheckThenBody(x *config) {
+	if x != nil && x.valid {
+		useConfig(*x)
+	}
+}
+
+// nilable(x, y)
+func andChainedChecks(x, y *config) string {
+	if x != nil && y != nil && x.valid && y.valid {
+		return x.name + y.name
+	}
+	return ""
+}
+
+// nilable(x)
+func andCheckNotOnLeft(x *config, ok bool) {
+	if ok && x != nil {
+		useConfig(*x)
+	}
+	if x.valid && x != nil { //want "function parameter `x` accessed field `valid`"
+		useConfig(*x)
+	}
+}
+
+// nilable(x)
+func andCheckElseBranch(x *config) {
+	if x != nil && x.valid {
+		return
+	}
+	useConfig(*x) //want "function parameter `x` dereferenced"
+}
+
+// nilable(x)
+func orCheckRightOperand(x *config) bool {
+	return x != nil || x.valid //want "function parameter `x` accessed field `valid`"
+}
+
+// nilable(x)
+func orCheckThenBody(x *config, ok bool) {
+	if x != nil || ok {
+		useConfig(*x) //want "function parameter `x` dereferenced"
+	}
+}
+
+// nilable(x)
+func orNegatedCheck(x *config) {
+	if x == nil || !x.valid {
+		return
+	}
+	useConfig(*x)
+}
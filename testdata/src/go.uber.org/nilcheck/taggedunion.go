@@ -0,0 +1,100 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nilcheck
+
+// This file tests the tagged union directive `//nilaway:nonnil-when(tag == VALUE)` on struct
+// fields, where a check of the tag field also guards the fields tagged by the checked value.
+
+type valueKind int
+
+const (
+	kindInt valueKind = iota
+	kindPtr
+	kindStr
+)
+
+// nilable(p, s, other)
+type taggedValue struct {
+	kind valueKind
+	i    int
+	//nilaway:nonnil-when(kind == kindPtr)
+	p *int
+	s *string //nilaway:nonnil-when(kind == kindStr)
+	// other is nilable regardless of the tag, since it has no directive.
+	other *int
+}
+
+func testTaggedUnionEqual(v *taggedValue) int {
+	if v.kind == kindPtr {
+		return *v.p
+	}
+	if kindStr == v.kind {
+		return len(*v.s)
+	}
+	return v.i
+}
+
+func testTaggedUnionNotEqual(v *taggedValue) int {
+	if v.kind != kindPtr {
+		return v.i
+	}
+	return *v.p
+}
+
+func testTaggedUnionCombined(v *taggedValue, b bool) int {
+	if b && v.kind == kindPtr {
+		return *v.p
+	}
+	if !(v.kind != kindPtr) {
+		return *v.p
+	}
+	return 0
+}
+
+func testTaggedUnionWrongTag(v *taggedValue) int {
+	if v.kind == kindStr {
+		return *v.p //want "dereferenced"
+	}
+	if v.kind == kindInt {
+		return len(*v.s) //want "dereferenced"
+	}
+	if v.kind != kindPtr {
+		return *v.p //want "dereferenced"
+	}
+	return 0
+}
+
+func testTaggedUnionUntagged(v *taggedValue) int {
+	if v.kind == kindPtr {
+		return *v.other //want "dereferenced"
+	}
+	return *v.p //want "dereferenced"
+}
+
+func testTaggedUnionOtherValue(v, w *taggedValue) int {
+	// The tag check only guards the fields of the same value.
+	if v.kind == kindPtr {
+		return *w.p //want "dereferenced"
+	}
+	return 0
+}
+
+func testTaggedUnionReassigned(v *taggedValue) int {
+	if v.kind == kindPtr {
+		v.p = nil
+		return *v.p //want "dereferenced"
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nilcheck
+
+// This file tests the comma-ok type assertions `v, ok := x.(*T)`, where `v` is nil if the
+// assertion fails, hence it must not be dereferenced where `ok` is known to be false.
+
+type assertedT struct {
+	f int
+}
+
+func derefInOkBranch(x any) int {
+	if v, ok := x.(*assertedT); ok {
+		return v.f
+	}
+	return 0
+}
+
+func derefAfterEarlyReturn(x any) int {
+	v, ok := x.(*assertedT)
+	if !ok {
+		return 0
+	}
+	return v.f
+}
+
+func derefInNotOkBranch(x any) int {
+	v, ok := x.(*assertedT)
+	if !ok {
+		return v.f //want "result of failed comma-ok type assertion to `\\*assertedT` accessed field `f`"
+	}
+	return 0
+}
+
+func derefInElseBranch(x any) int {
+	if v, ok := x.(*assertedT); ok {
+		return 0
+	} else {
+		return v.f //want "result of failed comma-ok type assertion to `\\*assertedT` accessed field `f`"
+	}
+}
+
+func derefInShortCircuit(x any) bool {
+	v, ok := x.(*assertedT)
+	return ok && v.f == 1
+}
+
+func derefInNotOkShortCircuit(x any) bool {
+	v, ok := x.(*assertedT)
+	if !ok && v.f == 1 { //want "result of failed comma-ok type assertion to `\\*assertedT` accessed field `f`"
+		return true
+	}
+	return false
+}
+
+// Only the uses where the assertion is known to have failed are reported, so the uses without
+// checking the `ok` are not.
+
+func derefWithoutCheck(x any) int {
+	v, _ := x.(*assertedT)
+	return v.f
+}
+
+func derefAfterOkReassigned(x, y any) int {
+	v, ok := x.(*assertedT)
+	_, ok = y.(*assertedT)
+	if !ok {
+		return v.f
+	}
+	return 0
+}
+
+func derefNonPointer(x any) int {
+	// The zero values of the types that bar nilness are safe to use.
+	v, ok := x.(assertedT)
+	if !ok {
+		return v.f
+	}
+	return 0
+}
+
+func derefSingleValue(x any) int {
+	// The single-value form panics on failed assertions instead of producing nil.
+	v := x.(*assertedT)
+	return v.f
+}
@@ -0,0 +1,82 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package packagedirective tests that the `//nilaway:package default-nonnil-returns` directive in
+// the package doc comment makes the results of the exported functions nonnil by default, while
+// the explicit annotations on the functions override it.
+//
+//nilaway:package default-nonnil-returns
+package packagedirective
+
+import "errors"
+
+var dummy bool
+
+type T struct {
+	f int
+}
+
+// New has no annotations, hence its result is nonnil by the package directive. The error is
+// reported at the (implicitly) annotated result.
+func New() *T { //want "literal `nil` returned"
+	if dummy {
+		return nil
+	}
+	return &T{}
+}
+
+// Find overrides the package directive.
+// nilable(result 0)
+func Find() *T {
+	if dummy {
+		return nil
+	}
+	return &T{}
+}
+
+// Open returns a nil result only along with a non-nil error, which respects the error contract.
+func Open() (*T, error) {
+	if dummy {
+		return nil, errors.New("cannot open")
+	}
+	return &T{}, nil
+}
+
+// find is not exported, hence its result is still inferred from its body.
+func find() *T {
+	if dummy {
+		return nil
+	}
+	return &T{}
+}
+
+func useNew() int {
+	return New().f
+}
+
+func useFind() int {
+	return Find().f //want "result 0 of `Find\\(\\)` accessed field `f`"
+}
+
+func useOpen() int {
+	t, err := Open()
+	if err != nil {
+		return 0
+	}
+	return t.f
+}
+
+func useUnexportedFind() int {
+	return find().f //want "result 0 of `find\\(\\)` accessed field `f`"
+}
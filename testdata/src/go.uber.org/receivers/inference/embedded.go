@@ -0,0 +1,83 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the fields and methods promoted through embedded pointers implicitly dereference the
+// embedded pointers (e.g., `d.valRecv()` is `(*d.embeddedBase).valRecv()`), and hence are reported
+// if the embedded pointers may be nil.
+
+type embeddedBase struct {
+	f int
+}
+
+func (b embeddedBase) valRecv() int {
+	return b.f
+}
+
+// ptrRecv tolerates a nil receiver.
+func (b *embeddedBase) ptrRecv() int {
+	if b == nil {
+		return 0
+	}
+	return b.f
+}
+
+type embeddingDerived struct {
+	*embeddedBase
+}
+
+type embeddingOuter struct {
+	embeddingDerived
+}
+
+func callPromotedValRecvOnNil() int {
+	d := &embeddingDerived{}
+	d.embeddedBase = nil
+	return d.valRecv() //want "called `valRecv\\(\\)`"
+}
+
+func readPromotedFieldOnNil() int {
+	d := &embeddingDerived{}
+	d.embeddedBase = nil
+	return d.f //want "accessed field `f`"
+}
+
+func callDeeplyPromotedValRecvOnNil() int {
+	o := &embeddingOuter{}
+	o.embeddedBase = nil
+	return o.valRecv() //want "called `valRecv\\(\\)`"
+}
+
+func callPromotedPtrRecvOnNil() int {
+	d := &embeddingDerived{}
+	d.embeddedBase = nil
+	// the nil embedded pointer is passed as the receiver, which is tolerated by `ptrRecv`
+	return d.ptrRecv()
+}
+
+func callPromotedValRecvGuarded() int {
+	d := &embeddingDerived{}
+	d.embeddedBase = nil
+	if d.embeddedBase != nil {
+		return d.valRecv() + d.f
+	}
+	return 0
+}
+
+func callPromotedValRecvOnNonnil() int {
+	d := &embeddingDerived{}
+	d.embeddedBase = &embeddedBase{}
+	return d.valRecv() + d.f
+}
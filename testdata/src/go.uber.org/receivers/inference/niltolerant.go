@@ -0,0 +1,58 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inference
+
+// Test that the nilability of the receiver and the nilability of the result of a method call are
+// tracked independently: a nil receiver is not reported for a nil-tolerant method (i.e., one that
+// does not dereference its receiver), while the nilable result of the method still is.
+
+type treeNode struct {
+	val  int
+	left *treeNode
+}
+
+// Left tolerates a nil receiver, for which it returns nil.
+func (t *treeNode) Left() *treeNode {
+	if t == nil {
+		return nil
+	}
+	return t.left
+}
+
+func newTreeNode() *treeNode {
+	if dummy {
+		return nil
+	}
+	return &treeNode{left: &treeNode{}}
+}
+
+func testNilTolerantMethodResultUnchecked() int {
+	var t *treeNode
+	// The error below is grouped with the one in testNilTolerantMethodOnNilableResultUnchecked,
+	// since they share the same nil source.
+	return t.Left().val //want "result 0 of `Left\\(\\)` accessed field `val`(.|\n)*niltolerant.go:49"
+}
+
+func testNilTolerantMethodOnNilableResultUnchecked() int {
+	return newTreeNode().Left().val
+}
+
+func testNilTolerantMethodResultChecked() int {
+	var t *treeNode
+	if l := t.Left(); l != nil {
+		return l.val
+	}
+	return 0
+}
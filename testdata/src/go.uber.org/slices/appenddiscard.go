@@ -0,0 +1,40 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slices
+
+// Test that `append(x, ...)` does not mutate `x` itself: if the result is discarded (or assigned
+// to another variable), a nil slice `x` stays nil and indexing it afterwards is still reported.
+
+func testAppendResultDiscarded() int {
+	var x []int
+	_ = append(x, 1)
+	return x[0] //want "sliced into"
+}
+
+func testAppendResultAssignedElsewhere() int {
+	var x []int
+	y := append(x, 1)
+	print(len(y))
+	return x[0] //want "sliced into"
+}
+
+func testAppendResultDiscardedThenChecked() int {
+	var x []int
+	_ = append(x, 1)
+	if len(x) > 0 {
+		return x[0]
+	}
+	return 0
+}
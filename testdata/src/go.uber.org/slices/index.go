@@ -0,0 +1,64 @@
+//  Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slices
+
+import "slices"
+
+// Test the elements found by the searches of the standard library (e.g., `slices.Index`), which
+// are read from the slices like any other elements. Hence, the found elements are nilable if the
+// elements of the slices are, even after checking that the search succeeded.
+
+type indexElem struct {
+	f int
+}
+
+// nonnil(s)
+// nilable(s[])
+func testIndexFoundNilableElem(s []*indexElem, target *indexElem) int {
+	i := slices.Index(s, target)
+	if i < 0 {
+		return 0
+	}
+	return s[i].f //want "deep read from parameter `s` accessed field `f`"
+}
+
+// nonnil(s)
+// nilable(s[])
+func testIndexFuncFoundNilableElem(s []*indexElem) int {
+	i := slices.IndexFunc(s, func(e *indexElem) bool { return e == nil || e.f > 0 })
+	if i == -1 {
+		return 0
+	}
+	return s[i].f //want "deep read from parameter `s` accessed field `f`"
+}
+
+// nonnil(s)
+// nilable(s[])
+func testIndexFoundNilableElemChecked(s []*indexElem, target *indexElem) int {
+	i := slices.Index(s, target)
+	if i < 0 || s[i] == nil {
+		return 0
+	}
+	return s[i].f
+}
+
+// nonnil(s)
+func testIndexFoundNonnilElem(s []*indexElem, target *indexElem) int {
+	i := slices.Index(s, target)
+	if i < 0 {
+		return 0
+	}
+	return s[i].f
+}
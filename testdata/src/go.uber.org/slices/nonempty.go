@@ -0,0 +1,48 @@
+//  Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slices
+
+// Test the `nonempty` annotation, which declares that a slice is never empty and hence can be
+// indexed without a length check.
+
+// nonempty(s)
+func testNonEmptyIndex(s []int) int {
+	return s[0]
+}
+
+func testUnannotatedIndex(s []int) int {
+	return s[0] //want "sliced into"
+}
+
+// nonempty(s)
+// nilable(s[])
+func testNonEmptyWithNilableElems(s []*int) int {
+	// The annotation only concerns the slice itself, not its elements.
+	return *s[0] //want "dereferenced"
+}
+
+// nonempty(s)
+func testNonEmptyAsArg(s []int) int {
+	return testNonEmptyIndex(s)
+}
+
+func testPassNilToNonEmpty() int {
+	return testNonEmptyIndex(nil) //want "passed as arg `s`"
+}
+
+func testPassEmptyToNonEmpty() int {
+	var s []int
+	return testNonEmptyIndex(s) //want "passed as arg `s`"
+}
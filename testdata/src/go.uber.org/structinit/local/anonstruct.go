@@ -0,0 +1,57 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+// Tests the uninitialized fields of anonymous structs
+
+func anonUnset() {
+	v := struct{ ptr *int }{}
+	print(*v.ptr) //want "uninitialized dereferenced"
+}
+
+func anonPtrUnset() {
+	v := &struct{ ptr *int }{}
+	print(*v.ptr) //want "uninitialized dereferenced"
+}
+
+func anonNewUnset() {
+	v := new(struct{ ptr *int })
+	print(*v.ptr) //want "uninitialized dereferenced"
+}
+
+func anonSet() {
+	v := struct{ ptr *int }{ptr: new(int)}
+	print(*v.ptr)
+}
+
+func anonPtrSet() {
+	v := &struct{ ptr *int }{ptr: new(int)}
+	print(*v.ptr)
+}
+
+func anonSetWithoutKey() {
+	v := struct {
+		ptr  *int
+		aptr *A
+	}{new(int), new(A)}
+	print(*v.ptr)
+	print(v.aptr.ptr)
+}
+
+func anonSetAfterInit() {
+	v := struct{ ptr *int }{}
+	v.ptr = new(int)
+	print(*v.ptr)
+}
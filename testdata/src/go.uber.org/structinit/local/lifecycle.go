@@ -0,0 +1,74 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+// Tests the accesses of fields in constructors, where the fields are only initialized by deferred
+// calls or by separate lifecycle methods, i.e., after the accesses.
+
+type conn struct {
+	addr *string
+}
+
+type server struct {
+	conn *conn
+}
+
+func (s *server) init() {
+	s.conn = &conn{}
+}
+
+func (s *server) start() {
+	print(s.conn.addr)
+}
+
+// The deferred initializer only runs when the constructor returns.
+func newServerDeferredInit() *server {
+	s := &server{}
+	defer s.init()
+	print(s.conn.addr) //want "uninitialized accessed field `addr`"
+	return s
+}
+
+// The lifecycle method initializing the field is never called in the constructor.
+func newServerLifecycleInit() *server {
+	s := &server{}
+	print(s.conn.addr) //want "uninitialized accessed field `addr`"
+	return s
+}
+
+// The field is initialized before it is accessed.
+func newServerInit() *server {
+	s := &server{}
+	s.init()
+	print(s.conn.addr)
+	return s
+}
+
+// The field is initialized on all paths before it is accessed.
+func newServerInitBranches(eager bool) *server {
+	s := &server{}
+	if eager {
+		s.conn = &conn{}
+	} else {
+		s.init()
+	}
+	print(s.conn.addr)
+	return s
+}
+
+func useServer() {
+	s := newServerInit()
+	s.start()
+}
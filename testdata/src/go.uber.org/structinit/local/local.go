@@ -97,8 +97,7 @@ func m12() {
 	print(x.a.ptr)
 
 	y := new(struct{ a *A })
-	// TODO: unnamed struct initialization is not supported. Following line should give a warning
-	print(y.a.aptr)
+	print(y.a.aptr) //want "uninitialized accessed field `aptr`"
 }
 
 // Tests use of anonymous fields
@@ -0,0 +1,14 @@
+// Package explained is meant to check if our keep-explanations-in-groups flag has effect.
+package explained
+
+func nilable() *int {
+	return nil
+}
+
+// When the keep-explanations-in-groups flag is set to true, the grouped error message should also
+// include the explanations of the other places sharing the same nil source.
+func test() {
+	x := nilable()
+	_ = *x //want "literal `nil` returned from `nilable\\(\\)`(.|\n)*Same nil source could also cause(.|\n)*Observed nil flow\\(s\\) from the same source to the other place\\(s\\):\n\"explained/explained.go:13:7\":\n\t- explained/explained.go:13:7: result 0 of `nilable\\(\\)` dereferenced"
+	_ = *x
+}
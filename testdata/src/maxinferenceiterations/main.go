@@ -0,0 +1,17 @@
+// Package maxinferenceiterations is meant to check if our max-inference-iterations flag has
+// effect: the inference of the package stops once the (tiny) bound is exceeded, and a note is
+// reported for the package instead of the errors that would need more iterations to conclude.
+package maxinferenceiterations //want "inference of package \"maxinferenceiterations\" stopped after 2 iterations"
+
+// countdown is recursive, such that the nilability of its result flows through itself before
+// reaching the dereference below.
+func countdown(n int) *int {
+	if n == 0 {
+		return nil
+	}
+	return countdown(n - 1)
+}
+
+func deref() int {
+	return *countdown(3)
+}
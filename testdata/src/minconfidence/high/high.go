@@ -0,0 +1,35 @@
+// Package high tests that, with the `min-confidence` flag set to "high", only the definite nil
+// panics are reported, labeled with their confidence levels.
+package high
+
+type T struct {
+	f int
+}
+
+func derefLiteralNil() int {
+	var t *T = nil
+	return t.f //want "literal `nil` accessed field `f`(.|\n)*Confidence: high"
+}
+
+func derefUnassigned() int {
+	var t *T
+	return t.f //want "unassigned variable `t` accessed field `f`(.|\n)*Confidence: high"
+}
+
+func nilableResult() *T {
+	return nil
+}
+
+func derefNilableResult() int {
+	// The result of the function may be nonnil on other paths, so this is not definite.
+	return nilableResult().f
+}
+
+func derefParam(t *T) int {
+	return t.f
+}
+
+func passNil() int {
+	// The nil flows through the parameter of another function, so this is not definite.
+	return derefParam(nil)
+}
@@ -0,0 +1,38 @@
+// Package medium tests that, with the `min-confidence` flag set to "medium", the potential nil
+// panics whose nil flows go through at most a few sites are reported, labeled with their
+// confidence levels, while the ones whose nil flows go through many sites are not.
+package medium
+
+type T struct {
+	f int
+}
+
+func derefUnassigned() int {
+	var t *T
+	return t.f //want "unassigned variable `t` accessed field `f`(.|\n)*Confidence: high"
+}
+
+func nilableResult() *T {
+	return nil
+}
+
+func derefNilableResult() int {
+	return nilableResult().f //want "literal `nil` returned from `nilableResult\\(\\)`(.|\n)*Confidence: medium"
+}
+
+func forward1(t *T) *T {
+	return forward2(t)
+}
+
+func forward2(t *T) *T {
+	return forward3(t)
+}
+
+func forward3(t *T) *T {
+	return t
+}
+
+func derefForwarded() int {
+	// The nil flows through many functions, so this is of low confidence.
+	return forward1(nil).f
+}
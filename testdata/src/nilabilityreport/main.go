@@ -0,0 +1,26 @@
+// Package nilabilityreport is meant to check if our nilability-report flag has effect: the
+// concluded nilability of the parameters and results of each function is reported as JSON, along
+// with the callees that contributed to it.
+package nilabilityreport
+
+func deref(p *int) int { //want `^\{"function":"nilabilityreport.deref","params":\[\{"name":"p","nilability":"nonnil","callees":\[\]\}\],"results":\[\]\}$`
+	return *p
+}
+
+// The parameter is forced to be nonnil by the callee dereferencing it.
+func callDeref(p *int, n int) int { //want `^\{"function":"nilabilityreport.callDeref","params":\[\{"name":"p","nilability":"nonnil","callees":\["nilabilityreport.deref"\]\}\],"results":\[\]\}$`
+	return deref(p) + n
+}
+
+func nilResult() *int { //want `^\{"function":"nilabilityreport.nilResult","params":\[\],"results":\[\{"name":"result 0","nilability":"nilable","callees":\[\]\}\]\}$`
+	return nil
+}
+
+// The result is forced to be nilable by the callee returning nil.
+func forwardNilResult() *int { //want `^\{"function":"nilabilityreport.forwardNilResult","params":\[\],"results":\[\{"name":"result 0","nilability":"nilable","callees":\["nilabilityreport.nilResult"\]\}\]\}$`
+	return nilResult()
+}
+
+func identity(p *int) *int { //want `^\{"function":"nilabilityreport.identity","params":\[\{"name":"p","nilability":"undetermined","callees":\[\]\}\],"results":\[\{"name":"result 0","nilability":"undetermined","callees":\[\]\}\]\}$`
+	return p
+}
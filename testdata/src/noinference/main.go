@@ -0,0 +1,60 @@
+// Package noinference is meant to check if our no-inference flag has effect: only the
+// syntactically-certain nil panics are reported.
+package noinference
+
+type A struct {
+	f *int
+}
+
+func literalNil() {
+	var p *int = nil
+	print(*p) //want "dereferenced"
+}
+
+func nilBranch(p *int) {
+	if p == nil {
+		print(*p) //want "dereferenced"
+	}
+}
+
+func nilBranchReturn(p *int) int {
+	if p == nil {
+		return *p //want "dereferenced"
+	}
+	return *p
+}
+
+func nonNilBranch(p *int) int {
+	if p != nil {
+		return *p
+	}
+	return *p //want "dereferenced"
+}
+
+func reassignedInNilBranch(p *int) int {
+	if p == nil {
+		p = new(int)
+	}
+	return *p
+}
+
+func unassignedField() {
+	var a *A
+	print(a.f) //want "accessed field `f`"
+}
+
+func nilable() *int {
+	return nil
+}
+
+func inferred() {
+	// The nilability of the result of `nilable()` is only known via inference, so it should not
+	// be reported in this mode.
+	print(*nilable())
+}
+
+// nilable(p)
+func annotated(p *int) {
+	// Annotations are not considered either.
+	print(*p)
+}
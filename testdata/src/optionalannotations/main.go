@@ -0,0 +1,44 @@
+/*
+Package optionalannotations tests that the `// +optional` comment convention on struct fields is
+treated as a `nilable` annotation when the corresponding flag is enabled.
+
+<nilaway no inference>
+*/
+package optionalannotations
+
+type Spec struct {
+	// Replicas is the number of desired replicas.
+	// +optional
+	Replicas *int
+
+	Selector *int // +optional
+
+	// Name is required.
+	Name *string
+
+	// +optional is only recognized on its own line, so this field is not annotated.
+	Template *string
+}
+
+func useReplicas(s *Spec) int {
+	return *s.Replicas //want "dereferenced"
+}
+
+func useSelector(s *Spec) int {
+	return *s.Selector //want "dereferenced"
+}
+
+func useName(s *Spec) string {
+	return *s.Name
+}
+
+func useTemplate(s *Spec) string {
+	return *s.Template
+}
+
+func guarded(s *Spec) int {
+	if s.Replicas != nil {
+		return *s.Replicas
+	}
+	return 0
+}
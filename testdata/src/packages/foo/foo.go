@@ -0,0 +1,8 @@
+// Package foo tests that, with the `packages` flag set to "packages/foo", this package is
+// analyzed.
+package foo
+
+func deref() int {
+	var p *int
+	return *p //want "unassigned variable `p` dereferenced"
+}
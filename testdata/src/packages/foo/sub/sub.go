@@ -0,0 +1,8 @@
+// Package sub tests that, with the `packages` flag set to "packages/foo", this package is not
+// analyzed, even though its import path has the listed one as a prefix.
+package sub
+
+func deref() int {
+	var p *int
+	return *p
+}
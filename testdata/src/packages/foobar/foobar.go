@@ -0,0 +1,8 @@
+// Package foobar tests that, with the `packages` flag set to "packages/foo", this package is not
+// analyzed, even though its import path shares a prefix with the listed one.
+package foobar
+
+func deref() int {
+	var p *int
+	return *p
+}
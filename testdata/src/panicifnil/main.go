@@ -0,0 +1,65 @@
+// Package panicifnil is meant to check if our panic-if-nil-funcs flag has effect: the test
+// configures "panicifnil.mustNotBeNil", "panicifnil.checkNotNil:1", and
+// "panicifnil.checker.notNil:0" as functions that panic if their arguments are nil.
+//
+// <nilaway no inference>
+package panicifnil
+
+// nilable(args)
+func mustNotBeNil(args ...any) {
+	for _, a := range args {
+		if a == nil {
+			panic("unexpected nil")
+		}
+	}
+}
+
+// nilable(v)
+func checkNotNil(msg string, v any) {
+	if v == nil {
+		panic(msg)
+	}
+}
+
+type checker struct{}
+
+// nilable(v, w)
+func (checker) notNil(v any, w any) {
+	if v == nil {
+		panic(w)
+	}
+}
+
+// nilable(a, b)
+func allArgs(a *int, b *int) {
+	mustNotBeNil(a, b)
+	print(*a)
+	print(*b)
+}
+
+// nilable(a)
+func nonFirstArg(a *int) {
+	// The helper takes the pointer as its second argument.
+	checkNotNil("a must not be nil", a)
+	print(*a)
+}
+
+// nilable(a, b)
+func method(c checker, a *int, b *int) {
+	c.notNil(a, b)
+	print(*a)
+	// Only the first argument of the method is configured to be checked.
+	print(*b) //want "dereferenced"
+}
+
+// nilable(a)
+func beforeCall(a *int) {
+	print(*a) //want "dereferenced"
+	mustNotBeNil(a)
+}
+
+// nilable(a, b)
+func otherArg(a *int, b *int) {
+	checkNotNil("b must not be nil", b)
+	print(*a) //want "dereferenced"
+}
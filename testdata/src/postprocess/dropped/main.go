@@ -0,0 +1,8 @@
+// Package dropped is meant to check if the diagnostic processors passed to NilAway's programmatic
+// API can drop diagnostics: the processor in the test drops all diagnostics in this package.
+package dropped
+
+func main() {
+	var a *int
+	print(*a)
+}
@@ -0,0 +1,8 @@
+// Package teama is meant to check if the diagnostic processors passed to NilAway's programmatic
+// API have effect: the processor in the test attaches an owner tag based on the file path.
+package teama
+
+func main() {
+	var a *int
+	print(*a) //want "\\[owner: team-a\\]$"
+}
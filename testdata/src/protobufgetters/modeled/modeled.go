@@ -0,0 +1,32 @@
+// Package modeled tests that, with the `model-protobuf-getters` flag, the results of the
+// protobuf getters that return messages are treated as nilable.
+package modeled
+
+import "protobufgetters/pb"
+
+func derefGetter(req *pb.Request) int {
+	return req.GetUser().ID //want "result of protobuf getter `GetUser\\(\\)` for a possibly unset field"
+}
+
+func derefGetterViaVar(req *pb.Request) int {
+	u := req.GetUser()
+	return u.ID //want "result of protobuf getter `GetUser\\(\\)` for a possibly unset field"
+}
+
+func guardedGetter(req *pb.Request) int {
+	if u := req.GetUser(); u != nil {
+		return u.ID
+	}
+	return 0
+}
+
+func chainedGetters(req *pb.Request) int {
+	// The getters handle nil receivers, so chaining them is safe.
+	return req.GetUser().GetID()
+}
+
+func nonMessageGetters(req *pb.Request) string {
+	// Only the getters that return messages are affected.
+	_ = req.GetName()
+	return req.GetUser().GetProfile().Bio
+}
@@ -0,0 +1,14 @@
+// Package unmodeled tests that, by default, the protobuf getters are treated like any other
+// methods in the excluded generated code, i.e., their results are assumed to be nonnil.
+package unmodeled
+
+import "protobufgetters/pb"
+
+func derefGetter(req *pb.Request) int {
+	return req.GetUser().ID
+}
+
+func derefGetterViaVar(req *pb.Request) int {
+	u := req.GetUser()
+	return u.ID
+}
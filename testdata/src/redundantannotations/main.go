@@ -0,0 +1,53 @@
+// Package redundantannotations is meant to check if our warn-redundant-annotations flag has
+// effect: annotations that inference would have reached on its own are reported.
+package redundantannotations
+
+var global int
+
+// nonnil(result 0)
+func alwaysNonNil() *int { //want "Redundant annotation: `nonnil` annotation on Result 0 of Function alwaysNonNil"
+	return &global
+}
+
+// nonnil(result 0)
+func fromNonNil() *int { //want "Redundant annotation: `nonnil` annotation on Result 0 of Function fromNonNil"
+	return alwaysNonNil()
+}
+
+// nilable(result 0)
+func neverNil() *int { //want "Redundant annotation: `nilable` annotation on Result 0 of Function neverNil"
+	return new(int)
+}
+
+// The annotations below are needed, and hence should not be reported.
+
+// nilable(result 0)
+func sometimesNil(b bool) *int {
+	if b {
+		return nil
+	}
+	return &global
+}
+
+// nonnil(result 0)
+func unknown(p *int) *int {
+	return p
+}
+
+// nonnil(result 0)
+func recursive(n int) *int {
+	if n == 0 {
+		return &global
+	}
+	return recursive(n - 1)
+}
+
+func use() {
+	if p := sometimesNil(true); p != nil {
+		print(*p)
+	}
+	print(*unknown(new(int)))
+	print(*recursive(3))
+	print(*fromNonNil())
+	print(*neverNil()) //want "dereferenced"
+}
@@ -0,0 +1,46 @@
+// Package relaxannotations is meant to check if our suggest-relax-annotations flag has effect:
+// `nonnil` parameter annotations that all call sites already satisfy are reported.
+package relaxannotations
+
+var global int
+
+// nonnil(p)
+func allNonNil(p *int) int { //want "Relaxable annotation: `nonnil` annotation on Param 0: 'p' of Function allNonNil adds no safety, since all 2 call site\\(s\\) in this package already pass nonnil values"
+	return *p
+}
+
+// nonnil(p, q)
+func someNil(p *int, q *int) int { //want "Relaxable annotation: `nonnil` annotation on Param 1: 'q' of Function someNil" "literal `nil` returned from `nilableResult\\(\\)`(.|\n)*passed as arg `p` to `someNil\\(\\)`(.|\n)*annotated as so"
+	return *p + *q
+}
+
+// nonnil(p)
+func notCalled(p *int) int {
+	return *p
+}
+
+// nonnil(p)
+func usedAsValue(p *int) int {
+	return *p
+}
+
+// Exported functions may be called from downstream packages that pass nilable values, hence the
+// annotations on their parameters are not reported even if all local call sites satisfy them.
+// nonnil(p)
+func Exported(p *int) int {
+	return *p
+}
+
+func nilableResult() *int {
+	return nil
+}
+
+func use() {
+	allNonNil(&global)
+	allNonNil(new(int))
+	// The nil flow is reported at the annotation site of `p`.
+	someNil(nilableResult(), &global)
+	f := usedAsValue
+	f(&global)
+	Exported(&global)
+}
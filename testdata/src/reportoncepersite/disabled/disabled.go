@@ -0,0 +1,26 @@
+// Package disabled tests that, without the `report-once-per-site` flag, a dereference reached by
+// nil flows from two different nil sources is reported once for each of them.
+//
+// <nilaway no inference>
+package disabled
+
+var dummy bool
+
+type T struct {
+	f int
+}
+
+// nilable(result 0)
+func nilableResult() *T {
+	return nil
+}
+
+func derefTwoSources() int {
+	var t *T
+	if dummy {
+		t = nil
+	} else {
+		t = nilableResult()
+	}
+	return t.f //want "literal `nil` accessed field `f`" "result 0 of `nilableResult\\(\\)` accessed field `f`"
+}
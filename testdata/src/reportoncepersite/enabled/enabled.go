@@ -0,0 +1,34 @@
+// Package enabled tests that, with the `report-once-per-site` flag, a dereference reached by nil
+// flows from two different nil sources is reported only once.
+//
+// <nilaway no inference>
+package enabled
+
+var dummy bool
+
+type T struct {
+	f int
+}
+
+// nilable(result 0)
+func nilableResult() *T {
+	return nil
+}
+
+func derefTwoSources() int {
+	var t *T
+	if dummy {
+		t = nil
+	} else {
+		t = nilableResult()
+	}
+	return t.f //want "literal `nil` accessed field `f`"
+}
+
+func derefOneSource() int {
+	var t *T
+	if dummy {
+		t = nilableResult()
+	}
+	return t.f //want "result 0 of `nilableResult\\(\\)` accessed field `f`"
+}
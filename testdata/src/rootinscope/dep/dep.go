@@ -0,0 +1,26 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dep is an in-scope dependency of the rootinscope package, which returns nilable values.
+package dep
+
+var dummy bool
+
+// Get returns a nilable pointer.
+func Get() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
@@ -0,0 +1,55 @@
+//  Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rootinscope is meant to check if our root-in-scope-only flag has effect: the potential
+// nil panics rooted in the nilable values from the out-of-scope dependency are suppressed, while the
+// ones rooted in this package or in the in-scope dependency are still reported.
+package rootinscope
+
+import (
+	"rootinscope/dep"
+	"rootinscope/excluded"
+)
+
+var dummy bool
+
+func get() *int {
+	if dummy {
+		return nil
+	}
+	return new(int)
+}
+
+func useLocal() {
+	print(*get()) //want "dereferenced"
+}
+
+func useDep() {
+	print(*dep.Get()) //want "dereferenced"
+}
+
+// The nil flow passes through a local function, and it is rooted in the in-scope dependency.
+func wrap() *int {
+	return dep.Get()
+}
+
+func useWrapped() {
+	print(*wrap()) //want "dereferenced"
+}
+
+// The dependency is excluded via the exclude-pkgs flag, hence the nilable value it returns is not
+// reported.
+func useExcluded() {
+	print(*excluded.Get())
+}
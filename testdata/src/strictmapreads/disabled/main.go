@@ -0,0 +1,31 @@
+// Package disabled tests that, by default, the single-value reads from maps are not reported if
+// the same index is written to or nil-checked before (i.e., the same code as in package enabled).
+package disabled
+
+func readAfterWrite() int {
+	m := make(map[string]*int)
+	m["a"] = new(int)
+	return *m["a"]
+}
+
+func readAfterNilCheck(m map[string]*int) int {
+	if m["a"] != nil {
+		return *m["a"]
+	}
+	return 0
+}
+
+func readNested(m map[string]map[string]*int) int {
+	return *m["a"]["b"]
+}
+
+func readCommaOk(m map[string]*int) int {
+	if v, ok := m["a"]; ok {
+		return *v
+	}
+	return 0
+}
+
+func readNonPointer(m map[string]int) int {
+	return m["a"] + 1
+}
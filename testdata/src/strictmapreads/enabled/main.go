@@ -0,0 +1,32 @@
+// Package enabled tests that, with the `strict-map-reads` flag, every single-value read from a map
+// of nilable values is reported when dereferenced, even if the same index is written to or
+// nil-checked before, unless it is in the comma-ok form with `ok` checked.
+package enabled
+
+func readAfterWrite() int {
+	m := make(map[string]*int)
+	m["a"] = new(int)
+	return *m["a"] //want "lacking guarding"
+}
+
+func readAfterNilCheck(m map[string]*int) int {
+	if m["a"] != nil {
+		return *m["a"] //want "lacking guarding"
+	}
+	return 0
+}
+
+func readNested(m map[string]map[string]*int) int {
+	return *m["a"]["b"] //want "single-value read from map lacking guarding"
+}
+
+func readCommaOk(m map[string]*int) int {
+	if v, ok := m["a"]; ok {
+		return *v
+	}
+	return 0
+}
+
+func readNonPointer(m map[string]int) int {
+	return m["a"] + 1
+}
@@ -0,0 +1,38 @@
+// Package nilable tests that, by default, the results of conversions from `unsafe.Pointer` are
+// treated as nilable, since the analysis cannot see through them.
+package nilable
+
+import "unsafe"
+
+type header struct {
+	n int
+}
+
+func derefConversion(p *int) int {
+	q := (*int)(unsafe.Pointer(p))
+	return *q //want "unsafe conversion to `\\*int` dereferenced"
+}
+
+func accessFieldOfConversion(ptr unsafe.Pointer) int {
+	return (*header)(ptr).n //want "unsafe conversion to `\\*header` accessed field `n`"
+}
+
+func convertBack(ptr unsafe.Pointer) *header {
+	return (*header)(ptr)
+}
+
+func derefResultOfConversion(ptr unsafe.Pointer) int {
+	return convertBack(ptr).n //want "unsafe conversion to `\\*header` returned from `convertBack\\(\\)`"
+}
+
+func guardedConversion(ptr unsafe.Pointer) int {
+	if h := (*header)(ptr); h != nil {
+		return h.n
+	}
+	return 0
+}
+
+func conversionToUnsafePointer(p *int) uintptr {
+	// Converting to `unsafe.Pointer` (or further to `uintptr`) is fine by itself.
+	return uintptr(unsafe.Pointer(p))
+}
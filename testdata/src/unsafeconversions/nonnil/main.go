@@ -0,0 +1,33 @@
+// Package nonnil tests that, with the `nonnil-unsafe-conversions` flag, the results of conversions
+// from `unsafe.Pointer` are treated as nonnil like any other conversions.
+package nonnil
+
+import "unsafe"
+
+type header struct {
+	n int
+}
+
+func derefConversion(p *int) int {
+	q := (*int)(unsafe.Pointer(p))
+	return *q
+}
+
+func accessFieldOfConversion(ptr unsafe.Pointer) int {
+	return (*header)(ptr).n
+}
+
+func convertBack(ptr unsafe.Pointer) *header {
+	return (*header)(ptr)
+}
+
+func derefResultOfConversion(ptr unsafe.Pointer) int {
+	return convertBack(ptr).n
+}
+
+func derefNil() int {
+	var p *int
+	// The flag only affects the conversions, the nil flows through them are still tracked
+	// elsewhere as usual.
+	return *p //want "unassigned variable `p` dereferenced"
+}
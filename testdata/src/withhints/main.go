@@ -0,0 +1,56 @@
+// Package withhints tests that, with the `with-hints` flag, each error message ends with a
+// one-line hint for fixing the issue, which is chosen by the root cause and the dereference point
+// of the nil flow.
+package withhints
+
+import "errors"
+
+type T struct {
+	f int
+}
+
+func retNil() *int {
+	return nil
+}
+
+func retNilT() *T {
+	return nil
+}
+
+func retNilMap() map[string]int {
+	return nil
+}
+
+func retNilSlice() []int {
+	return nil
+}
+
+func get() (*int, error) {
+	return nil, errors.New("no value")
+}
+
+func deref() int {
+	return *retNil() //want "dereferenced\nHint: add a nil check \\(e.g., `if x != nil \\{ ... \\}`\\) before this dereference"
+}
+
+func fieldAccess() int {
+	return retNilT().f //want "accessed field `f`\nHint: add a nil check \\(e.g., `if x != nil \\{ ... \\}`\\) before this dereference"
+}
+
+func mapWrite() {
+	retNilMap()["a"] = 1 //want "Hint: initialize the map \\(e.g., with `make`\\) before writing to it"
+}
+
+func sliceIndex() int {
+	return retNilSlice()[0] //want "Hint: check the length of the slice \\(e.g., `if len\\(s\\) > i \\{ ... \\}`\\) before indexing into it"
+}
+
+func discardedError() int {
+	v, _ := get()
+	return *v //want "Hint: check the error returned by `get\\(\\)` instead of discarding it"
+}
+
+func unassignedVar() int {
+	var p *int
+	return *p //want "Hint: assign a nonnil value to the variable on all paths before using it"
+}
@@ -42,6 +42,9 @@ var BuiltinAppend = types.Universe.Lookup("append")
 // BuiltinNew is the builtin "new" function object.
 var BuiltinNew = types.Universe.Lookup("new")
 
+// BuiltinDelete is the builtin "delete" function object.
+var BuiltinDelete = types.Universe.Lookup("delete")
+
 // TypeIsDeep checks if a type is an expression that admits deep nilability, such as maps, slices, arrays, etc.
 // Only consider pointers to deep types (e.g., `var x *[]int`) as deep type,
 // not pointers to basic types (e.g., `var x *int`) or struct types (e.g., `var x *S`)
@@ -145,8 +148,12 @@ func TypeAsDeeplyStruct(typ types.Type) *types.Struct {
 	}
 
 	if ptType, ok := typ.(*types.Pointer); ok {
-		if namedType, ok := ptType.Elem().(*types.Named); ok {
-			if resType, ok := namedType.Underlying().(*types.Struct); ok {
+		switch elem := ptType.Elem().(type) {
+		case *types.Struct:
+			// pointer to an anonymous struct, e.g., `*struct{ p *int }`
+			return elem
+		case *types.Named:
+			if resType, ok := elem.Underlying().(*types.Struct); ok {
 				return resType
 			}
 		}
@@ -175,10 +182,13 @@ func UnwrapPtr(t types.Type) types.Type {
 	return t
 }
 
-// FuncIdentFromCallExpr return a function identified from a call expression, nil otherwise
+// FuncIdentFromCallExpr return a function identified from a call expression, nil otherwise. The
+// explicit instantiations of generic functions (e.g., `f[int]()`) are unwrapped to the generic
+// functions (see UnwrapInstantiation), while the calls to function values in an indexable (e.g.,
+// `fs[0]()`) are not identified.
 // nilable(result 0)
-func FuncIdentFromCallExpr(expr *ast.CallExpr) *ast.Ident {
-	switch fun := expr.Fun.(type) {
+func FuncIdentFromCallExpr(info *types.Info, expr *ast.CallExpr) *ast.Ident {
+	switch fun := UnwrapInstantiation(info, expr.Fun).(type) {
 	case *ast.Ident:
 		return fun
 	case *ast.SelectorExpr:
@@ -189,6 +199,33 @@ func FuncIdentFromCallExpr(expr *ast.CallExpr) *ast.Ident {
 	}
 }
 
+// UnwrapInstantiation returns the generic function expression `f` if `fun` is an explicit
+// instantiation of it (e.g., `f[int]` or `pkg.f[int, string]`), and `fun` itself otherwise.
+func UnwrapInstantiation(info *types.Info, fun ast.Expr) ast.Expr {
+	var x ast.Expr
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		x = index.X
+	case *ast.IndexListExpr:
+		x = index.X
+	default:
+		return fun
+	}
+	var ident *ast.Ident
+	switch x := x.(type) {
+	case *ast.Ident:
+		ident = x
+	case *ast.SelectorExpr:
+		ident = x.Sel
+	default:
+		return fun
+	}
+	if _, ok := info.Instances[ident]; !ok {
+		return fun
+	}
+	return x
+}
+
 // PartiallyQualifiedFuncName returns the name of the passed function, with the name of its receiver
 // if defined
 func PartiallyQualifiedFuncName(f *types.Func) string {
@@ -198,6 +235,24 @@ func PartiallyQualifiedFuncName(f *types.Func) string {
 	return f.Name()
 }
 
+// FullyQualifiedFuncName returns the fully-qualified name of the function, i.e.,
+// "<pkg path>.<func>" for functions and "<pkg path>.<type>.<method>" for methods (of generic types,
+// without the type arguments), and false if the function has no such name (e.g., builtins).
+func FullyQualifiedFuncName(f *types.Func) (string, bool) {
+	if f.Pkg() == nil {
+		return "", false
+	}
+	f = f.Origin()
+	if recv := f.Type().(*types.Signature).Recv(); recv != nil {
+		n, ok := UnwrapPtr(recv.Type()).(*types.Named)
+		if !ok {
+			return "", false
+		}
+		return f.Pkg().Path() + "." + n.Obj().Name() + "." + f.Name(), true
+	}
+	return f.Pkg().Path() + "." + f.Name(), true
+}
+
 // PortionAfterSep returns the suffix of the passed string `input` containing at most `occ` occurrences
 // of the separator `sep`
 func PortionAfterSep(input, sep string, occ int) string {
@@ -269,11 +324,45 @@ func TypeBarsNilness(t types.Type) bool {
 	case *types.Basic:
 		// all basic types except UntypedNil are not inhabited by nil
 		return t.Kind() != types.UntypedNil
+	case *types.TypeParam:
+		// A type parameter may be instantiated with any type in its type set, so it is inhabited
+		// by nil unless its constraint restricts the type set to types that bar nilness.
+		return typeSetBarsNilness(t.Constraint())
 	default:
 		return true
 	}
 }
 
+// typeSetBarsNilness returns true iff all types in the type set of the constraint bar nilness.
+// Note that the type set of an interface is the intersection of the type sets of its embedded
+// elements, so it suffices to find one element whose type set bars nilness.
+func typeSetBarsNilness(constraint types.Type) bool {
+	iface, ok := constraint.Underlying().(*types.Interface)
+	if !ok {
+		return TypeBarsNilness(constraint)
+	}
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		switch e := iface.EmbeddedType(i).(type) {
+		case *types.Union:
+			bars := true
+			for j := 0; j < e.Len(); j++ {
+				if !TypeBarsNilness(e.Term(j).Type()) {
+					bars = false
+					break
+				}
+			}
+			if bars {
+				return true
+			}
+		default:
+			if typeSetBarsNilness(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ExprBarsNilness returns if the expression can never be nil for the simple reason that nil does
 // not inhabit its type.
 func ExprBarsNilness(pass *analysis.Pass, expr ast.Expr) bool {